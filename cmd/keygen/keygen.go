@@ -67,6 +67,7 @@ func main() {
 
 	msgsOut1 := make([][]byte, 0, n)
 	msgsOut2 := make([][]byte, 0, n*(n-1)/2)
+	msgsOut3 := make([][]byte, 0, n)
 
 	for _, s := range states {
 		msgs1, err := helpers.PartyRoutine(nil, s)
@@ -87,7 +88,16 @@ func main() {
 	}
 
 	for _, s := range states {
-		_, err := helpers.PartyRoutine(msgsOut2, s)
+		msgs3, err := helpers.PartyRoutine(msgsOut2, s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		msgsOut3 = append(msgsOut3, msgs3...)
+	}
+
+	for _, s := range states {
+		_, err := helpers.PartyRoutine(msgsOut3, s)
 		if err != nil {
 			fmt.Println(err)
 			return
@@ -0,0 +1,200 @@
+/*
+Demo runs a distributed key generation followed by a signing session between n participants in the current process.
+
+Every participant runs in its own goroutine, and only communicates with the others through the serialized messages.Message
+it sends over Go channels, as it would over a network. The resulting signature is verified with crypto/ed25519.
+
+Usage:
+
+	go run ./example/demo [-n parties] [-t threshold] [-m message]
+*/
+package main
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/keygen"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/sign"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+// timeout aborts a protocol if a participant stops receiving messages, so that the demo never hangs.
+const timeout = 2 * time.Second
+
+func main() {
+	n := flag.Uint("n", 5, "number of parties taking part in the key generation")
+	t := flag.Uint("t", 2, "threshold, such that t+1 parties are needed to sign")
+	message := flag.String("m", "hello", "message to sign")
+	flag.Parse()
+
+	if _, _, err := run(os.Stdout, party.Size(*n), party.Size(*t), []byte(*message)); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run generates a key shared by n parties with the given threshold, signs message with the first threshold+1 of them,
+// and writes the group key and the signature to w. It returns both after they have been verified.
+func run(w io.Writer, n, threshold party.Size, message []byte) (ed25519.PublicKey, []byte, error) {
+	if threshold >= n {
+		return nil, nil, fmt.Errorf("threshold %d must be smaller than the number of parties %d", threshold, n)
+	}
+	ids := make([]party.ID, 0, n)
+	for id := party.ID(1); id <= n; id++ {
+		ids = append(ids, id)
+	}
+	partyIDs := party.NewIDSlice(ids)
+
+	// Key generation between all parties
+	states := make(map[party.ID]*state.State, len(partyIDs))
+	keygenOutputs := make(map[party.ID]*keygen.Output, len(partyIDs))
+	for _, id := range partyIDs {
+		var err error
+		states[id], keygenOutputs[id], err = frost.NewKeygenState(id, partyIDs, threshold, timeout)
+		if err != nil {
+			return nil, nil, fmt.Errorf("keygen: %w", err)
+		}
+	}
+	if err := runParties(states); err != nil {
+		return nil, nil, fmt.Errorf("keygen: %w", err)
+	}
+	public := keygenOutputs[partyIDs[0]].Public
+	for _, id := range partyIDs {
+		if !keygenOutputs[id].Public.Equal(public) {
+			return nil, nil, fmt.Errorf("keygen: party %d obtained a different public key", id)
+		}
+	}
+	groupKey := public.GroupKey.ToEd25519()
+	fmt.Fprintf(w, "parties:   %v\n", partyIDs)
+	fmt.Fprintf(w, "group key: %x\n", []byte(groupKey))
+
+	// Signing session between threshold+1 parties
+	signers := partyIDs[:threshold+1]
+	states = make(map[party.ID]*state.State, len(signers))
+	signOutputs := make(map[party.ID]*sign.Output, len(signers))
+	for _, id := range signers {
+		var err error
+		states[id], signOutputs[id], err = frost.NewSignState(signers, keygenOutputs[id].SecretKey, public, message, timeout)
+		if err != nil {
+			return nil, nil, fmt.Errorf("sign: %w", err)
+		}
+	}
+	if err := runParties(states); err != nil {
+		return nil, nil, fmt.Errorf("sign: %w", err)
+	}
+	var sig *eddsa.Signature
+	for _, id := range signers {
+		if sig == nil {
+			sig = signOutputs[id].Signature
+		} else if sig.Equal(signOutputs[id].Signature) != 1 {
+			return nil, nil, fmt.Errorf("sign: party %d obtained a different signature", id)
+		}
+	}
+	signature := sig.ToEd25519()
+	if !ed25519.Verify(groupKey, message, signature) {
+		return nil, nil, errors.New("sign: the signature is invalid")
+	}
+	fmt.Fprintf(w, "signers:   %v\n", signers)
+	fmt.Fprintf(w, "message:   %q\n", message)
+	fmt.Fprintf(w, "signature: %s\n", hex.EncodeToString(signature))
+	return groupKey, signature, nil
+}
+
+// runParties runs the protocol of every state in its own goroutine, connected to the others by a network,
+// and returns the first error reported by a participant once all of them have stopped.
+func runParties(states map[party.ID]*state.State) error {
+	net := newNetwork(states)
+	errs := make(chan error, len(states))
+	for id, s := range states {
+		go func(id party.ID, s *state.State) {
+			if err := participate(id, s, net); err != nil {
+				errs <- fmt.Errorf("party %d: %w", id, err)
+				return
+			}
+			errs <- nil
+		}(id, s)
+	}
+
+	var firstErr error
+	for range states {
+		if err := <-errs; err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// participate is the message loop of party id: it gives every message it receives to s,
+// and sends the messages produced by s on net, until the protocol has finished.
+func participate(id party.ID, s *state.State, net *network) error {
+	// The first round does not need any message
+	if err := net.send(id, s.ProcessAll()); err != nil {
+		return err
+	}
+	for {
+		select {
+		case data := <-net.inboxes[id]:
+			var msg messages.Message
+			if err := msg.UnmarshalBinary(data); err != nil {
+				return fmt.Errorf("failed to unmarshal message: %w", err)
+			}
+			// An error here does not abort the protocol, which reports it through s.WaitForError if it must
+			_ = s.HandleMessage(&msg)
+			if err := net.send(id, s.ProcessAll()); err != nil {
+				return err
+			}
+		case <-s.Done():
+			return s.WaitForError()
+		}
+	}
+}
+
+// network delivers the serialized messages of a protocol to the inbox of their recipients.
+// The inboxes are large enough to hold all the messages of a keygen or sign session, so sending never blocks.
+type network struct {
+	inboxes map[party.ID]chan []byte
+}
+
+func newNetwork(states map[party.ID]*state.State) *network {
+	inboxes := make(map[party.ID]chan []byte, len(states))
+	for id := range states {
+		inboxes[id] = make(chan []byte, 4*len(states))
+	}
+	return &network{inboxes: inboxes}
+}
+
+// send marshals msgs from party from, and sends each of them to all other parties if it is a broadcast,
+// or to its recipient otherwise.
+func (n *network) send(from party.ID, msgs []*messages.Message) error {
+	for _, msg := range msgs {
+		data, err := msg.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("failed to marshal message: %w", err)
+		}
+		if !msg.IsBroadcast() {
+			inbox, ok := n.inboxes[msg.To]
+			if !ok {
+				return fmt.Errorf("message sent to unknown party %d", msg.To)
+			}
+			inbox <- data
+			continue
+		}
+		for id, inbox := range n.inboxes {
+			if id != from {
+				inbox <- data
+			}
+		}
+	}
+	return nil
+}
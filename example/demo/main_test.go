@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	message := []byte("demo")
+	var out bytes.Buffer
+	groupKey, sig, err := run(&out, 5, 2, message)
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(groupKey, message, sig))
+
+	// The printed group key and signature are enough to verify the message
+	printed := map[string][]byte{}
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), ":", 2)
+		if len(fields) != 2 {
+			continue
+		}
+		if b, err := hex.DecodeString(strings.TrimSpace(fields[1])); err == nil {
+			printed[fields[0]] = b
+		}
+	}
+	require.Len(t, printed["group key"], ed25519.PublicKeySize)
+	require.Len(t, printed["signature"], ed25519.SignatureSize)
+	assert.True(t, ed25519.Verify(printed["group key"], message, printed["signature"]))
+	assert.Equal(t, []byte(groupKey), printed["group key"])
+	assert.Equal(t, sig, printed["signature"])
+
+	_, _, err = run(&out, 3, 3, message)
+	assert.Error(t, err, "t+1 signers are needed")
+}
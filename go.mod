@@ -1,8 +1,16 @@
 module github.com/taurusgroup/frost-ed25519
 
-go 1.14
+go 1.26.0
 
 require (
 	filippo.io/edwards25519 v1.1.0
 	github.com/stretchr/testify v1.10.0
+	golang.org/x/crypto v0.57.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.48.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,96 @@
+package eddsa
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+// batchCoefficientSize is the number of random bytes used for each coefficient of the linear combination.
+// 128 bits are enough to make the probability of accepting an invalid batch negligible.
+const batchCoefficientSize = 16
+
+// BatchVerify verifies that for each index i, signatures[i] is a valid signature of messages[i]
+// under publicKeys[i].
+//
+// It samples random coefficients zᵢ using crypto/rand, and checks the single equation
+//
+//	[∑ zᵢ • sᵢ] B - ∑ [zᵢ • cᵢ] Aᵢ - ∑ [zᵢ] Rᵢ == 0
+//
+// with one multi-scalar multiplication. If the batch is invalid, each signature is then verified
+// individually, and the indices of the invalid signatures are returned.
+//
+// If the slices have different lengths, or if the randomness could not be sampled,
+// BatchVerify returns false and a nil slice. An empty batch is valid.
+func BatchVerify(publicKeys []*PublicKey, messages [][]byte, signatures []*Signature) (bool, []int) {
+	n := len(signatures)
+	if len(publicKeys) != n || len(messages) != n {
+		return false, nil
+	}
+	if n == 0 {
+		return true, nil
+	}
+
+	if ok, err := batchVerify(publicKeys, messages, signatures); err != nil {
+		return false, nil
+	} else if ok {
+		return true, nil
+	}
+
+	invalid := make([]int, 0, 1)
+	for i := range signatures {
+		if !publicKeys[i].Verify(messages[i], signatures[i]) {
+			invalid = append(invalid, i)
+		}
+	}
+	return false, invalid
+}
+
+// batchVerify performs the randomized check described in BatchVerify, and assumes all slices have the same length.
+func batchVerify(publicKeys []*PublicKey, messages [][]byte, signatures []*Signature) (bool, error) {
+	n := len(signatures)
+
+	// The terms are ( ∑ zᵢ • sᵢ, B ), ( - zᵢ • cᵢ, Aᵢ ) and ( - zᵢ, Rᵢ )
+	scalars := make([]*ristretto.Scalar, 0, 2*n+1)
+	points := make([]*ristretto.Element, 0, 2*n+1)
+
+	sumS := ristretto.NewScalar()
+	scalars = append(scalars, sumS)
+	points = append(points, ristretto.NewGeneratorElement())
+
+	randomBytes := make([]byte, batchCoefficientSize*n)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return false, fmt.Errorf("eddsa.BatchVerify: failed to sample coefficients: %w", err)
+	}
+
+	coefficientBytes := make([]byte, 32)
+	for i, sig := range signatures {
+		var z ristretto.Scalar
+		copy(coefficientBytes, randomBytes[i*batchCoefficientSize:(i+1)*batchCoefficientSize])
+		if _, err := z.SetCanonicalBytes(coefficientBytes); err != nil {
+			return false, fmt.Errorf("eddsa.BatchVerify: %w", err)
+		}
+
+		c := ComputeChallenge(&sig.R, publicKeys[i], messages[i])
+
+		// ∑ zᵢ • sᵢ
+		sumS.MultiplyAdd(&z, &sig.S, sumS)
+
+		// - zᵢ • cᵢ
+		zc := new(ristretto.Scalar).Multiply(&z, c)
+		zc.Negate(zc)
+		scalars = append(scalars, zc)
+		points = append(points, &publicKeys[i].pk)
+
+		// - zᵢ
+		scalars = append(scalars, new(ristretto.Scalar).Negate(&z))
+		points = append(points, &sig.R)
+	}
+
+	var result ristretto.Element
+	if _, err := result.VarTimeMultiScalarMult(scalars, points); err != nil {
+		return false, fmt.Errorf("eddsa.BatchVerify: %w", err)
+	}
+	return result.IsIdentity() == 1, nil
+}
@@ -0,0 +1,83 @@
+package eddsa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+func generateBatch(t testing.TB, n int) ([]*PublicKey, [][]byte, []*Signature) {
+	publicKeys := make([]*PublicKey, n)
+	msgs := make([][]byte, n)
+	signatures := make([]*Signature, n)
+	for i := 0; i < n; i++ {
+		sig, pk, err := generateSignature()
+		require.NoError(t, err, "failed to generate signature")
+		publicKeys[i] = pk
+		msgs[i] = []byte(sampleMessage)
+		signatures[i] = sig
+	}
+	return publicKeys, msgs, signatures
+}
+
+func TestBatchVerify(t *testing.T) {
+	publicKeys, msgs, signatures := generateBatch(t, 10)
+
+	ok, invalid := BatchVerify(publicKeys, msgs, signatures)
+	assert.True(t, ok, "valid batch should verify")
+	assert.Empty(t, invalid)
+
+	ok, invalid = BatchVerify(nil, nil, nil)
+	assert.True(t, ok, "empty batch should verify")
+	assert.Empty(t, invalid)
+
+	// Corrupt a single signature
+	corrupted := make([]*Signature, len(signatures))
+	copy(corrupted, signatures)
+	bad := *signatures[3]
+	bad.S.Add(&bad.S, ristretto.NewScalar().SetUint64(1))
+	corrupted[3] = &bad
+	ok, invalid = BatchVerify(publicKeys, msgs, corrupted)
+	assert.False(t, ok, "batch with a corrupted signature should not verify")
+	assert.Equal(t, []int{3}, invalid)
+
+	// Wrong message for two signatures
+	wrongMsgs := make([][]byte, len(msgs))
+	copy(wrongMsgs, msgs)
+	wrongMsgs[0] = []byte("another message")
+	wrongMsgs[9] = []byte("yet another message")
+	ok, invalid = BatchVerify(publicKeys, wrongMsgs, signatures)
+	assert.False(t, ok)
+	assert.Equal(t, []int{0, 9}, invalid)
+
+	// Two invalid signatures which cancel out would pass a batch check without random coefficients
+	var delta ristretto.Scalar
+	delta.SetUint64(42)
+	cancel1, cancel2 := *signatures[1], *signatures[2]
+	cancel1.S.Add(&cancel1.S, &delta)
+	cancel2.S.Subtract(&cancel2.S, &delta)
+	cancelling := make([]*Signature, len(signatures))
+	copy(cancelling, signatures)
+	cancelling[1], cancelling[2] = &cancel1, &cancel2
+	ok, invalid = BatchVerify(publicKeys, msgs, cancelling)
+	assert.False(t, ok, "batch with cancelling signatures should not verify")
+	assert.Equal(t, []int{1, 2}, invalid)
+}
+
+func TestBatchVerify_Lengths(t *testing.T) {
+	publicKeys, msgs, signatures := generateBatch(t, 3)
+
+	ok, invalid := BatchVerify(publicKeys[:2], msgs, signatures)
+	assert.False(t, ok)
+	assert.Nil(t, invalid)
+
+	ok, invalid = BatchVerify(publicKeys, msgs[:2], signatures)
+	assert.False(t, ok)
+	assert.Nil(t, invalid)
+
+	ok, invalid = BatchVerify(publicKeys, msgs, signatures[:2])
+	assert.False(t, ok)
+	assert.Nil(t, invalid)
+}
@@ -0,0 +1,42 @@
+package eddsa
+
+import (
+	"crypto/sha512"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+// Hasher computes the hash functions used by the signing and key generation protocols.
+// Every call is given a label, which separates the different uses of the hash function,
+// followed by the data to hash.
+//
+// SHA512 is the default, and is required for the signatures to be compatible with Ed25519.
+// Another Hasher can be used to define a different ciphersuite, in which case all parties,
+// as well as the verifiers, must use the same one.
+type Hasher interface {
+	// Hash returns the digest of label ∥ m₁ ∥ ... ∥ mₙ.
+	Hash(label string, m ...[]byte) []byte
+
+	// HashToScalar sets s to the digest of label ∥ m₁ ∥ ... ∥ mₙ, interpreted as an integer modulo l, and returns s.
+	HashToScalar(s *ristretto.Scalar, label string, m ...[]byte) *ristretto.Scalar
+}
+
+// SHA512 is the Hasher of Ed25519.
+var SHA512 Hasher = sha512Hasher{}
+
+type sha512Hasher struct{}
+
+func (sha512Hasher) Hash(label string, m ...[]byte) []byte {
+	h := sha512.New()
+	_, _ = h.Write([]byte(label))
+	for _, b := range m {
+		_, _ = h.Write(b)
+	}
+	return h.Sum(nil)
+}
+
+func (h sha512Hasher) HashToScalar(s *ristretto.Scalar, label string, m ...[]byte) *ristretto.Scalar {
+	// SetUniformBytes only returns an error when the length is wrong, and the digest is 64 bytes long
+	_, _ = s.SetUniformBytes(h.Hash(label, m...))
+	return s
+}
@@ -0,0 +1,59 @@
+package eddsa
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// jwk is an Octet Key Pair JSON Web Key, as defined in RFC 8037.
+type jwk struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Kid string `json:"kid,omitempty"`
+}
+
+const (
+	jwkKeyType = "OKP"
+	jwkCurve   = "Ed25519"
+)
+
+// MarshalJWK returns the PublicKey encoded as an OKP JSON Web Key with "crv": "Ed25519" (RFC 8037).
+func (pk *PublicKey) MarshalJWK() ([]byte, error) {
+	return pk.MarshalJWKWithKeyID("")
+}
+
+// MarshalJWKWithKeyID is like MarshalJWK, but sets the "kid" parameter of the JWK to kid if it is not empty.
+func (pk *PublicKey) MarshalJWKWithKeyID(kid string) ([]byte, error) {
+	return json.Marshal(jwk{
+		Kty: jwkKeyType,
+		Crv: jwkCurve,
+		X:   base64.RawURLEncoding.EncodeToString(pk.ToEd25519()),
+		Kid: kid,
+	})
+}
+
+// ParseJWK parses an OKP JSON Web Key containing an Ed25519 public key, and returns it along with its "kid".
+// The "x" parameter must be the unpadded base64url encoding of a valid public key, as accepted by PublicKeyFromEd25519.
+func ParseJWK(data []byte) (pk *PublicKey, kid string, err error) {
+	var key jwk
+	if err = json.Unmarshal(data, &key); err != nil {
+		return nil, "", fmt.Errorf("eddsa: invalid JWK: %w", err)
+	}
+	if key.Kty != jwkKeyType {
+		return nil, "", fmt.Errorf("eddsa: unsupported JWK key type %q", key.Kty)
+	}
+	if key.Crv != jwkCurve {
+		return nil, "", fmt.Errorf("eddsa: unsupported JWK curve %q", key.Crv)
+	}
+	x, err := base64.RawURLEncoding.Strict().DecodeString(key.X)
+	if err != nil {
+		return nil, "", errors.New("eddsa: invalid JWK: x is not base64url encoded")
+	}
+	if pk, err = PublicKeyFromEd25519(x); err != nil {
+		return nil, "", err
+	}
+	return pk, key.Kid, nil
+}
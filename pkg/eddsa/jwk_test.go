@@ -0,0 +1,71 @@
+package eddsa
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicKey_MarshalJWK(t *testing.T) {
+	pkBytes, skBytes, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, pk := newKeyPair(skBytes)
+
+	data, err := pk.MarshalJWK()
+	require.NoError(t, err)
+
+	var fields map[string]string
+	require.NoError(t, json.Unmarshal(data, &fields))
+	assert.Equal(t, map[string]string{
+		"kty": "OKP",
+		"crv": "Ed25519",
+		"x":   base64.RawURLEncoding.EncodeToString(pkBytes),
+	}, fields)
+
+	pkDecoded, kid, err := ParseJWK(data)
+	require.NoError(t, err)
+	assert.True(t, pk.Equal(pkDecoded))
+	assert.Empty(t, kid)
+
+	data, err = pk.MarshalJWKWithKeyID("frost-2021")
+	require.NoError(t, err)
+	pkDecoded, kid, err = ParseJWK(data)
+	require.NoError(t, err)
+	assert.True(t, pk.Equal(pkDecoded))
+	assert.Equal(t, "frost-2021", kid)
+}
+
+func TestParseJWK_Invalid(t *testing.T) {
+	pkBytes, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	x := base64.RawURLEncoding.EncodeToString(pkBytes)
+
+	// y = 2 is not the y-coordinate of a point on the curve
+	offCurve, _ := hex.DecodeString("0200000000000000000000000000000000000000000000000000000000000000")
+	// p + 3 is a non-canonical encoding of y = 3, which is on the curve
+	nonCanonical, _ := hex.DecodeString("f0ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")
+
+	for name, key := range map[string]jwk{
+		"kty":           {Kty: "EC", Crv: "Ed25519", X: x},
+		"crv":           {Kty: "OKP", Crv: "X25519", X: x},
+		"padding":       {Kty: "OKP", Crv: "Ed25519", X: base64.URLEncoding.EncodeToString(pkBytes)},
+		"base64":        {Kty: "OKP", Crv: "Ed25519", X: base64.StdEncoding.EncodeToString(pkBytes) + "+/"},
+		"short":         {Kty: "OKP", Crv: "Ed25519", X: x[:20]},
+		"off-curve":     {Kty: "OKP", Crv: "Ed25519", X: base64.RawURLEncoding.EncodeToString(offCurve)},
+		"non-canonical": {Kty: "OKP", Crv: "Ed25519", X: base64.RawURLEncoding.EncodeToString(nonCanonical)},
+	} {
+		data, err := json.Marshal(key)
+		require.NoError(t, err)
+		_, _, err = ParseJWK(data)
+		assert.Error(t, err, name)
+	}
+
+	_, _, err = ParseJWK([]byte("{"))
+	assert.Error(t, err)
+}
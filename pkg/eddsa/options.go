@@ -0,0 +1,181 @@
+package eddsa
+
+import (
+	"crypto"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+// dom2Prefix is the constant string of the dom2 function of RFC 8032, Section 2.
+const dom2Prefix = "SigEd25519 no Ed25519 collisions"
+
+// Options selects the Ed25519 variant with which a signature is computed and verified.
+// It mirrors ed25519.Options, and a signature created with some Options verifies
+// with ed25519.VerifyWithOptions given the same Options.
+// The zero value corresponds to regular Ed25519.
+type Options struct {
+	// Hash is zero for regular Ed25519, or crypto.SHA512 for Ed25519ph.
+	// In the latter case, the message is expected to be the 64 byte SHA-512 digest of the actual message.
+	Hash crypto.Hash
+
+	// Context binds the signature to its purpose, and can be at most MaxContextLength bytes long.
+	// A non-empty Context without Hash selects Ed25519ctx.
+	// An empty Context without Hash is regular Ed25519, so that it matches the no-context path.
+	Context string
+
+	// Cofactored selects the cofactored verification equation [8][S]B = [8]R + [8][k]A,
+	// instead of the strict (cofactorless) equation [S]B = R + [k]A of RFC 8032, which is the default.
+	// The two only differ when R or A has a small-order component.
+	// Signatures produced by FROST use R and A in the prime-order subgroup, and verify with both.
+	Cofactored bool
+
+	// Hasher computes the challenge. If it is nil, SHA512 is used, as required by Ed25519.
+	Hasher Hasher
+
+	// AssociatedData is covered by the signature along with the message, such as metadata which is not part of it.
+	// The challenge is computed over MessageWithAssociatedData(message, AssociatedData) instead of the message,
+	// and the signature verifies with ed25519.Verify given that encoding.
+	// With Hash set, the encoding no longer is a 64 byte digest, so the signature does not verify with ed25519.VerifyWithOptions.
+	// An empty AssociatedData matches the path without associated data.
+	AssociatedData []byte
+}
+
+// associatedDataDomainSeparation precedes the encoding of a message with associated data.
+const associatedDataDomainSeparation = "FROST-Ed25519-AD"
+
+// MessageWithAssociatedData returns the encoding of message and ad which is signed when Options.AssociatedData is ad:
+//
+//	"FROST-Ed25519-AD" ∥ uint64(len(ad)) ∥ ad ∥ message
+//
+// where the length is big-endian, so that two different pairs of message and associated data have different encodings.
+// If ad is empty, message is returned unchanged.
+//
+// The signature is an Ed25519 signature of this encoding, so it is also valid for the plain message equal to the encoding,
+// without associated data. The prefix makes such messages unlikely, but an application which signs messages both with
+// and without associated data under the same key must not sign plain messages starting with "FROST-Ed25519-AD".
+func MessageWithAssociatedData(message, ad []byte) []byte {
+	if len(ad) == 0 {
+		return message
+	}
+	prefixSize := len(associatedDataDomainSeparation) + 8
+	out := make([]byte, prefixSize, prefixSize+len(ad)+len(message))
+	copy(out, associatedDataDomainSeparation)
+	binary.BigEndian.PutUint64(out[len(associatedDataDomainSeparation):], uint64(len(ad)))
+	out = append(out, ad...)
+	return append(out, message...)
+}
+
+// hasher returns opts.Hasher, or SHA512 if it is not set.
+func (opts *Options) hasher() Hasher {
+	if opts.Hasher == nil {
+		return SHA512
+	}
+	return opts.Hasher
+}
+
+// MaxContextLength is the maximum length in bytes of Options.Context.
+const MaxContextLength = 255
+
+var (
+	ErrOptionsHash         = errors.New("eddsa: expected Options.Hash to be zero or crypto.SHA512")
+	ErrOptionsPrehashedLen = errors.New("eddsa: prehashed message must be 64 bytes long")
+	ErrOptionsContextLen   = errors.New("eddsa: context must be at most 255 bytes long")
+	ErrInvalidSignature    = errors.New("eddsa: invalid signature")
+
+	// ErrNonCanonicalSignature wraps ErrInvalidSignature, and is returned when R or S is not canonically encoded.
+	// In particular, S must be reduced modulo l, so that a signature cannot be modified by adding l to it.
+	ErrNonCanonicalSignature = fmt.Errorf("%w: non-canonical encoding", ErrInvalidSignature)
+)
+
+// Validate returns an error if the Options are not supported, or cannot be used with the given message.
+func (opts *Options) Validate(message []byte) error {
+	if len(opts.Context) > MaxContextLength {
+		return ErrOptionsContextLen
+	}
+	switch opts.Hash {
+	case crypto.Hash(0):
+	case crypto.SHA512:
+		if len(message) != sha512.Size {
+			return ErrOptionsPrehashedLen
+		}
+	default:
+		return ErrOptionsHash
+	}
+	return nil
+}
+
+// prefix returns the domain separation string prepended to the challenge hash.
+// It is dom2(1, Context) for Ed25519ph, dom2(0, Context) for Ed25519ctx, and empty for Ed25519.
+func (opts *Options) prefix() []byte {
+	var phflag byte
+	switch {
+	case opts.Hash == crypto.SHA512:
+		phflag = 1
+	case opts.Context != "":
+		phflag = 0
+	default:
+		return nil
+	}
+	out := make([]byte, 0, len(dom2Prefix)+2+len(opts.Context))
+	out = append(out, dom2Prefix...)
+	out = append(out, phflag, byte(len(opts.Context)))
+	out = append(out, opts.Context...)
+	return out
+}
+
+// ComputeChallengeWithOptions computes the value H(dom2(F,C), R, A, M) for the variant selected by opts.
+// With the zero Options, it is the same as ComputeChallenge.
+// If opts.AssociatedData is not empty, M is MessageWithAssociatedData(message, opts.AssociatedData).
+func ComputeChallengeWithOptions(R *ristretto.Element, groupKey *PublicKey, message []byte, opts *Options) (*ristretto.Scalar, error) {
+	if err := opts.Validate(message); err != nil {
+		return nil, err
+	}
+	return computeChallengeBytes(opts.hasher(), opts.prefix(), R.BytesEd25519(), groupKey.ToEd25519(), opts.signedMessage(message)), nil
+}
+
+// signedMessage returns the message from which the challenge is computed, which includes the associated data.
+func (opts *Options) signedMessage(message []byte) []byte {
+	return MessageWithAssociatedData(message, opts.AssociatedData)
+}
+
+// computeChallengeBytes computes H(prefix, R, A, M) given the Ed25519 encodings of R and A.
+func computeChallengeBytes(h Hasher, prefix, R, A, message []byte) *ristretto.Scalar {
+	var s ristretto.Scalar
+	return h.HashToScalar(&s, string(prefix), R, A, message)
+}
+
+// VerifyWithOptions checks that sig is a valid signature of message for the variant selected by opts.
+// It returns nil if the signature is valid.
+//
+// If Options.Cofactored is set, the cofactored equation [8][s]B = [8]R + [8][c]A is checked instead of the strict one.
+// The R of a Signature and the PublicKey are elements of the prime-order group, so both equations accept the same signatures.
+// Use VerifyEd25519 to verify a signature in its Ed25519 encoding, where R may have a small-order component.
+func (pk *PublicKey) VerifyWithOptions(message []byte, sig *Signature, opts *Options) error {
+	challenge, err := ComputeChallengeWithOptions(&sig.R, pk, message, opts)
+	if err != nil {
+		return err
+	}
+
+	var publicNeg, RPrime ristretto.Element
+	publicNeg.Negate(&pk.pk)
+	// RPrime = [c](-A) + [s]B
+	RPrime.VarTimeDoubleScalarBaseMult(challenge, &publicNeg, &sig.S)
+
+	if !opts.Cofactored {
+		if RPrime.Equal(&sig.R) != 1 {
+			return ErrInvalidSignature
+		}
+		return nil
+	}
+
+	// [8](RPrime - R) = 0
+	RPrime.Subtract(&RPrime, &sig.R)
+	if RPrime.MultByCofactor(&RPrime).IsIdentity() != 1 {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package eddsa
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/scalar"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+// signWithOptions generates a signature for the variant selected by opts.
+func (sk *SecretShare) signWithOptions(message []byte, opts *Options) (*Signature, error) {
+	var sig Signature
+
+	r := scalar.NewScalarRandom()
+	sig.R.ScalarBaseMult(r)
+
+	pk := PublicKey{pk: sk.Public}
+
+	c, err := ComputeChallengeWithOptions(&sig.R, &pk, message, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	sig.S.MultiplyAdd(&sk.Secret, c, r)
+	return &sig, nil
+}
+
+func TestComputeChallengeWithOptions(t *testing.T) {
+	sig, pk, err := generateSignature()
+	require.NoError(t, err)
+
+	c, err := ComputeChallengeWithOptions(&sig.R, pk, []byte(sampleMessage), &Options{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, c.Equal(ComputeChallenge(&sig.R, pk, []byte(sampleMessage))), "zero Options should be Ed25519")
+
+	digest := sha512.Sum512([]byte(sampleMessage))
+	cPh, err := ComputeChallengeWithOptions(&sig.R, pk, digest[:], &Options{Hash: crypto.SHA512})
+	require.NoError(t, err)
+	assert.Equal(t, 0, cPh.Equal(ComputeChallenge(&sig.R, pk, digest[:])), "Ed25519ph should be domain separated")
+
+	_, err = ComputeChallengeWithOptions(&sig.R, pk, []byte(sampleMessage), &Options{Hash: crypto.SHA512})
+	assert.ErrorIs(t, err, ErrOptionsPrehashedLen)
+
+	_, err = ComputeChallengeWithOptions(&sig.R, pk, digest[:32], &Options{Hash: crypto.SHA256})
+	assert.ErrorIs(t, err, ErrOptionsHash)
+}
+
+func TestPublicKey_VerifyWithOptions(t *testing.T) {
+	_, skBytes, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sk, pk := newKeyPair(skBytes)
+	share := NewSecretShare(1, sk)
+
+	digest := sha512.Sum512([]byte(sampleMessage))
+	opts := &Options{Hash: crypto.SHA512}
+	sig, err := share.signWithOptions(digest[:], opts)
+	require.NoError(t, err)
+
+	assert.NoError(t, pk.VerifyWithOptions(digest[:], sig, opts))
+	assert.NoError(t, ed25519.VerifyWithOptions(pk.ToEd25519(), digest[:], sig.ToEd25519(), &ed25519.Options{Hash: crypto.SHA512}))
+	assert.NoError(t, pk.VerifyWithOptions(digest[:], sig, &Options{Hash: crypto.SHA512, Cofactored: true}))
+	assert.ErrorIs(t, pk.VerifyWithOptions(digest[:], sig, &Options{Cofactored: true}), ErrInvalidSignature)
+	other := *sig
+	other.S.Add(&other.S, ristretto.NewScalar().SetUint64(1))
+	assert.ErrorIs(t, pk.VerifyWithOptions(digest[:], &other, &Options{Hash: crypto.SHA512, Cofactored: true}), ErrInvalidSignature)
+
+	// An Ed25519ph signature is not a valid Ed25519 signature of the digest
+	assert.ErrorIs(t, pk.VerifyWithOptions(digest[:], sig, &Options{}), ErrInvalidSignature)
+	assert.False(t, pk.Verify(digest[:], sig))
+	assert.False(t, ed25519.Verify(pk.ToEd25519(), digest[:], sig.ToEd25519()))
+
+}
+
+func TestPublicKey_VerifyWithOptions_Context(t *testing.T) {
+	_, skBytes, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sk, pk := newKeyPair(skBytes)
+	share := NewSecretShare(1, sk)
+	message := []byte(sampleMessage)
+
+	optsA := &Options{Context: "protocol A"}
+	optsB := &Options{Context: "protocol B"}
+	sig, err := share.signWithOptions(message, optsA)
+	require.NoError(t, err)
+
+	assert.NoError(t, pk.VerifyWithOptions(message, sig, optsA))
+	assert.NoError(t, ed25519.VerifyWithOptions(pk.ToEd25519(), message, sig.ToEd25519(), &ed25519.Options{Context: optsA.Context}))
+	assert.ErrorIs(t, pk.VerifyWithOptions(message, sig, optsB), ErrInvalidSignature)
+	assert.ErrorIs(t, pk.VerifyWithOptions(message, sig, &Options{}), ErrInvalidSignature)
+
+	// The empty context is plain Ed25519
+	sig, err = share.signWithOptions(message, &Options{Context: ""})
+	require.NoError(t, err)
+	assert.True(t, pk.Verify(message, sig))
+	assert.ErrorIs(t, pk.VerifyWithOptions(message, sig, optsA), ErrInvalidSignature)
+
+	// Ed25519ph with a context
+	digest := sha512.Sum512(message)
+	optsPh := &Options{Hash: crypto.SHA512, Context: "protocol A"}
+	sig, err = share.signWithOptions(digest[:], optsPh)
+	require.NoError(t, err)
+	assert.NoError(t, ed25519.VerifyWithOptions(pk.ToEd25519(), digest[:], sig.ToEd25519(), &ed25519.Options{Hash: crypto.SHA512, Context: optsPh.Context}))
+	assert.ErrorIs(t, pk.VerifyWithOptions(digest[:], sig, &Options{Hash: crypto.SHA512}), ErrInvalidSignature)
+
+	// The longest context allowed by RFC 8032
+	longest := &Options{Context: strings.Repeat("c", MaxContextLength)}
+	sig, err = share.signWithOptions(message, longest)
+	require.NoError(t, err)
+	assert.NoError(t, ed25519.VerifyWithOptions(pk.ToEd25519(), message, sig.ToEd25519(), &ed25519.Options{Context: longest.Context}))
+
+	tooLong := &Options{Context: string(make([]byte, MaxContextLength+1))}
+	_, err = share.signWithOptions(message, tooLong)
+	assert.ErrorIs(t, err, ErrOptionsContextLen)
+}
+
+func TestOptions_prefix(t *testing.T) {
+	// dom2(0, "ctx") for Ed25519ctx
+	expected := append([]byte("SigEd25519 no Ed25519 collisions"), 0, 3, 'c', 't', 'x')
+	assert.Equal(t, expected, (&Options{Context: "ctx"}).prefix())
+
+	// dom2(1, "") for Ed25519ph
+	expected = append([]byte("SigEd25519 no Ed25519 collisions"), 1, 0)
+	assert.Equal(t, expected, (&Options{Hash: crypto.SHA512}).prefix())
+
+	assert.Empty(t, (&Options{}).prefix())
+}
+
+func TestOptions_AssociatedData(t *testing.T) {
+	_, skBytes, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sk, pk := newKeyPair(skBytes)
+	share := NewSecretShare(1, sk)
+	message := []byte(sampleMessage)
+
+	optsA := &Options{AssociatedData: []byte("policy 1, 2024-01-01")}
+	optsB := &Options{AssociatedData: []byte("policy 2, 2024-01-01")}
+	sig, err := share.signWithOptions(message, optsA)
+	require.NoError(t, err)
+	assert.NoError(t, pk.VerifyWithOptions(message, sig, optsA))
+	assert.NoError(t, pk.VerifyEd25519(message, sig.ToEd25519(), optsA))
+	assert.True(t, ed25519.Verify(pk.ToEd25519(), MessageWithAssociatedData(message, optsA.AssociatedData), sig.ToEd25519()))
+	// The encoding is also a plain message, which is why it is prefixed
+	assert.True(t, pk.Verify(MessageWithAssociatedData(message, optsA.AssociatedData), sig))
+
+	// Signatures with different associated data don't cross-verify
+	assert.ErrorIs(t, pk.VerifyWithOptions(message, sig, optsB), ErrInvalidSignature)
+	assert.ErrorIs(t, pk.VerifyWithOptions(message, sig, &Options{}), ErrInvalidSignature)
+	assert.False(t, pk.Verify(message, sig))
+
+	// Moving bytes between the message and the associated data gives a different signed message
+	moved := &Options{AssociatedData: append(append([]byte(nil), optsA.AssociatedData...), message[0])}
+	assert.ErrorIs(t, pk.VerifyWithOptions(message[1:], sig, moved), ErrInvalidSignature)
+	assert.NotEqual(t, MessageWithAssociatedData(message, optsA.AssociatedData), MessageWithAssociatedData(message[1:], moved.AssociatedData))
+
+	// The empty associated data matches the path without associated data
+	for _, empty := range [][]byte{nil, {}} {
+		sig, err = share.signWithOptions(message, &Options{AssociatedData: empty})
+		require.NoError(t, err)
+		assert.True(t, pk.Verify(message, sig))
+		assert.True(t, ed25519.Verify(pk.ToEd25519(), message, sig.ToEd25519()))
+		assert.Equal(t, message, MessageWithAssociatedData(message, empty))
+	}
+
+	expected := append([]byte("FROST-Ed25519-AD"), 0, 0, 0, 0, 0, 0, 0, 2, 'a', 'd')
+	assert.Equal(t, append(expected, message...), MessageWithAssociatedData(message, []byte("ad")))
+}
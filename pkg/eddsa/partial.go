@@ -0,0 +1,76 @@
+package eddsa
+
+import (
+	"fmt"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+// Commitment contains the nonce commitments (Dᵢ, Eᵢ) sent by a signer in the first round of a signing session,
+// and its binding factor ρᵢ derived from the commitments of all signers.
+type Commitment struct {
+	D, E          ristretto.Element
+	BindingFactor ristretto.Scalar
+}
+
+// Nonce returns the commitment Rᵢ = Dᵢ + [ρᵢ]Eᵢ of the signer to its nonce.
+func (c *Commitment) Nonce() *ristretto.Element {
+	var Ri ristretto.Element
+	Ri.ScalarMult(&c.BindingFactor, &c.E)
+	return Ri.Add(&Ri, &c.D)
+}
+
+// GroupCommitment returns the commitment R = ∑ Rᵢ of a signing session, given the commitments of all signers.
+// It is the R of the resulting Signature, from which the challenge is computed with ComputeChallengeWithOptions.
+func GroupCommitment(commitments map[party.ID]*Commitment) *ristretto.Element {
+	R := ristretto.NewIdentityElement()
+	for _, c := range commitments {
+		R.Add(R, c.Nonce())
+	}
+	return R
+}
+
+// VerifyPartialSignature returns true if partial is a valid signature share zᵢ of a signer, that is if
+//
+//	[zᵢ]B = Rᵢ + [λᵢ • c]Yᵢ, where Rᵢ = Dᵢ + [ρᵢ]Eᵢ
+//
+// where B is the base point, Yᵢ the signer's publicShare, c the challenge of the session,
+// λᵢ the Lagrange coefficient of the signer for the set of signers, and (Dᵢ, Eᵢ, ρᵢ) are given by commitment.
+//
+// Only public data is needed, so a party which holds no share can check the signature shares of a session.
+// The product [λᵢ]Yᵢ is returned by Public.LagrangeShare.
+func VerifyPartialSignature(partial *ristretto.Scalar, commitment *Commitment, publicShare *ristretto.Element, challenge, lambda *ristretto.Scalar) bool {
+	var public ristretto.Element
+	// [λᵢ]Yᵢ
+	public.ScalarMult(lambda, publicShare)
+	return VerifyShare(partial, commitment.Nonce(), &public, challenge)
+}
+
+// VerifyShare returns true if [zᵢ]B = Rᵢ + [c]Aᵢ, where Rᵢ is the nonce commitment of the signer, as returned by Commitment.Nonce,
+// and Aᵢ = [λᵢ]Yᵢ, as returned by Public.LagrangeShare.
+// It is VerifyPartialSignature, for a caller which already computed Rᵢ and Aᵢ, such as the signing protocol.
+func VerifyShare(partial *ristretto.Scalar, Ri, Ai *ristretto.Element, challenge *ristretto.Scalar) bool {
+	var publicNeg, RPrime ristretto.Element
+	publicNeg.Negate(Ai)
+
+	// RPrime = [c](-Aᵢ) + [zᵢ]B
+	RPrime.VarTimeDoubleScalarBaseMult(challenge, &publicNeg, partial)
+	return RPrime.Equal(Ri) == 1
+}
+
+// LagrangeShare returns [λᵢ]Yᵢ, the public share Yᵢ of party id multiplied by its Lagrange coefficient λᵢ for quorum.
+// Summed over a quorum, these give the GroupKey.
+//
+// It returns an error if id is not contained in quorum, or if quorum is not a set of parties contained in s.
+func (s *Public) LagrangeShare(id party.ID, quorum party.IDSlice) (*ristretto.Element, error) {
+	if unknown := party.NewIDSlice(quorum).Difference(s.PartyIDs); len(unknown) > 0 {
+		return nil, fmt.Errorf("eddsa.LagrangeShare: parties %v are not contained in s", unknown)
+	}
+	lambda, err := party.LagrangeCoefficient(id, quorum)
+	if err != nil {
+		return nil, fmt.Errorf("eddsa.LagrangeShare: %w", err)
+	}
+	var share ristretto.Element
+	return share.ScalarMult(lambda, s.Shares[id]), nil
+}
@@ -0,0 +1,90 @@
+package eddsa
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/polynomial"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/scalar"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+// TestVerifier checks the signature shares and the signature of a session using only public data:
+// the VSS commitments of the key, the nonce commitments of the signers and their signature shares.
+func TestVerifier(t *testing.T) {
+	partyIDs := party.IDSlice{1, 2, 3, 4}
+	quorum := party.IDSlice{1, 3, 4}
+	message := []byte(sampleMessage)
+
+	// Signers: a 2 out of 4 sharing of a random secret
+	f := polynomial.NewPolynomial(2, scalar.NewScalarRandom())
+	vss := polynomial.NewPolynomialExponent(f).Coefficients()
+	secrets := make(map[party.ID]*ristretto.Scalar, len(quorum))
+	nonces := make(map[party.ID][2]*ristretto.Scalar, len(quorum))
+	commitments := make(map[party.ID]*Commitment, len(quorum))
+	for _, id := range quorum {
+		secrets[id] = f.Evaluate(id.Scalar())
+		d, e := scalar.NewScalarRandom(), scalar.NewScalarRandom()
+		nonces[id] = [2]*ristretto.Scalar{d, e}
+		var c Commitment
+		c.D.ScalarBaseMult(d)
+		c.E.ScalarBaseMult(e)
+		c.BindingFactor.Set(scalar.NewScalarRandom())
+		commitments[id] = &c
+	}
+
+	// Verifier: the public shares are recomputed from the VSS commitments
+	publicShares := make(map[party.ID]*ristretto.Element, len(partyIDs))
+	for _, id := range partyIDs {
+		pk, err := PublicShareFromCommitments(vss, id)
+		require.NoError(t, err)
+		publicShares[id] = &pk.pk
+	}
+	public, err := NewPublic(publicShares, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, public.GroupKey.pk.Equal(vss[0]))
+
+	R := GroupCommitment(commitments)
+	c, err := ComputeChallengeWithOptions(R, public.GroupKey, message, &Options{})
+	require.NoError(t, err)
+
+	groupKey := ristretto.NewIdentityElement()
+	S := ristretto.NewScalar()
+	for _, id := range quorum {
+		// Signer: zᵢ = dᵢ + (eᵢ • ρᵢ) + λᵢ • sᵢ • c
+		lambda, err := party.LagrangeCoefficient(id, quorum)
+		require.NoError(t, err)
+		var z ristretto.Scalar
+		z.Multiply(lambda, secrets[id])
+		z.Multiply(&z, c)
+		z.MultiplyAdd(nonces[id][1], &commitments[id].BindingFactor, &z)
+		z.Add(&z, nonces[id][0])
+
+		// Verifier
+		assert.True(t, VerifyPartialSignature(&z, commitments[id], public.Shares[id], c, lambda), "party %d", id)
+		other := ristretto.NewScalar().Add(&z, ristretto.NewScalar().SetUint64(1))
+		assert.False(t, VerifyPartialSignature(other, commitments[id], public.Shares[id], c, lambda), "party %d", id)
+
+		share, err := public.LagrangeShare(id, quorum)
+		require.NoError(t, err)
+		assert.Equal(t, 1, share.Equal(new(ristretto.Element).ScalarMult(lambda, public.Shares[id])))
+		assert.True(t, VerifyShare(&z, commitments[id].Nonce(), share, c), "party %d", id)
+		assert.False(t, VerifyShare(other, commitments[id].Nonce(), share, c), "party %d", id)
+		groupKey.Add(groupKey, share)
+
+		S.Add(S, &z)
+	}
+	assert.Equal(t, 1, groupKey.Equal(&public.GroupKey.pk), "the Lagrange shares sum to the group key")
+
+	sig := &Signature{R: *R, S: *S}
+	assert.True(t, public.GroupKey.Verify(message, sig))
+	assert.True(t, ed25519.Verify(public.GroupKey.ToEd25519(), message, sig.ToEd25519()))
+
+	_, err = public.LagrangeShare(2, quorum)
+	assert.Error(t, err, "party is not in the quorum")
+	_, err = public.LagrangeShare(1, party.IDSlice{1, 5})
+	assert.Error(t, err, "quorum is not contained in public")
+}
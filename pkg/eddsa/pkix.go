@@ -0,0 +1,53 @@
+package eddsa
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// pemBlockType is the type of the PEM block containing a SubjectPublicKeyInfo.
+const pemBlockType = "PUBLIC KEY"
+
+// MarshalPKIX returns the DER encoding of the PublicKey as a PKIX SubjectPublicKeyInfo
+// with the Ed25519 OID (RFC 8410), as produced by x509.MarshalPKIXPublicKey.
+func (pk *PublicKey) MarshalPKIX() ([]byte, error) {
+	return x509.MarshalPKIXPublicKey(pk.ToEd25519())
+}
+
+// ParsePKIXPublicKey parses a DER encoded PKIX SubjectPublicKeyInfo containing an Ed25519 public key.
+func ParsePKIXPublicKey(der []byte) (*PublicKey, error) {
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("eddsa: %w", err)
+	}
+	edKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("eddsa: PKIX public key has type %T, not ed25519.PublicKey", key)
+	}
+	return PublicKeyFromEd25519(edKey)
+}
+
+// MarshalPEM returns the PKIX encoding of the PublicKey in a "PUBLIC KEY" PEM block.
+func (pk *PublicKey) MarshalPEM() ([]byte, error) {
+	der, err := pk.MarshalPKIX()
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemBlockType, Bytes: der}), nil
+}
+
+// ParsePEMPublicKey parses the first PEM block in data, which must be a "PUBLIC KEY" block
+// containing an Ed25519 PKIX public key.
+func ParsePEMPublicKey(data []byte) (*PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("eddsa: no PEM block found")
+	}
+	if block.Type != pemBlockType {
+		return nil, fmt.Errorf("eddsa: unexpected PEM block type %q", block.Type)
+	}
+	return ParsePKIXPublicKey(block.Bytes)
+}
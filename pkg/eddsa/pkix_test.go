@@ -0,0 +1,73 @@
+package eddsa
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicKey_MarshalPKIX(t *testing.T) {
+	pkBytes, skBytes, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, pk := newKeyPair(skBytes)
+
+	der, err := pk.MarshalPKIX()
+	require.NoError(t, err)
+
+	// Cross-check with the standard library, in both directions
+	parsed, err := x509.ParsePKIXPublicKey(der)
+	require.NoError(t, err)
+	assert.Equal(t, pkBytes, parsed)
+
+	stdDer, err := x509.MarshalPKIXPublicKey(pkBytes)
+	require.NoError(t, err)
+	assert.Equal(t, stdDer, der)
+
+	pkDecoded, err := ParsePKIXPublicKey(stdDer)
+	require.NoError(t, err)
+	assert.True(t, pk.Equal(pkDecoded))
+
+	// Other key types are rejected
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecDer, err := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	require.NoError(t, err)
+	_, err = ParsePKIXPublicKey(ecDer)
+	assert.Error(t, err)
+
+	_, err = ParsePKIXPublicKey(der[:len(der)-1])
+	assert.Error(t, err)
+}
+
+func TestPublicKey_MarshalPEM(t *testing.T) {
+	pkBytes, skBytes, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, pk := newKeyPair(skBytes)
+
+	data, err := pk.MarshalPEM()
+	require.NoError(t, err)
+
+	block, rest := pem.Decode(data)
+	require.NotNil(t, block)
+	assert.Empty(t, rest)
+	assert.Equal(t, "PUBLIC KEY", block.Type)
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	require.NoError(t, err)
+	assert.Equal(t, pkBytes, parsed)
+
+	pkDecoded, err := ParsePEMPublicKey(data)
+	require.NoError(t, err)
+	assert.True(t, pk.Equal(pkDecoded))
+
+	_, err = ParsePEMPublicKey([]byte("not a pem block"))
+	assert.Error(t, err)
+	_, err = ParsePEMPublicKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: block.Bytes}))
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ package eddsa
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
 	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
@@ -33,6 +34,9 @@ func NewPublic(shares map[party.ID]*ristretto.Element, threshold party.Size) (*P
 		IDs = append(IDs, id)
 	}
 
+	if err := party.ValidateIDs(IDs); err != nil {
+		return nil, fmt.Errorf("PublicShares: %w", err)
+	}
 	set := party.NewIDSlice(IDs)
 
 	s := &Public{
@@ -42,7 +46,7 @@ func NewPublic(shares map[party.ID]*ristretto.Element, threshold party.Size) (*P
 		GroupKey:  computeGroupKey(set, shares),
 	}
 
-	if s.Threshold+1 > s.PartyIDs.N() {
+	if s.Threshold >= s.PartyIDs.N() {
 		return nil, errors.New("PublicShares: Threshold should be < N - 1")
 	}
 
@@ -54,9 +58,9 @@ func computeGroupKey(partyIDs party.IDSlice, shares map[party.ID]*ristretto.Elem
 	var tmp ristretto.Element
 
 	groupKey := ristretto.NewIdentityElement()
+	lagrange, _ := party.LagrangeCoefficients(partyIDs)
 	for _, id := range partyIDs {
-		lagrange, _ := id.Lagrange(partyIDs)
-		tmp.ScalarMult(lagrange, shares[id])
+		tmp.ScalarMult(lagrange[id], shares[id])
 		groupKey.Add(groupKey, &tmp)
 	}
 	return NewPublicKeyFromPoint(groupKey)
@@ -84,6 +88,17 @@ func (s *Public) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &out); err != nil {
 		return err
 	}
+	if out.GroupKey == nil {
+		return errors.New("PublicShares: missing group key")
+	}
+	if out.Threshold < 0 || int(party.Size(out.Threshold)) != out.Threshold {
+		return errors.New("PublicShares: invalid threshold")
+	}
+	for id, share := range out.Shares {
+		if id == 0 || share == nil {
+			return errors.New("PublicShares: invalid share")
+		}
+	}
 
 	newS, err := NewPublic(out.Shares, party.Size(out.Threshold))
 	if err != nil {
@@ -126,3 +141,14 @@ func (s *Public) Equal(s2 *Public) bool {
 
 	return true
 }
+
+// PartySize returns the number of parties with a share, usually denoted n.
+// The threshold t is given by the Threshold field, and a quorum must contain at least t+1 of these parties.
+func (s *Public) PartySize() int {
+	return len(s.PartyIDs)
+}
+
+// IDs returns a sorted copy of the IDs of all parties with a share, which the caller may modify.
+func (s *Public) IDs() party.IDSlice {
+	return s.PartyIDs.Copy()
+}
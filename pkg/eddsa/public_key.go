@@ -3,7 +3,10 @@ package eddsa
 import (
 	"crypto/ed25519"
 	"encoding/json"
+	"errors"
+	"fmt"
 
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
 	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
 )
 
@@ -30,16 +33,69 @@ func (pk *PublicKey) Verify(message []byte, sig *Signature) bool {
 	return RPrime.Equal(&sig.R) == 1
 }
 
+// PublicShareFromCommitments returns the public share [f(id)]•B of party id, given the Feldman VSS commitments
+// [a₀]•B, ..., [aₜ]•B to the coefficients of the polynomial f, such as keygen.Output.Commitments:
+//
+//	[f(id)]•B = ∑ [idʲ]•[aⱼ]•B
+//
+// It lets a verifier check the partial signatures of a party knowing only the broadcast commitments.
+func PublicShareFromCommitments(commitments []*ristretto.Element, id party.ID) (*PublicKey, error) {
+	public, err := evaluateCommitments(commitments, id)
+	if err != nil {
+		return nil, fmt.Errorf("eddsa.PublicShareFromCommitments: %w", err)
+	}
+	return NewPublicKeyFromPoint(public), nil
+}
+
+// evaluateCommitments returns ∑ [idʲ]•Cⱼ, where the Cⱼ are the commitments.
+func evaluateCommitments(commitments []*ristretto.Element, id party.ID) (*ristretto.Element, error) {
+	if id == 0 {
+		return nil, errors.New("id was 0 (invalid)")
+	}
+	if len(commitments) == 0 {
+		return nil, errors.New("no commitments")
+	}
+
+	// Horner's method, starting with the highest degree coefficient
+	var result ristretto.Element
+	x := id.Scalar()
+	result.Set(ristretto.NewIdentityElement())
+	for i := len(commitments) - 1; i >= 0; i-- {
+		if commitments[i] == nil {
+			return nil, fmt.Errorf("commitment %d is nil", i)
+		}
+		result.ScalarMult(x, &result)
+		result.Add(&result, commitments[i])
+	}
+	return &result, nil
+}
+
 // Equal returns true if the public key is equal to pk0
 func (pk *PublicKey) Equal(pkOther *PublicKey) bool {
 	return pk.pk.Equal(&pkOther.pk) == 1
 }
 
-// ToEd25519 converts the PublicKey to an ed25519 compatible format
+// ToEd25519 converts the PublicKey to an ed25519 compatible format.
+// The result is the 32 byte encoding of the point in the prime-order subgroup,
+// so that signatures produced by FROST verify with ed25519.Verify.
 func (pk *PublicKey) ToEd25519() ed25519.PublicKey {
 	return pk.pk.BytesEd25519()
 }
 
+// PublicKeyFromEd25519 is the inverse of ToEd25519.
+// It returns an error if key is not the canonical encoding of a point in the prime-order subgroup,
+// or if it is the identity.
+func PublicKeyFromEd25519(key ed25519.PublicKey) (*PublicKey, error) {
+	var pk PublicKey
+	if _, err := pk.pk.SetBytesEd25519(key); err != nil {
+		return nil, fmt.Errorf("eddsa: invalid public key: %w", err)
+	}
+	if pk.pk.Equal(ristretto.NewIdentityElement()) == 1 {
+		return nil, errors.New("eddsa: invalid public key: identity")
+	}
+	return &pk, nil
+}
+
 // MarshalJSON implements the json.Marshaler interface.
 func (pk PublicKey) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&pk.pk)
@@ -7,6 +7,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/polynomial"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/scalar"
 	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
 )
 
@@ -38,3 +42,47 @@ func TestPrivateKey_ToEd25519(t *testing.T) {
 
 	assert.Equal(t, pk.ToEd25519(), pkbytes)
 }
+
+func TestPublicKeyFromEd25519(t *testing.T) {
+	pkBytes, skBytes, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err, "failed to generate key")
+
+	_, pk := newKeyPair(skBytes)
+
+	pkDecoded, err := PublicKeyFromEd25519(pkBytes)
+	require.NoError(t, err)
+	assert.True(t, pk.Equal(pkDecoded))
+	assert.Equal(t, pkBytes, pkDecoded.ToEd25519())
+
+	identity := make([]byte, 32)
+	identity[0] = 1
+	_, err = PublicKeyFromEd25519(identity)
+	assert.Error(t, err, "identity should be rejected")
+
+	_, err = PublicKeyFromEd25519(pkBytes[:31])
+	assert.Error(t, err, "short key should be rejected")
+}
+
+func TestPublicShareFromCommitments(t *testing.T) {
+	f := polynomial.NewPolynomial(3, scalar.NewScalarRandom())
+	commitments := polynomial.NewPolynomialExponent(f).Coefficients()
+
+	for _, id := range []party.ID{1, 2, 7, 65535} {
+		share := NewSecretShare(id, f.Evaluate(id.Scalar()))
+		pk, err := PublicShareFromCommitments(commitments, id)
+		require.NoError(t, err)
+		assert.True(t, pk.Equal(NewPublicKeyFromPoint(&share.Public)), "id %d", id)
+	}
+
+	pk, err := PublicShareFromCommitments(commitments, 2)
+	require.NoError(t, err)
+	other := NewSecretShare(3, f.Evaluate(party.ID(3).Scalar()))
+	assert.False(t, pk.Equal(NewPublicKeyFromPoint(&other.Public)))
+
+	_, err = PublicShareFromCommitments(commitments, 0)
+	assert.Error(t, err)
+	_, err = PublicShareFromCommitments(nil, 1)
+	assert.Error(t, err)
+	_, err = PublicShareFromCommitments([]*ristretto.Element{commitments[0], nil}, 1)
+	assert.Error(t, err)
+}
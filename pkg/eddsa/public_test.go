@@ -50,6 +50,18 @@ func TestShares_GroupKey(t *testing.T) {
 	}
 }
 
+func TestNewPublic_ZeroID(t *testing.T) {
+	shares := map[party.ID]*ristretto.Element{
+		0: new(ristretto.Element).ScalarBaseMult(scalar.NewScalarRandom()),
+		1: new(ristretto.Element).ScalarBaseMult(scalar.NewScalarRandom()),
+		2: new(ristretto.Element).ScalarBaseMult(scalar.NewScalarRandom()),
+	}
+	_, err := NewPublic(shares, 1)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "contain 0")
+	}
+}
+
 func TestShares_MarshalJSON(t *testing.T) {
 	var public ristretto.Element
 	shares, secret := fakeShares(40, 38)
@@ -75,3 +87,22 @@ func TestShares_MarshalJSON(t *testing.T) {
 		t.Error("unmarshalled is not equal")
 	}
 }
+
+func TestShares_UnmarshalJSON_Invalid(t *testing.T) {
+	shares, _ := fakeShares(3, 1)
+	groupKey, err := json.Marshal(shares.GroupKey)
+	assert.NoError(t, err)
+	share, err := json.Marshal(shares.Shares[shares.PartyIDs[0]])
+	assert.NoError(t, err)
+
+	for name, data := range map[string]string{
+		"null share":         fmt.Sprintf(`{"t":0,"groupkey":%s,"shares":{"1":null}}`, groupKey),
+		"missing group key":  fmt.Sprintf(`{"t":0,"shares":{"1":%s}}`, share),
+		"zero ID":            fmt.Sprintf(`{"t":0,"groupkey":%s,"shares":{"0":%s}}`, groupKey, share),
+		"threshold overflow": fmt.Sprintf(`{"t":65535,"groupkey":%s,"shares":{"1":%s}}`, groupKey, share),
+		"negative threshold": fmt.Sprintf(`{"t":-1,"groupkey":%s,"shares":{"1":%s}}`, groupKey, share),
+	} {
+		var s Public
+		assert.Error(t, json.Unmarshal([]byte(data), &s), name)
+	}
+}
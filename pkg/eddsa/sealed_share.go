@@ -0,0 +1,147 @@
+package eddsa
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	// sealVersion is the version of the envelopes produced by Seal.
+	sealVersion byte = 2
+
+	// sealVersionLegacy envelopes encode the party.ID of the share on 2 bytes, as before IDs were widened to 32 bits.
+	// They can still be opened.
+	sealVersionLegacy byte = 1
+	sealLegacyIDSize       = 2
+
+	// sealKDFArgon2id identifies Argon2id as the KDF used to derive the encryption key.
+	sealKDFArgon2id byte = 1
+
+	sealSaltSize = 16
+	sealTagSize  = 16
+
+	// sealHeaderSize is the size of version ∥ kdf ∥ time ∥ memory ∥ threads ∥ salt ∥ nonce
+	sealHeaderSize = 1 + 1 + 4 + 4 + 1 + sealSaltSize + chacha20poly1305.NonceSizeX
+
+	// sealMaxMemory bounds the Argon2id memory parameter (in KiB) accepted by OpenSecretShare,
+	// so that a malicious envelope cannot exhaust the memory of the host.
+	// It allows 256 MiB, four times the memory used by Seal.
+	sealMaxMemory = 256 * 1024
+	sealMaxTime   = 64
+)
+
+// Argon2id parameters used by Seal, following the second recommended option of RFC 9106, Section 4.
+var (
+	sealTime    uint32 = 3
+	sealMemory  uint32 = 64 * 1024
+	sealThreads uint8  = 4
+)
+
+var (
+	ErrSealedShareFormat = errors.New("eddsa: invalid sealed secret share")
+	ErrSealedShareOpen   = errors.New("eddsa: wrong passphrase or corrupted sealed secret share")
+)
+
+// Seal encrypts the SecretShare with a key derived from passphrase, so that it can be written to storage.
+//
+// The key is derived with Argon2id using a random salt, and the share is encrypted with XChaCha20-Poly1305.
+// The returned envelope contains the KDF parameters, the salt, the nonce and the ciphertext:
+//
+//	version ∥ kdf ∥ time ∥ memory ∥ threads ∥ salt ∥ nonce ∥ ciphertext
+//
+// The header is authenticated as additional data.
+func (sk *SecretShare) Seal(passphrase []byte) ([]byte, error) {
+	plaintext, err := sk.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	defer zero(plaintext)
+	return seal(sealVersion, plaintext, passphrase)
+}
+
+// seal returns the envelope of plaintext with the given version.
+func seal(version byte, plaintext, passphrase []byte) ([]byte, error) {
+	header := make([]byte, sealHeaderSize)
+	header[0] = version
+	header[1] = sealKDFArgon2id
+	binary.BigEndian.PutUint32(header[2:], sealTime)
+	binary.BigEndian.PutUint32(header[6:], sealMemory)
+	header[10] = sealThreads
+	if _, err := rand.Read(header[11:]); err != nil {
+		return nil, fmt.Errorf("eddsa: failed to generate salt and nonce: %w", err)
+	}
+	salt := header[11 : 11+sealSaltSize]
+	nonce := header[11+sealSaltSize:]
+
+	key := argon2.IDKey(passphrase, salt, sealTime, sealMemory, sealThreads, chacha20poly1305.KeySize)
+	defer zero(key)
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(header, nonce, plaintext, header), nil
+}
+
+// OpenSecretShare decrypts a SecretShare sealed with SecretShare.Seal.
+// It returns ErrSealedShareOpen if the passphrase is wrong or the data was modified,
+// and ErrSealedShareFormat if data is not a valid envelope.
+// Envelopes of version 1, which encoded the ID of the share on 2 bytes, are still accepted.
+func OpenSecretShare(data, passphrase []byte) (*SecretShare, error) {
+	if len(data) < sealHeaderSize+sealTagSize {
+		return nil, ErrSealedShareFormat
+	}
+	header, ciphertext := data[:sealHeaderSize], data[sealHeaderSize:]
+	version := header[0]
+	if (version != sealVersion && version != sealVersionLegacy) || header[1] != sealKDFArgon2id {
+		return nil, ErrSealedShareFormat
+	}
+	time := binary.BigEndian.Uint32(header[2:])
+	memory := binary.BigEndian.Uint32(header[6:])
+	threads := header[10]
+	if time == 0 || time > sealMaxTime || memory == 0 || memory > sealMaxMemory || threads == 0 {
+		return nil, ErrSealedShareFormat
+	}
+	salt := header[11 : 11+sealSaltSize]
+	nonce := header[11+sealSaltSize:]
+
+	key := argon2.IDKey(passphrase, salt, time, memory, threads, chacha20poly1305.KeySize)
+	defer zero(key)
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, nonce, ciphertext, header)
+	if err != nil {
+		return nil, ErrSealedShareOpen
+	}
+	defer zero(plaintext)
+
+	if version == sealVersionLegacy {
+		// Widen the ID to party.IDByteSize
+		if len(plaintext) != sealLegacyIDSize+32 {
+			return nil, ErrSealedShareFormat
+		}
+		upgraded := make([]byte, party.IDByteSize-sealLegacyIDSize, party.IDByteSize+32)
+		upgraded = append(upgraded, plaintext...)
+		defer zero(upgraded)
+		plaintext = upgraded
+	}
+
+	var sk SecretShare
+	if err = sk.UnmarshalBinary(plaintext); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrSealedShareFormat, err)
+	}
+	return &sk, nil
+}
+
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,89 @@
+package eddsa
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+func TestSecretShare_Seal(t *testing.T) {
+	var secret ristretto.Scalar
+	secret.SetUint64(42)
+	share := NewSecretShare(3, &secret)
+	passphrase := []byte("correct horse battery staple")
+
+	sealed, err := share.Seal(passphrase)
+	require.NoError(t, err)
+
+	opened, err := OpenSecretShare(sealed, passphrase)
+	require.NoError(t, err)
+	assert.True(t, share.Equal(opened))
+	assert.Equal(t, 1, share.Public.Equal(&opened.Public))
+
+	// Sealing twice uses a fresh salt and nonce
+	sealed2, err := share.Seal(passphrase)
+	require.NoError(t, err)
+	assert.NotEqual(t, sealed, sealed2)
+
+	t.Run("wrong passphrase", func(t *testing.T) {
+		_, err := OpenSecretShare(sealed, []byte("incorrect horse battery staple"))
+		assert.ErrorIs(t, err, ErrSealedShareOpen)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		// flip a bit in the ciphertext, and in the salt
+		for _, i := range []int{len(sealed) - 1, 12} {
+			tampered := append([]byte{}, sealed...)
+			tampered[i] ^= 1
+			_, err := OpenSecretShare(tampered, passphrase)
+			assert.ErrorIs(t, err, ErrSealedShareOpen)
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		for _, n := range []int{0, 10, sealHeaderSize, len(sealed) - 1} {
+			_, err := OpenSecretShare(sealed[:n], passphrase)
+			assert.Error(t, err, "length %d", n)
+		}
+	})
+
+	t.Run("legacy version", func(t *testing.T) {
+		// Version 1 encoded the ID on 2 bytes
+		plaintext := append([]byte{0, 3}, secret.Bytes()...)
+		legacy, err := seal(sealVersionLegacy, plaintext, passphrase)
+		require.NoError(t, err)
+		opened, err := OpenSecretShare(legacy, passphrase)
+		require.NoError(t, err)
+		assert.True(t, share.Equal(opened))
+
+		// The version is authenticated, so a new envelope cannot be opened as a legacy one
+		tampered := append([]byte{}, sealed...)
+		tampered[0] = sealVersionLegacy
+		_, err = OpenSecretShare(tampered, passphrase)
+		assert.ErrorIs(t, err, ErrSealedShareOpen)
+
+		legacy, err = seal(sealVersionLegacy, append(plaintext, 0), passphrase)
+		require.NoError(t, err)
+		_, err = OpenSecretShare(legacy, passphrase)
+		assert.ErrorIs(t, err, ErrSealedShareFormat)
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		tampered := append([]byte{}, sealed...)
+		tampered[0] = sealVersion + 1
+		_, err := OpenSecretShare(tampered, passphrase)
+		assert.ErrorIs(t, err, ErrSealedShareFormat)
+
+		// A huge memory parameter is rejected before running the KDF
+		tampered = append([]byte{}, sealed...)
+		tampered[6] = 0xff
+		_, err = OpenSecretShare(tampered, passphrase)
+		assert.ErrorIs(t, err, ErrSealedShareFormat)
+		binary.BigEndian.PutUint32(tampered[6:], sealMaxMemory+1)
+		_, err = OpenSecretShare(tampered, passphrase)
+		assert.ErrorIs(t, err, ErrSealedShareFormat)
+	})
+}
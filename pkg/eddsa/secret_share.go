@@ -3,6 +3,7 @@ package eddsa
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
 	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
@@ -30,6 +31,35 @@ func NewSecretShare(id party.ID, secret *ristretto.Scalar) *SecretShare {
 	return &share
 }
 
+// Zeroize overwrites the Secret with zeros, after which the SecretShare can no longer be used to sign.
+// The ID and Public are left untouched, since they are not secret.
+// As for ristretto.Scalar.Zeroize, copies made by the Go runtime are not erased.
+func (sk *SecretShare) Zeroize() {
+	sk.Secret.Zeroize()
+}
+
+// VerifyAgainstCommitments checks that the Secret is the evaluation at id of the polynomial f committed to by
+// the Feldman VSS commitments [a₀]•B, ..., [aₜ]•B, i.e. that
+//
+//	[Secret]•B = ∑ [idʲ]•[aⱼ]•B
+//
+// The commitments of a t-of-n sharing contain t+1 elements, the first of which is the group key.
+// For the output of the key generation, id is sk.ID.
+func (sk *SecretShare) VerifyAgainstCommitments(commitments []*ristretto.Element, id party.ID) error {
+	expected, err := evaluateCommitments(commitments, id)
+	if err != nil {
+		return fmt.Errorf("SecretShare: VerifyAgainstCommitments: %w", err)
+	}
+
+	var public ristretto.Element
+	public.ScalarBaseMult(&sk.Secret)
+	if public.Equal(expected) != 1 {
+		return fmt.Errorf("SecretShare: VerifyAgainstCommitments: share is not consistent with the commitments of degree %d at id %d",
+			len(commitments)-1, id)
+	}
+	return nil
+}
+
 // MarshalBinary implements the encoding.BinaryMarshaler interface.
 func (sk *SecretShare) MarshalBinary() ([]byte, error) {
 	data := make([]byte, 0, party.IDByteSize+32)
@@ -49,7 +79,7 @@ func (sk *SecretShare) UnmarshalBinary(data []byte) error {
 	}
 	data = data[party.IDByteSize:]
 
-	if _, err = sk.Secret.SetCanonicalBytes(data); err != nil {
+	if _, err = sk.Secret.SetCanonicalBytesConstantTime(data); err != nil {
 		return err
 	}
 	sk.Public.ScalarBaseMult(&sk.Secret)
@@ -76,7 +106,7 @@ func (sk *SecretShare) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	sk.ID = party.ID(out.ID)
-	if _, err := sk.Secret.SetCanonicalBytes(out.SecretShare); err != nil {
+	if _, err := sk.Secret.SetCanonicalBytesConstantTime(out.SecretShare); err != nil {
 		return err
 	}
 	sk.Public.ScalarBaseMult(&sk.Secret)
@@ -3,7 +3,9 @@ package eddsa
 import (
 	"testing"
 
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
 	"github.com/taurusgroup/frost-ed25519/pkg/internal/scalar"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
 )
 
 // sign generates an Ed25519 compatible signature for the message.
@@ -57,3 +59,72 @@ func TestSecretShare_MarshalBinary(t *testing.T) {
 		t.Error("unmarshalled share is not the same")
 	}
 }
+
+func TestSecretShare_VerifyAgainstCommitments(t *testing.T) {
+	// f(X) = a₀ + a₁•X + a₂•X²
+	coefficients := []*ristretto.Scalar{scalar.NewScalarRandom(), scalar.NewScalarRandom(), scalar.NewScalarRandom()}
+	commitments := make([]*ristretto.Element, len(coefficients))
+	for i, a := range coefficients {
+		commitments[i] = new(ristretto.Element).ScalarBaseMult(a)
+	}
+	f := func(id party.ID) *ristretto.Scalar {
+		var result ristretto.Scalar
+		x := id.Scalar()
+		for i := len(coefficients) - 1; i >= 0; i-- {
+			result.MultiplyAdd(&result, x, coefficients[i])
+		}
+		return &result
+	}
+
+	for _, id := range []party.ID{1, 2, 42, 65535} {
+		if err := NewSecretShare(id, f(id)).VerifyAgainstCommitments(commitments, id); err != nil {
+			t.Errorf("VerifyAgainstCommitments() failed for a valid share of %d: %v", id, err)
+		}
+	}
+
+	share := NewSecretShare(2, f(2))
+
+	var corrupted ristretto.Scalar
+	corrupted.Add(&share.Secret, scalar.NewScalarUInt32(1))
+	if err := NewSecretShare(2, &corrupted).VerifyAgainstCommitments(commitments, 2); err == nil {
+		t.Error("VerifyAgainstCommitments() should fail for a corrupted share")
+	}
+
+	if err := share.VerifyAgainstCommitments(commitments, 3); err == nil {
+		t.Error("VerifyAgainstCommitments() should fail for the wrong id")
+	}
+	if err := share.VerifyAgainstCommitments(commitments, 0); err == nil {
+		t.Error("VerifyAgainstCommitments() should fail for the 0 id")
+	}
+
+	// A polynomial of lower degree does not verify
+	if err := share.VerifyAgainstCommitments(commitments[:2], 2); err == nil {
+		t.Error("VerifyAgainstCommitments() should fail with missing commitments")
+	}
+	if err := share.VerifyAgainstCommitments(nil, 2); err == nil {
+		t.Error("VerifyAgainstCommitments() should fail without commitments")
+	}
+	if err := share.VerifyAgainstCommitments([]*ristretto.Element{commitments[0], nil, commitments[2]}, 2); err == nil {
+		t.Error("VerifyAgainstCommitments() should fail with a nil commitment")
+	}
+
+	// A constant polynomial is a degenerate sharing where every share is the secret
+	if err := NewSecretShare(5, coefficients[0]).VerifyAgainstCommitments(commitments[:1], 5); err != nil {
+		t.Errorf("VerifyAgainstCommitments() failed for a constant polynomial: %v", err)
+	}
+}
+
+func TestSecretShare_Zeroize(t *testing.T) {
+	s := NewSecretShare(42, scalar.NewScalarRandom())
+	public := s.Public
+
+	s.Zeroize()
+	for _, b := range s.Secret.Bytes() {
+		if b != 0 {
+			t.Fatalf("Zeroize() left non-zero bytes: %x", s.Secret.Bytes())
+		}
+	}
+	if s.ID != 42 || s.Public.Equal(&public) != 1 {
+		t.Error("Zeroize() should not modify the public values")
+	}
+}
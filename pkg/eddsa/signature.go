@@ -1,10 +1,13 @@
 package eddsa
 
 import (
+	"bytes"
 	"crypto/sha512"
 	"errors"
 	"fmt"
 
+	"filippo.io/edwards25519"
+
 	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
 )
 
@@ -22,12 +25,22 @@ type Signature struct {
 
 // ToEd25519 returns a signature that can be validated by ed25519.Verify.
 func (sig *Signature) ToEd25519() []byte {
-	out := make([]byte, 0, MessageLengthSig)
-	out = append(out, sig.R.BytesEd25519()...)
-	out = append(out, sig.S.Bytes()...)
+	out, _ := sig.MarshalBinary()
 	return out
 }
 
+// SignatureFromEd25519 is the inverse of ToEd25519.
+// It returns an error wrapping ErrNonCanonicalSignature if R or S is not canonically encoded,
+// and ErrInvalidSignature if R is not a point of the prime-order subgroup.
+// Use PublicKey.VerifyEd25519 to verify a signature which may not be produced by FROST.
+func SignatureFromEd25519(data []byte) (*Signature, error) {
+	var sig Signature
+	if err := sig.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return &sig, nil
+}
+
 // ComputeChallenge computes the value H(R, A, M), and assumes nothing about whether M is hashed.
 func ComputeChallenge(R *ristretto.Element, groupKey *PublicKey, message []byte) *ristretto.Scalar {
 	var s ristretto.Scalar
@@ -48,32 +61,44 @@ func ComputeChallenge(R *ristretto.Element, groupKey *PublicKey, message []byte)
 //
 
 // MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The output is the 64 byte Ed25519 encoding R ∥ S returned by ToEd25519, as produced by ed25519.Sign.
 func (sig *Signature) MarshalBinary() ([]byte, error) {
 	out := make([]byte, 0, MessageLengthSig)
 	return sig.BytesAppend(out)
 }
 
-// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface, and is the inverse of MarshalBinary.
+// It returns ErrInvalidSignature if data is not 64 bytes long or if R is not a point of the prime-order subgroup,
+// and an error wrapping ErrNonCanonicalSignature if R or S is not canonically encoded.
+// sig is only modified if data is valid.
 func (sig *Signature) UnmarshalBinary(data []byte) error {
-	var err error
-	if len(data) < MessageLengthSig {
-		return fmt.Errorf("sig: %w", ErrInvalidMessage)
+	if len(data) != MessageLengthSig {
+		return ErrInvalidSignature
 	}
-
-	_, err = sig.R.SetCanonicalBytes(data[:32])
-	if err != nil {
-		return fmt.Errorf("sig.Ri: %w", err)
+	// ristretto.Element.SetBytesEd25519 accepts the non-canonical encodings accepted by edwards25519.Point.SetBytes
+	var R edwards25519.Point
+	if _, err := R.SetBytes(data[:32]); err != nil {
+		return ErrInvalidSignature
 	}
-	_, err = sig.S.SetCanonicalBytes(data[32:])
-	if err != nil {
-		return fmt.Errorf("sig.S: %w", err)
+	if !bytes.Equal(R.Bytes(), data[:32]) {
+		return ErrNonCanonicalSignature
 	}
 
+	var decoded Signature
+	if _, err := decoded.S.SetCanonicalBytes(data[32:]); err != nil {
+		return ErrNonCanonicalSignature
+	}
+	if _, err := decoded.R.SetBytesEd25519(data[:32]); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	sig.R.Set(&decoded.R)
+	sig.S.Set(&decoded.S)
 	return nil
 }
 
+// BytesAppend appends the 64 byte encoding of sig returned by MarshalBinary to existing.
 func (sig *Signature) BytesAppend(existing []byte) ([]byte, error) {
-	existing = append(existing, sig.R.Bytes()...)
+	existing = append(existing, sig.R.BytesEd25519()...)
 	existing = append(existing, sig.S.Bytes()...)
 	return existing, nil
 }
@@ -82,16 +107,8 @@ func (sig *Signature) Size() int {
 	return MessageLengthSig
 }
 
-func (sig *Signature) Equal(other interface{}) bool {
-	otherSignature, ok := other.(*Signature)
-	if !ok {
-		return false
-	}
-	if otherSignature.R.Equal(&sig.R) != 1 {
-		return false
-	}
-	if otherSignature.S.Equal(&sig.S) != 1 {
-		return false
-	}
-	return true
+// Equal returns 1 if sig and other represent the same signature, and 0 otherwise.
+// Both R and S are compared in constant time.
+func (sig *Signature) Equal(other *Signature) int {
+	return sig.R.Equal(&other.R) & sig.S.Equal(&other.S)
 }
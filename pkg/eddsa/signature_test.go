@@ -1,13 +1,14 @@
 package eddsa
 
 import (
+	"bytes"
 	"crypto/ed25519"
 	"crypto/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
 )
 
 const sampleMessage = "This is a test for FROST"
@@ -40,8 +41,88 @@ func TestSignatureEncode_Decode(t *testing.T) {
 	signature, _, err := generateSignature()
 	assert.NoError(t, err, "failed to generate signature")
 
-	assert.NoError(t, messages.CheckFROSTMarshaler(signature, &signatureOutput))
+	data, err := signature.MarshalBinary()
+	require.NoError(t, err)
+	require.Len(t, data, signature.Size())
+	require.NoError(t, signatureOutput.UnmarshalBinary(data))
 
 	assert.Equal(t, 1, signature.R.Equal(&signatureOutput.R))
 	assert.Equal(t, 1, signature.S.Equal(&signatureOutput.S))
+	assert.Equal(t, 1, signature.Equal(&signatureOutput))
+}
+
+func TestSignature_MarshalBinary_Ed25519(t *testing.T) {
+	// A signature produced by ed25519.Sign is decoded, and encoded back to the same bytes
+	pk, sk, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	message := []byte(sampleMessage)
+	expected := ed25519.Sign(sk, message)
+
+	var sig Signature
+	require.NoError(t, sig.UnmarshalBinary(expected))
+	data, err := sig.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, expected, data)
+	assert.Equal(t, expected, sig.ToEd25519())
+
+	publicKey, err := PublicKeyFromEd25519(pk)
+	require.NoError(t, err)
+	assert.True(t, publicKey.Verify(message, &sig))
+
+	// A FROST signature is encoded as ed25519.Verify expects
+	frostSig, frostPk, err := generateSignature()
+	require.NoError(t, err)
+	data, err = frostSig.MarshalBinary()
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(frostPk.ToEd25519(), message, data))
+}
+
+func TestSignature_UnmarshalBinary_Invalid(t *testing.T) {
+	sig, _, err := generateSignature()
+	require.NoError(t, err)
+	data, err := sig.MarshalBinary()
+	require.NoError(t, err)
+
+	var decoded Signature
+	decoded.R.Set(&sig.R)
+	decoded.S.Set(&sig.S)
+	for _, length := range []int{0, 32, 63, 65, 96} {
+		encoded := make([]byte, length)
+		copy(encoded, data)
+		assert.ErrorIs(t, decoded.UnmarshalBinary(encoded), ErrInvalidSignature, "length %d", length)
+	}
+	assert.ErrorIs(t, decoded.UnmarshalBinary(nil), ErrInvalidSignature)
+
+	// S is not reduced modulo l
+	unreduced := append(data[:32:32], addOrder(t, sig.S.Bytes())...)
+	assert.ErrorIs(t, decoded.UnmarshalBinary(unreduced), ErrNonCanonicalSignature)
+
+	// p + 1 is a non-canonical encoding of y = 1
+	nonCanonicalR := append([]byte{0xee}, bytes.Repeat([]byte{0xff}, 30)...)
+	nonCanonicalR = append(nonCanonicalR, 0x7f)
+	assert.ErrorIs(t, decoded.UnmarshalBinary(append(nonCanonicalR, data[32:]...)), ErrNonCanonicalSignature)
+
+	// A failed decoding leaves the signature unchanged
+	assert.Equal(t, 1, decoded.Equal(sig))
+}
+
+func TestSignature_Equal(t *testing.T) {
+	sig, _, err := generateSignature()
+	require.NoError(t, err)
+
+	var same Signature
+	same.R.Set(&sig.R)
+	same.S.Set(&sig.S)
+	assert.Equal(t, 1, sig.Equal(&same))
+	assert.Equal(t, 1, sig.Equal(sig))
+
+	var otherR Signature
+	otherR.R.Add(&sig.R, ristretto.NewGeneratorElement())
+	otherR.S.Set(&sig.S)
+	assert.Equal(t, 0, sig.Equal(&otherR))
+
+	var otherS Signature
+	otherS.R.Set(&sig.R)
+	otherS.S.Add(&sig.S, ristretto.NewScalar().SetUint64(1))
+	assert.Equal(t, 0, sig.Equal(&otherS))
 }
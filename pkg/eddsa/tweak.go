@@ -0,0 +1,38 @@
+package eddsa
+
+import (
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/transcript"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+const tweakDomainSeparation = "FROST-Ed25519-tweak"
+
+// DeriveTweak returns the public tweak t of the key derived from groupKey along path, computed as the challenge
+//
+//	key:     groupKey
+//	path:    pᵢ for each element of path, in order
+//	t =      challenge "tweak"
+//
+// of a transcript hashed with SHA-512. The same groupKey and path always give the same tweak, and different paths
+// give unrelated ones, so that a single threshold key can be used for many logical identities.
+// The derived key is groupKey.Tweak(t), for which signatures are produced with sign.WithTweak(t).
+//
+// Anyone knowing the group key and the path can compute t, so the derived keys of a group can be linked to each other
+// by whoever knows the paths.
+func DeriveTweak(groupKey *PublicKey, path ...string) *ristretto.Scalar {
+	t := transcript.New(tweakDomainSeparation, SHA512)
+	t.AppendMessage("key", groupKey.pk.Bytes())
+	for _, p := range path {
+		t.AppendMessage("path", []byte(p))
+	}
+	return t.ChallengeScalar("tweak")
+}
+
+// Tweak returns the public key A + [t]B, where A is pk and B the base point.
+// A signature valid under the result is produced by the holders of the shares of A with sign.WithTweak(t).
+func (pk *PublicKey) Tweak(t *ristretto.Scalar) *PublicKey {
+	var tweaked ristretto.Element
+	tweaked.ScalarBaseMult(t)
+	tweaked.Add(&tweaked, &pk.pk)
+	return NewPublicKeyFromPoint(&tweaked)
+}
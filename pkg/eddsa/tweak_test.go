@@ -0,0 +1,33 @@
+package eddsa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/scalar"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+func TestDeriveTweak(t *testing.T) {
+	secret := scalar.NewScalarRandom()
+	pk := NewPublicKeyFromPoint(new(ristretto.Element).ScalarBaseMult(secret))
+	other := NewPublicKeyFromPoint(new(ristretto.Element).ScalarBaseMult(scalar.NewScalarRandom()))
+
+	tweak := DeriveTweak(pk, "a", "b")
+	assert.Equal(t, 1, tweak.Equal(DeriveTweak(pk, "a", "b")), "the tweak is deterministic")
+	for _, different := range []*ristretto.Scalar{
+		DeriveTweak(pk, "a"),
+		DeriveTweak(pk, "ab"),
+		DeriveTweak(pk, "b", "a"),
+		DeriveTweak(pk, "a", "b", ""),
+		DeriveTweak(other, "a", "b"),
+	} {
+		assert.Equal(t, 0, tweak.Equal(different))
+	}
+
+	// The tweaked key is that of the tweaked secret
+	tweakedSecret := ristretto.NewScalar().Add(secret, tweak)
+	expected := NewPublicKeyFromPoint(new(ristretto.Element).ScalarBaseMult(tweakedSecret))
+	assert.True(t, pk.Tweak(tweak).Equal(expected))
+	assert.True(t, pk.Tweak(ristretto.NewScalar()).Equal(pk))
+}
@@ -0,0 +1,87 @@
+package eddsa
+
+import (
+	"bytes"
+	"crypto/ed25519"
+
+	"filippo.io/edwards25519"
+)
+
+// VerifyEd25519 checks that sig is a valid signature of message in the 64 byte Ed25519 format, such as the output of
+// Signature.ToEd25519, for the variant selected by opts.
+// It returns nil if the signature is valid.
+//
+// Unlike a Signature, the Ed25519 encoding of R may have a small-order component.
+// Such a signature is rejected by the strict equation [S]B = R + [k]A, but may be accepted by the cofactored
+// equation [8][S]B = [8]R + [8][k]A when Options.Cofactored is set.
+// In both cases, non-canonical encodings of R and S are rejected with ErrNonCanonicalSignature,
+// including an S which is not reduced modulo l.
+func (pk *PublicKey) VerifyEd25519(message, sig []byte, opts *Options) error {
+	if err := opts.Validate(message); err != nil {
+		return err
+	}
+	if len(sig) != MessageLengthSig {
+		return ErrInvalidSignature
+	}
+
+	// The encodings are checked before the verification equation, as in RFC 8032, Section 5.1.7
+	var R, A edwards25519.Point
+	if _, err := R.SetBytes(sig[:32]); err != nil {
+		return ErrInvalidSignature
+	}
+	// edwards25519.Point.SetBytes accepts some non-canonical encodings
+	if !bytes.Equal(R.Bytes(), sig[:32]) {
+		return ErrNonCanonicalSignature
+	}
+	S, err := edwards25519.NewScalar().SetCanonicalBytes(sig[32:])
+	if err != nil {
+		return ErrNonCanonicalSignature
+	}
+
+	publicKey := pk.ToEd25519()
+	if _, err = A.SetBytes(publicKey); err != nil {
+		return ErrInvalidSignature
+	}
+
+	c := computeChallengeBytes(opts.hasher(), opts.prefix(), sig[:32], publicKey, opts.signedMessage(message))
+	k, err := edwards25519.NewScalar().SetCanonicalBytes(c.Bytes())
+	if err != nil {
+		return err
+	}
+
+	var RPrime edwards25519.Point
+	// RPrime = [k](-A) + [S]B
+	A.Negate(&A)
+	RPrime.VarTimeDoubleScalarBaseMult(k, &A, S)
+
+	if !opts.Cofactored {
+		if RPrime.Equal(&R) != 1 {
+			return ErrInvalidSignature
+		}
+		return nil
+	}
+
+	// [8](RPrime - R) = 0
+	RPrime.Subtract(&RPrime, &R)
+	RPrime.MultByCofactor(&RPrime)
+	if RPrime.Equal(edwards25519.NewIdentityPoint()) != 1 {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Verify reports whether sig is a valid Ed25519 signature of message by publicKey, both in their 32 and 64 byte encodings.
+// It uses the strict verification equation, as VerifyEd25519 with no Options, and returns false if either input cannot be parsed.
+//
+// Unlike ed25519.Verify, a publicKey outside the prime-order subgroup, or equal to the identity, is rejected,
+// since it could not be the output of a key generation.
+func Verify(publicKey, message, sig []byte) bool {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return false
+	}
+	pk, err := PublicKeyFromEd25519(publicKey)
+	if err != nil {
+		return false
+	}
+	return pk.VerifyEd25519(message, sig, &Options{}) == nil
+}
@@ -0,0 +1,166 @@
+package eddsa
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"filippo.io/edwards25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/scalar"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+// torsionPoint returns a point of order 8.
+func torsionPoint(t *testing.T) *edwards25519.Point {
+	b, err := hex.DecodeString("c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a")
+	require.NoError(t, err)
+	T, err := new(edwards25519.Point).SetBytes(b)
+	require.NoError(t, err)
+
+	var tmp edwards25519.Point
+	require.Equal(t, 1, tmp.MultByCofactor(T).Equal(edwards25519.NewIdentityPoint()))
+	require.Equal(t, 0, tmp.Add(T, T).Add(&tmp, &tmp).Equal(edwards25519.NewIdentityPoint()), "point has order less than 8")
+	return T
+}
+
+// signWithTorsion returns an Ed25519 signature of message whose R has an order 8 component.
+// It satisfies the cofactored verification equation, but not the strict one.
+func (sk *SecretShare) signWithTorsion(t *testing.T, message []byte) []byte {
+	var R0 edwards25519.Point
+	var nonce ristretto.Element
+	r := scalar.NewScalarRandom()
+	_, err := R0.SetBytes(nonce.ScalarBaseMult(r).BytesEd25519())
+	require.NoError(t, err)
+
+	R := new(edwards25519.Point).Add(&R0, torsionPoint(t))
+	pk := PublicKey{pk: sk.Public}
+	c := computeChallengeBytes(SHA512, nil, R.Bytes(), pk.ToEd25519(), message)
+
+	var S ristretto.Scalar
+	S.MultiplyAdd(&sk.Secret, c, r)
+	return append(R.Bytes(), S.Bytes()...)
+}
+
+func TestPublicKey_VerifyEd25519(t *testing.T) {
+	sig, pk, err := generateSignature()
+	require.NoError(t, err)
+	message := []byte(sampleMessage)
+
+	// An honest signature verifies with both equations
+	assert.NoError(t, pk.VerifyEd25519(message, sig.ToEd25519(), &Options{}))
+	assert.NoError(t, pk.VerifyEd25519(message, sig.ToEd25519(), &Options{Cofactored: true}))
+
+	assert.ErrorIs(t, pk.VerifyEd25519([]byte("other message"), sig.ToEd25519(), &Options{}), ErrInvalidSignature)
+	assert.ErrorIs(t, pk.VerifyEd25519([]byte("other message"), sig.ToEd25519(), &Options{Cofactored: true}), ErrInvalidSignature)
+	assert.ErrorIs(t, pk.VerifyEd25519(message, sig.ToEd25519()[:63], &Options{}), ErrInvalidSignature)
+}
+
+func TestPublicKey_VerifyEd25519_SmallOrder(t *testing.T) {
+	_, skBytes, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sk, pk := newKeyPair(skBytes)
+	share := NewSecretShare(1, sk)
+	message := []byte(sampleMessage)
+
+	sig := share.signWithTorsion(t, message)
+
+	assert.ErrorIs(t, pk.VerifyEd25519(message, sig, &Options{}), ErrInvalidSignature, "strict verification should reject a small-order component")
+	assert.NoError(t, pk.VerifyEd25519(message, sig, &Options{Cofactored: true}))
+	assert.False(t, ed25519.Verify(pk.ToEd25519(), message, sig), "ed25519.Verify uses the strict equation")
+
+	// The signature cannot be represented as a Signature without losing the small-order component
+	var s Signature
+	_, err = s.R.SetBytesEd25519(sig[:32])
+	assert.Error(t, err)
+}
+
+// addOrder returns the little-endian encoding of s + l, which is an unreduced encoding of s.
+func addOrder(t *testing.T, s []byte) []byte {
+	l, ok := new(big.Int).SetString("7237005577332262213973186563042994240857116359379907606001950938285454250989", 10)
+	require.True(t, ok)
+	be := make([]byte, len(s))
+	for i := range s {
+		be[len(s)-1-i] = s[i]
+	}
+	sum := new(big.Int).Add(new(big.Int).SetBytes(be), l).FillBytes(make([]byte, 32))
+	out := make([]byte, len(sum))
+	for i := range sum {
+		out[len(sum)-1-i] = sum[i]
+	}
+	return out
+}
+
+func TestPublicKey_VerifyEd25519_Malleability(t *testing.T) {
+	sig, pk, err := generateSignature()
+	require.NoError(t, err)
+	message := []byte(sampleMessage)
+
+	malleated := append(sig.ToEd25519()[:32], addOrder(t, sig.S.Bytes())...)
+	assert.False(t, ed25519.Verify(pk.ToEd25519(), message, malleated))
+	for _, opts := range []*Options{{}, {Cofactored: true}} {
+		err = pk.VerifyEd25519(message, malleated, opts)
+		assert.ErrorIs(t, err, ErrNonCanonicalSignature)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	}
+	_, err = SignatureFromEd25519(malleated)
+	assert.ErrorIs(t, err, ErrNonCanonicalSignature)
+
+	// p + 1 is a non-canonical encoding of y = 1, the identity
+	nonCanonicalR := append([]byte{0xee}, bytes.Repeat([]byte{0xff}, 30)...)
+	nonCanonicalR = append(nonCanonicalR, 0x7f)
+	withR := append(nonCanonicalR, sig.S.Bytes()...)
+	assert.ErrorIs(t, pk.VerifyEd25519(message, withR, &Options{}), ErrNonCanonicalSignature)
+	_, err = SignatureFromEd25519(withR)
+	assert.ErrorIs(t, err, ErrNonCanonicalSignature)
+}
+
+func TestSignatureFromEd25519(t *testing.T) {
+	sig, pk, err := generateSignature()
+	require.NoError(t, err)
+
+	decoded, err := SignatureFromEd25519(sig.ToEd25519())
+	require.NoError(t, err)
+	assert.Equal(t, 1, sig.Equal(decoded))
+	assert.True(t, pk.Verify([]byte(sampleMessage), decoded))
+
+	_, err = SignatureFromEd25519(sig.ToEd25519()[:63])
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	// R has a small-order component
+	_, skBytes, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sk, _ := newKeyPair(skBytes)
+	torsion := NewSecretShare(1, sk).signWithTorsion(t, []byte(sampleMessage))
+	_, err = SignatureFromEd25519(torsion)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+	assert.NotErrorIs(t, err, ErrNonCanonicalSignature)
+}
+
+func TestVerify(t *testing.T) {
+	sig, pk, err := generateSignature()
+	require.NoError(t, err)
+	message := []byte(sampleMessage)
+	publicKey := pk.ToEd25519()
+
+	assert.True(t, Verify(publicKey, message, sig.ToEd25519()))
+	assert.False(t, Verify(publicKey, []byte("other message"), sig.ToEd25519()))
+
+	otherKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	assert.False(t, Verify(otherKey, message, sig.ToEd25519()), "wrong key")
+
+	assert.False(t, Verify(publicKey[:31], message, sig.ToEd25519()))
+	assert.False(t, Verify(append(publicKey, 0), message, sig.ToEd25519()))
+	assert.False(t, Verify(publicKey, message, sig.ToEd25519()[:63]))
+	assert.False(t, Verify(publicKey, message, append(sig.ToEd25519(), 0)))
+	assert.False(t, Verify(nil, message, nil))
+	assert.False(t, Verify(make([]byte, 32), message, sig.ToEd25519()), "point of small order")
+
+	malleated := append(sig.ToEd25519()[:32], addOrder(t, sig.S.Bytes())...)
+	assert.False(t, Verify(publicKey, message, malleated))
+}
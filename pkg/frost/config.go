@@ -0,0 +1,93 @@
+package frost
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/keygen"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/refresh"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/sign"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+// Config bundles the key material of a party, from which its sessions are created
+// without having to pass the same parameters in the right order to every constructor.
+type Config struct {
+	// SelfID is the ID of the party.
+	SelfID party.ID
+
+	// SecretShare is the share of the party, whose ID must be SelfID.
+	SecretShare *eddsa.SecretShare
+
+	// Public contains the group key and the public shares of all parties, including that of SelfID.
+	Public *eddsa.Public
+}
+
+// NewConfig returns the Config of the party which obtained output from a key generation.
+func NewConfig(output *keygen.Output) (*Config, error) {
+	if output.SecretKey == nil || output.Public == nil {
+		return nil, errors.New("frost.NewConfig: the key generation has not finished")
+	}
+	c := &Config{
+		SelfID:      output.SecretKey.ID,
+		SecretShare: output.SecretKey,
+		Public:      output.Public,
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Validate returns an error if the fields of c are not consistent with each other:
+// SelfID must be a party of Public, and the SecretShare of SelfID must match its public share in Public.
+// It is called by all constructors of sessions from a Config.
+func (c *Config) Validate() error {
+	if c.SecretShare == nil || c.Public == nil {
+		return errors.New("frost.Config: missing SecretShare or Public")
+	}
+	if c.SelfID == 0 {
+		return errors.New("frost.Config: SelfID is 0, which is not a valid ID")
+	}
+	if c.SecretShare.ID != c.SelfID {
+		return fmt.Errorf("frost.Config: SecretShare belongs to party %d instead of %d", c.SecretShare.ID, c.SelfID)
+	}
+	publicShare, ok := c.Public.Shares[c.SelfID]
+	if !ok || !c.Public.PartyIDs.Contains(c.SelfID) {
+		return fmt.Errorf("frost.Config: party %d is not contained in Public", c.SelfID)
+	}
+	var expected ristretto.Element
+	expected.ScalarBaseMult(&c.SecretShare.Secret)
+	if expected.Equal(&c.SecretShare.Public) != 1 || publicShare.Equal(&c.SecretShare.Public) != 1 {
+		return errors.New("frost.Config: SecretShare does not match its public share")
+	}
+	return nil
+}
+
+// NewSignState is NewSignState with the key material of c, once c is validated.
+func (c *Config) NewSignState(partyIDs party.IDSlice, message []byte, timeout time.Duration, opts ...sign.Option) (*state.State, *sign.Output, error) {
+	if err := c.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return NewSignState(partyIDs, c.SecretShare, c.Public, message, timeout, opts...)
+}
+
+// NewSignBatchState is NewSignBatchState with the key material of c, once c is validated.
+func (c *Config) NewSignBatchState(partyIDs party.IDSlice, msgs [][]byte, timeout time.Duration, opts ...sign.Option) (*state.State, []*sign.Output, error) {
+	if err := c.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return NewSignBatchState(partyIDs, c.SecretShare, c.Public, msgs, timeout, opts...)
+}
+
+// NewRefreshState is NewRefreshState with the key material of c, once c is validated.
+func (c *Config) NewRefreshState(timeout time.Duration) (*state.State, *refresh.Output, error) {
+	if err := c.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return NewRefreshState(c.SecretShare, c.Public, timeout)
+}
@@ -0,0 +1,167 @@
+package frost
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/polynomial"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+// TrustedDeal generates a new secret key, and splits it into shares for the parties in partyIDs,
+// so that any threshold+1 of them can sign with NewSignState. It is an alternative to NewKeygenState,
+// in which a single dealer learns the secret key, and is trusted to erase it along with the shares
+// of the other parties once they have been distributed.
+//
+// The public shares are the evaluations [f(i)]•B of the polynomial in the exponent, so every party
+// can check that its share is consistent with the returned eddsa.Public.
+// The randomness is read from random, or from crypto/rand.Reader if it is nil.
+func TrustedDeal(threshold party.Size, partyIDs []party.ID, random io.Reader) (*eddsa.Public, map[party.ID]*eddsa.SecretShare, error) {
+	if random == nil {
+		random = rand.Reader
+	}
+	secret, err := ristretto.RandomScalar(random)
+	if err != nil {
+		return nil, nil, fmt.Errorf("frost.TrustedDeal: failed to read random bytes: %w", err)
+	}
+	defer secret.Set(ristretto.NewScalar())
+
+	public, shares, err := splitSecret(secret, threshold, partyIDs, random)
+	if err != nil {
+		return nil, nil, fmt.Errorf("frost.TrustedDeal: %w", err)
+	}
+	return public, shares, nil
+}
+
+// SplitExistingKey splits an existing Ed25519 private key into shares for the parties in partyIDs,
+// so that any threshold+1 of them can produce signatures which verify under priv.Public() with ed25519.Verify.
+// Like TrustedDeal, it must be run by a trusted dealer, who erases priv and the shares once they have been distributed.
+//
+// The secret scalar is derived from the seed of priv as in RFC 8032, by clamping the first half
+// of SHA-512(seed). The randomness for the polynomial is read from random,
+// or from crypto/rand.Reader if it is nil.
+func SplitExistingKey(priv ed25519.PrivateKey, threshold party.Size, partyIDs []party.ID, random io.Reader) (*eddsa.Public, map[party.ID]*eddsa.SecretShare, error) {
+	if len(priv) != ed25519.PrivateKeySize {
+		return nil, nil, errors.New("frost.SplitExistingKey: invalid private key size")
+	}
+	if random == nil {
+		random = rand.Reader
+	}
+
+	digest := sha512.Sum512(priv.Seed())
+	defer zero(digest[:])
+	var secret ristretto.Scalar
+	if _, err := secret.SetBytesWithClamping(digest[:32]); err != nil {
+		return nil, nil, fmt.Errorf("frost.SplitExistingKey: %w", err)
+	}
+	defer secret.Set(ristretto.NewScalar())
+
+	public, shares, err := splitSecret(&secret, threshold, partyIDs, random)
+	if err != nil {
+		return nil, nil, fmt.Errorf("frost.SplitExistingKey: %w", err)
+	}
+
+	// The public part of priv is not used for signing, but it must match the secret.
+	if !bytes.Equal(public.GroupKey.ToEd25519(), priv.Public().(ed25519.PublicKey)) {
+		return nil, nil, errors.New("frost.SplitExistingKey: public key does not correspond to the seed of priv")
+	}
+	return public, shares, nil
+}
+
+// Reconstruct interpolates the group's secret key from the shares of a quorum of at least public.Threshold+1 parties.
+//
+// WARNING: this defeats the purpose of threshold signing, since whoever calls it learns the secret key, and can sign
+// alone. It should only be used to escrow the key, or to migrate away from the threshold scheme.
+// The result should be erased once it is no longer needed.
+//
+// An error is returned if there are not enough shares, if the ID of a share does not match its key in shares,
+// or if a share is not consistent with public, including when the reconstructed secret does not match the GroupKey.
+func Reconstruct(shares map[party.ID]*eddsa.SecretShare, public *eddsa.Public) (*ristretto.Scalar, error) {
+	if party.Size(len(shares)) <= public.Threshold {
+		return nil, fmt.Errorf("frost.Reconstruct: at least %d shares are required, but only %d were given",
+			public.Threshold+1, len(shares))
+	}
+
+	quorum := make([]party.ID, 0, len(shares))
+	for id := range shares {
+		quorum = append(quorum, id)
+	}
+	if err := party.ValidateIDs(quorum); err != nil {
+		return nil, fmt.Errorf("frost.Reconstruct: %w", err)
+	}
+
+	var publicShare ristretto.Element
+	for id, share := range shares {
+		if share == nil || share.ID != id {
+			return nil, fmt.Errorf("frost.Reconstruct: share given for party %d does not belong to it", id)
+		}
+		expected, ok := public.Shares[id]
+		if !ok {
+			return nil, fmt.Errorf("frost.Reconstruct: party %d is not contained in public", id)
+		}
+		if publicShare.ScalarBaseMult(&share.Secret).Equal(expected) != 1 {
+			return nil, fmt.Errorf("frost.Reconstruct: share of party %d is not consistent with its public share", id)
+		}
+	}
+
+	lagrange, err := party.LagrangeCoefficients(quorum)
+	if err != nil {
+		return nil, fmt.Errorf("frost.Reconstruct: %w", err)
+	}
+	secret := ristretto.NewScalar()
+	for id, share := range shares {
+		secret.MultiplyAdd(lagrange[id], &share.Secret, secret)
+	}
+
+	var groupKey ristretto.Element
+	groupKey.ScalarBaseMult(secret)
+	if !public.GroupKey.Equal(eddsa.NewPublicKeyFromPoint(&groupKey)) {
+		secret.Set(ristretto.NewScalar())
+		return nil, errors.New("frost.Reconstruct: reconstructed secret does not match the group key")
+	}
+	return secret, nil
+}
+
+// zero overwrites b with zeros.
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// splitSecret creates a Shamir sharing of secret among partyIDs, with a random polynomial of degree threshold.
+func splitSecret(secret *ristretto.Scalar, threshold party.Size, partyIDs []party.ID, random io.Reader) (*eddsa.Public, map[party.ID]*eddsa.SecretShare, error) {
+	if err := party.ValidateIDs(partyIDs); err != nil {
+		return nil, nil, err
+	}
+	set := party.NewIDSlice(partyIDs)
+	if err := party.ValidateThreshold(threshold, set.N()); err != nil {
+		return nil, nil, err
+	}
+
+	poly, err := polynomial.NewPolynomialFromReader(threshold, secret, random)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read random bytes: %w", err)
+	}
+	defer poly.Reset()
+
+	shares := make(map[party.ID]*eddsa.SecretShare, len(set))
+	publicShares := make(map[party.ID]*ristretto.Element, len(set))
+	for _, id := range set {
+		shares[id] = eddsa.NewSecretShare(id, poly.Evaluate(id.Scalar()))
+		publicShares[id] = new(ristretto.Element).Set(&shares[id].Public)
+	}
+
+	public, err := eddsa.NewPublic(publicShares, threshold)
+	if err != nil {
+		return nil, nil, err
+	}
+	return public, shares, nil
+}
@@ -0,0 +1,102 @@
+package frost
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/polynomial"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// MinRootSecretSize is the minimum length of the root secret given to DeriveSecretShare and DeriveTrustedDeal.
+	MinRootSecretSize = 32
+
+	deriveDomainSeparation = "FROST-Ed25519-derive-share"
+)
+
+// DeriveSecretShare deterministically derives the share of party id from rootSecret, for a sharing with the given
+// threshold, so that a stateless signer can recompute its share instead of storing it.
+// The shares derived from the same rootSecret, threshold and info are those returned by DeriveTrustedDeal,
+// and any threshold+1 of them interpolate to the same group key. Different info give unrelated keys.
+//
+// The polynomial f of degree threshold is derived with HKDF-SHA512, keyed with rootSecret and whose info is
+//
+//	"FROST-Ed25519-derive-share" ∥ uint32(threshold) ∥ info
+//
+// from whose output the secret f(0) and then the coefficients a₁, ..., aₜ are read as with ristretto.RandomScalar.
+// The share of party id is f(id).
+//
+// WARNING: rootSecret determines all shares, and therefore the group's secret key. Whoever knows it can sign alone,
+// exactly as the dealer of TrustedDeal. It is only appropriate when the dealer, or every signer holding rootSecret,
+// is trusted, for example when all signers run in the same trust domain and only derive their share on demand.
+// rootSecret must be uniformly random, and at least MinRootSecretSize bytes long. The threshold must be less than 255.
+func DeriveSecretShare(rootSecret []byte, threshold party.Size, id party.ID, info []byte) (*eddsa.SecretShare, error) {
+	if id == 0 {
+		return nil, errors.New("frost.DeriveSecretShare: id was 0 (invalid)")
+	}
+	poly, err := derivePolynomial(rootSecret, threshold, info)
+	if err != nil {
+		return nil, fmt.Errorf("frost.DeriveSecretShare: %w", err)
+	}
+	defer poly.Reset()
+	return eddsa.NewSecretShare(id, poly.Evaluate(id.Scalar())), nil
+}
+
+// DeriveTrustedDeal is like TrustedDeal, but derives the secret key and the shares of partyIDs from rootSecret,
+// as DeriveSecretShare does. The same warnings apply, since the dealer learns the secret key.
+func DeriveTrustedDeal(rootSecret []byte, threshold party.Size, partyIDs []party.ID, info []byte) (*eddsa.Public, map[party.ID]*eddsa.SecretShare, error) {
+	r, err := deriveReader(rootSecret, threshold, info)
+	if err != nil {
+		return nil, nil, fmt.Errorf("frost.DeriveTrustedDeal: %w", err)
+	}
+	secret, err := ristretto.RandomScalar(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("frost.DeriveTrustedDeal: %w", err)
+	}
+	defer secret.Set(ristretto.NewScalar())
+
+	public, shares, err := splitSecret(secret, threshold, partyIDs, r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("frost.DeriveTrustedDeal: %w", err)
+	}
+	return public, shares, nil
+}
+
+// derivePolynomial returns the polynomial of degree threshold derived from rootSecret and info.
+func derivePolynomial(rootSecret []byte, threshold party.Size, info []byte) (*polynomial.Polynomial, error) {
+	r, err := deriveReader(rootSecret, threshold, info)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := ristretto.RandomScalar(r)
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Set(ristretto.NewScalar())
+	return polynomial.NewPolynomialFromReader(threshold, secret, r)
+}
+
+// deriveReader returns the HKDF-SHA512 output from which the polynomial is read.
+func deriveReader(rootSecret []byte, threshold party.Size, info []byte) (io.Reader, error) {
+	if len(rootSecret) < MinRootSecretSize {
+		return nil, fmt.Errorf("root secret must be at least %d bytes long", MinRootSecretSize)
+	}
+	// HKDF-SHA512 outputs at most 255 • 64 bytes, and each of the threshold+1 scalars reads 64 of them
+	if threshold >= 255 {
+		return nil, errors.New("threshold must be less than 255")
+	}
+	var t [4]byte
+	binary.BigEndian.PutUint32(t[:], uint32(threshold))
+	hkdfInfo := make([]byte, 0, len(deriveDomainSeparation)+len(t)+len(info))
+	hkdfInfo = append(hkdfInfo, deriveDomainSeparation...)
+	hkdfInfo = append(hkdfInfo, t[:]...)
+	hkdfInfo = append(hkdfInfo, info...)
+	return hkdf.New(sha512.New, rootSecret, nil, hkdfInfo), nil
+}
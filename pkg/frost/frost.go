@@ -1,11 +1,16 @@
 package frost
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"time"
 
 	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
 	"github.com/taurusgroup/frost-ed25519/pkg/frost/keygen"
 	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/refresh"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/reshare"
 	"github.com/taurusgroup/frost-ed25519/pkg/frost/sign"
 	"github.com/taurusgroup/frost-ed25519/pkg/state"
 )
@@ -13,8 +18,8 @@ import (
 // NewKeygenState returns a state.State which coordinates the multiple rounds.
 // The second parameter is the output of the protocol and will be filled with the output once the protocol has finished executing.
 // It is safe to use the output when State.WaitForError() returns nil.
-func NewKeygenState(selfID party.ID, partyIDs party.IDSlice, threshold party.Size, timeout time.Duration) (*state.State, *keygen.Output, error) {
-	round, output, err := keygen.NewRound(selfID, partyIDs, threshold)
+func NewKeygenState(selfID party.ID, partyIDs party.IDSlice, threshold party.Size, timeout time.Duration, opts ...keygen.Option) (*state.State, *keygen.Output, error) {
+	round, output, err := keygen.NewRound(selfID, partyIDs, threshold, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -30,8 +35,9 @@ func NewKeygenState(selfID party.ID, partyIDs party.IDSlice, threshold party.Siz
 // NewSignState returns a state.State which coordinates the multiple rounds.
 // The second parameter is the output of the protocol and will be filled with the output once the protocol has finished executing.
 // It is safe to use the output when State.WaitForError() returns nil.
-func NewSignState(partyIDs party.IDSlice, secret *eddsa.SecretShare, shares *eddsa.Public, message []byte, timeout time.Duration) (*state.State, *sign.Output, error) {
-	round, output, err := sign.NewRound(partyIDs, secret, shares, message)
+// The options, such as sign.WithCiphersuite, configure the signing session and must agree across all signers.
+func NewSignState(partyIDs party.IDSlice, secret *eddsa.SecretShare, shares *eddsa.Public, message []byte, timeout time.Duration, opts ...sign.Option) (*state.State, *sign.Output, error) {
+	round, output, err := sign.NewRound(partyIDs, secret, shares, message, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -39,3 +45,100 @@ func NewSignState(partyIDs party.IDSlice, secret *eddsa.SecretShare, shares *edd
 
 	return s, output, nil
 }
+
+// RestartSignState returns a state.State for a new signing session of message by the responders,
+// after a session with the given quorum aborted because some of its signers did not respond.
+// The coordinator usually chooses the responders from the state.TimeoutError of the aborted session,
+// as the parties in TimeoutError.Received together with itself, and sends them to all responders.
+//
+// The responders must be a subset of quorum containing the owner of secret, with at least shares.Threshold+1 parties.
+// The new session starts from scratch with fresh nonces, and nothing from the aborted session is reused,
+// so the commitments of the aborted session must not be given to it.
+func RestartSignState(quorum, responders party.IDSlice, secret *eddsa.SecretShare, shares *eddsa.Public, message []byte, timeout time.Duration, opts ...sign.Option) (*state.State, *sign.Output, error) {
+	responders = party.NewIDSlice(responders)
+	if unknown := responders.Difference(party.NewIDSlice(quorum)); len(unknown) > 0 {
+		return nil, nil, fmt.Errorf("frost.RestartSignState: responders %v were not part of the aborted quorum", unknown)
+	}
+	if !responders.Contains(secret.ID) {
+		return nil, nil, errors.New("frost.RestartSignState: owner of SecretShare is not one of the responders")
+	}
+	return NewSignState(responders, secret, shares, message, timeout, opts...)
+}
+
+// NewRefreshState returns a state.State which coordinates the multiple rounds of a share refresh.
+// Every party in shares must participate, and each obtains a new SecretShare and eddsa.Public with the same GroupKey.
+// The previous shares cannot be combined with the refreshed ones, and should be erased once the protocol succeeds.
+// It is safe to use the output when State.WaitForError() returns nil.
+func NewRefreshState(secret *eddsa.SecretShare, shares *eddsa.Public, timeout time.Duration) (*state.State, *refresh.Output, error) {
+	round, output, err := refresh.NewRound(secret, shares)
+	if err != nil {
+		return nil, nil, err
+	}
+	s, err := state.NewBaseState(round, timeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s, output, nil
+}
+
+// NewReshareState returns a state.State which coordinates the multiple rounds of a resharing.
+// The dealers are a quorum of the parties in previous, who share the group's secret to the receivers
+// so that any threshold+1 of them can sign for the same GroupKey. A party which is only a receiver may give a nil secret.
+// The shares of the previous access structure cannot be combined with the new ones.
+// It is safe to use the output when State.WaitForError() returns nil.
+func NewReshareState(selfID party.ID, secret *eddsa.SecretShare, previous *eddsa.Public, dealers, receivers party.IDSlice, threshold party.Size, timeout time.Duration) (*state.State, *reshare.Output, error) {
+	round, output, err := reshare.NewRound(selfID, secret, previous, dealers, receivers, threshold)
+	if err != nil {
+		return nil, nil, err
+	}
+	s, err := state.NewBaseState(round, timeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s, output, nil
+}
+
+// NewSignBatchState returns a state.State which signs all msgs in the same two rounds of communication.
+// The i-th Output is filled with the signature of the i-th message once the protocol has finished executing.
+// It is safe to use the outputs when State.WaitForError() returns nil.
+func NewSignBatchState(partyIDs party.IDSlice, secret *eddsa.SecretShare, shares *eddsa.Public, msgs [][]byte, timeout time.Duration, opts ...sign.Option) (*state.State, []*sign.Output, error) {
+	round, outputs, err := sign.NewBatchRound(partyIDs, secret, shares, msgs, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	s, err := state.NewBaseState(round, timeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s, outputs, nil
+}
+
+// NewSignMultiKeyState returns a state.State which signs message under every key in the same two rounds of communication.
+// The i-th key is given by secrets[i] and shares[i], and the i-th Output is filled with the signature which verifies
+// under shares[i].GroupKey once the protocol has finished executing.
+// It is safe to use the outputs when State.WaitForError() returns nil.
+func NewSignMultiKeyState(partyIDs party.IDSlice, secrets []*eddsa.SecretShare, shares []*eddsa.Public, message []byte, timeout time.Duration, opts ...sign.Option) (*state.State, []*sign.Output, error) {
+	round, outputs, err := sign.NewMultiKeyRound(partyIDs, secrets, shares, message, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	s, err := state.NewBaseState(round, timeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s, outputs, nil
+}
+
+// NewSignStateFromReader is like NewSignState, but reads the message from r and signs it with Ed25519ph.
+// See sign.NewRoundFromReader.
+func NewSignStateFromReader(partyIDs party.IDSlice, secret *eddsa.SecretShare, shares *eddsa.Public, r io.Reader, timeout time.Duration, opts ...sign.Option) (*state.State, *sign.Output, error) {
+	round, output, err := sign.NewRoundFromReader(partyIDs, secret, shares, r, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	s, err := state.NewBaseState(round, timeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s, output, nil
+}
@@ -1,8 +1,10 @@
 package keygen
 
 import (
-	"errors"
+	"crypto/rand"
+	"io"
 
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
 	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
 	"github.com/taurusgroup/frost-ed25519/pkg/internal/polynomial"
 	"github.com/taurusgroup/frost-ed25519/pkg/messages"
@@ -32,6 +34,15 @@ type (
 		// Commitments contains all other parties commitment polynomials
 		Commitments map[party.ID]*polynomial.Exponent
 
+		// shares contains the shares received from the other parties, until they are verified and added to Secret.
+		shares map[party.ID]*ristretto.Scalar
+
+		// random is the source of randomness for the polynomial and the proof of knowledge.
+		random io.Reader
+
+		// hasher computes the challenges of the proofs of knowledge.
+		hasher eddsa.Hasher
+
 		Output *Output
 	}
 	round1 struct {
@@ -40,16 +51,21 @@ type (
 	round2 struct {
 		*round1
 	}
+	round3 struct {
+		*round2
+	}
 )
 
-func NewRound(selfID party.ID, partyIDs party.IDSlice, threshold party.Size) (state.Round, *Output, error) {
+// NewRound returns the first round of the key generation for the party selfID.
+// threshold must satisfy 1 ≤ threshold ≤ N-1, so that threshold+1 of the N parties are required to sign.
+func NewRound(selfID party.ID, partyIDs party.IDSlice, threshold party.Size, opts ...Option) (state.Round, *Output, error) {
 	N := partyIDs.N()
 
-	if threshold == 0 {
-		return nil, nil, errors.New("threshold must be at least 1, or a minimum of T+1=2 signers")
+	if err := party.ValidateIDs(partyIDs); err != nil {
+		return nil, nil, err
 	}
-	if threshold > N-1 {
-		return nil, nil, errors.New("threshold must be at most N-1, or a maximum of T+1=N signers")
+	if err := party.ValidateThreshold(threshold, N); err != nil {
+		return nil, nil, err
 	}
 
 	baseRound, err := state.NewBaseRound(selfID, partyIDs)
@@ -61,7 +77,13 @@ func NewRound(selfID party.ID, partyIDs party.IDSlice, threshold party.Size) (st
 		BaseRound:   baseRound,
 		Threshold:   threshold,
 		Commitments: make(map[party.ID]*polynomial.Exponent, N),
+		shares:      make(map[party.ID]*ristretto.Scalar, N),
 		Output:      &Output{},
+		random:      rand.Reader,
+		hasher:      eddsa.SHA512,
+	}
+	for _, opt := range opts {
+		opt(&r)
 	}
 
 	return &r, r.Output, nil
@@ -69,11 +91,20 @@ func NewRound(selfID party.ID, partyIDs party.IDSlice, threshold party.Size) (st
 
 func (round *round0) Reset() {
 	round.Secret.Set(ristretto.NewScalar())
-	round.Polynomial.Reset()
-	round.CommitmentsSum.Reset()
+	// The polynomials are only set once the first round has generated its messages
+	if round.Polynomial != nil {
+		round.Polynomial.Reset()
+	}
+	if round.CommitmentsSum != nil {
+		round.CommitmentsSum.Reset()
+	}
 	for _, p := range round.Commitments {
 		p.Reset()
 	}
+	for id, share := range round.shares {
+		share.Zeroize()
+		delete(round.shares, id)
+	}
 	round.Output = nil
 }
 
@@ -82,5 +113,6 @@ func (round *round0) Reset() {
 // ---
 
 func (round *round0) AcceptedMessageTypes() []messages.MessageType {
-	return []messages.MessageType{messages.MessageTypeNone, messages.MessageTypeKeyGen1, messages.MessageTypeKeyGen2}
+	return []messages.MessageType{messages.MessageTypeNone, messages.MessageTypeKeyGen1, messages.MessageTypeKeyGen2,
+		messages.MessageTypeKeyGen3}
 }
@@ -0,0 +1,196 @@
+package keygen
+
+import (
+	"crypto/sha512"
+	"errors"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/polynomial"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+// sessionDomainSeparation is used to derive the SessionID of a key generation.
+var sessionDomainSeparation = []byte("FROST-KEYGEN-SESSION")
+
+// SessionID implements state.MarshalableRound.
+// It is a hash of the protocol and all parameters given to NewRound:
+//
+//	SHA-512/256("FROST-KEYGEN-SESSION" ∥ Threshold ∥ SelfID ∥ PartyIDs)
+func (round *round0) SessionID() []byte {
+	data := make([]byte, 0, len(sessionDomainSeparation)+int(round.PartyIDs().N()+2)*party.IDByteSize)
+	data = append(data, sessionDomainSeparation...)
+	data = append(data, round.Threshold.Bytes()...)
+	data = append(data, round.SelfID().Bytes()...)
+	for _, id := range round.PartyIDs() {
+		data = append(data, id.Bytes()...)
+	}
+
+	digest := sha512.Sum512_256(data)
+	return digest[:]
+}
+
+// Flags describing which parts of the round are set in its encoding.
+const (
+	hasPolynomial byte = 1 << iota
+	hasCommitment
+	hasShare
+)
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface, and is used by state.State.MarshalBinary.
+// The output contains the Secret, followed by the party's polynomial and the sum of the commitments if they were generated,
+// and the commitments and shares received from each other party.
+// A flag byte precedes the optional values.
+func (round *round0) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 32+1+round.polynomialSize()+round.polynomialSize()+
+		int(round.PartyIDs().N())*(1+round.polynomialSize()+32))
+	data = append(data, round.Secret.Bytes()...)
+
+	if round.Polynomial == nil {
+		data = append(data, 0)
+	} else {
+		data = append(data, hasPolynomial)
+		polynomialData, err := round.Polynomial.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, polynomialData...)
+		if data, err = round.CommitmentsSum.BytesAppend(data); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, id := range round.PartyIDs() {
+		if id == round.SelfID() {
+			continue
+		}
+		commitment, okCommitment := round.Commitments[id]
+		share, okShare := round.shares[id]
+
+		var flags byte
+		if okCommitment {
+			flags |= hasCommitment
+		}
+		if okShare {
+			flags |= hasShare
+		}
+		data = append(data, flags)
+
+		if okCommitment {
+			var err error
+			if data, err = commitment.BytesAppend(data); err != nil {
+				return nil, err
+			}
+		}
+		if okShare {
+			data = append(data, share.Bytes()...)
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface, and is used by state.State.UnmarshalBinary.
+// The round must have been created by NewRound with the same parameters. It is unchanged if an error is returned.
+func (round *round0) UnmarshalBinary(data []byte) error {
+	errSize := errors.New("keygen: serialized round has the wrong size")
+
+	var secret ristretto.Scalar
+	if len(data) < 32+1 {
+		return errSize
+	}
+	if _, err := secret.SetCanonicalBytesConstantTime(data[:32]); err != nil {
+		return err
+	}
+	flags := data[32]
+	data = data[33:]
+
+	var (
+		p              *polynomial.Polynomial
+		commitmentsSum *polynomial.Exponent
+		err            error
+	)
+	switch flags {
+	case 0:
+	case hasPolynomial:
+		if len(data) < round.polynomialSize()+round.polynomialSize() {
+			return errSize
+		}
+		p = &polynomial.Polynomial{}
+		if err = p.UnmarshalBinary(data[:round.polynomialSize()]); err != nil {
+			return err
+		}
+		data = data[round.polynomialSize():]
+		if commitmentsSum, err = round.unmarshalExponent(data[:round.polynomialSize()]); err != nil {
+			return err
+		}
+		data = data[round.polynomialSize():]
+	default:
+		return errors.New("keygen: invalid flags")
+	}
+
+	commitments := make(map[party.ID]*polynomial.Exponent, len(round.PartyIDs()))
+	shares := make(map[party.ID]*ristretto.Scalar, len(round.PartyIDs()))
+	for _, id := range round.PartyIDs() {
+		if id == round.SelfID() {
+			continue
+		}
+		if len(data) < 1 {
+			return errSize
+		}
+		flags, data = data[0], data[1:]
+		if flags&^(hasCommitment|hasShare) != 0 {
+			return errors.New("keygen: invalid flags")
+		}
+
+		if flags&hasCommitment != 0 {
+			if len(data) < round.polynomialSize() {
+				return errSize
+			}
+			if commitments[id], err = round.unmarshalExponent(data[:round.polynomialSize()]); err != nil {
+				return err
+			}
+			data = data[round.polynomialSize():]
+		}
+		if flags&hasShare != 0 {
+			if len(data) < 32 {
+				return errSize
+			}
+			var share ristretto.Scalar
+			if _, err = share.SetCanonicalBytesConstantTime(data[:32]); err != nil {
+				return err
+			}
+			shares[id] = &share
+			data = data[32:]
+		}
+	}
+	if len(data) != 0 {
+		return errSize
+	}
+
+	round.Secret.Set(&secret)
+	round.Polynomial = p
+	round.CommitmentsSum = commitmentsSum
+	for id, c := range commitments {
+		round.Commitments[id] = c
+	}
+	for id, share := range shares {
+		round.shares[id] = share
+	}
+	return nil
+}
+
+// unmarshalExponent decodes a polynomial.Exponent, and checks that its degree is the Threshold.
+func (round *round0) unmarshalExponent(data []byte) (*polynomial.Exponent, error) {
+	var p polynomial.Exponent
+	if err := p.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	if p.Degree() != round.Threshold {
+		return nil, errors.New("keygen: polynomial has the wrong degree")
+	}
+	return &p, nil
+}
+
+// polynomialSize is the size of the encoding of a polynomial.Polynomial or polynomial.Exponent of degree Threshold.
+func (round *round0) polynomialSize() int {
+	return party.IDByteSize + 32*(int(round.Threshold)+1)
+}
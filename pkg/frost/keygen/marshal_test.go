@@ -0,0 +1,103 @@
+package keygen
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/helpers"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+func TestState_MarshalBinary(t *testing.T) {
+	partyIDs := helpers.GenerateSet(4)
+	threshold := party.Size(2)
+
+	// Deterministic randomness, so that both executions give the same output
+	newState := func(id party.ID, threshold party.Size) (*state.State, *Output) {
+		r, output, err := NewRound(id, partyIDs, threshold, WithRandom(rand.New(rand.NewSource(int64(id)))))
+		require.NoError(t, err)
+		s, err := state.NewBaseState(r, 0)
+		require.NoError(t, err)
+		return s, output
+	}
+
+	// restart runs the keygen, and restarts the first party after it received one message of the given type.
+	// It returns the outputs of all parties.
+	run := func(restart messages.MessageType) []*Output {
+		states := make([]*state.State, len(partyIDs))
+		outputs := make([]*Output, len(partyIDs))
+		for i, id := range partyIDs {
+			states[i], outputs[i] = newState(id, threshold)
+		}
+
+		// The messages are sent as bytes, since the outgoing messages may share data with the round
+		send := func(msgs []*messages.Message) [][]byte {
+			out := make([][]byte, 0, len(msgs))
+			for _, msg := range msgs {
+				data, err := msg.MarshalBinary()
+				require.NoError(t, err)
+				out = append(out, data)
+			}
+			return out
+		}
+
+		var msgs [][]byte
+		for _, s := range states {
+			msgs = append(msgs, send(s.ProcessAll())...)
+		}
+		for round := 0; round < 3; round++ {
+			var next [][]byte
+			for i, s := range states {
+				for _, msgData := range msgs {
+					var msg messages.Message
+					require.NoError(t, msg.UnmarshalBinary(msgData))
+					if msg.From == partyIDs[i] || (msg.To != 0 && msg.To != partyIDs[i]) {
+						continue
+					}
+					require.NoError(t, s.HandleMessage(&msg))
+
+					if i != 0 || msg.Type != restart {
+						continue
+					}
+					restart = messages.MessageTypeNone
+					data, err := s.MarshalBinary()
+					require.NoError(t, err)
+
+					// The state can only be restored in the same session
+					other, _ := newState(partyIDs[1], threshold)
+					assert.ErrorIs(t, other.UnmarshalBinary(data), state.ErrStateSessionID)
+					other, _ = newState(partyIDs[0], threshold-1)
+					assert.ErrorIs(t, other.UnmarshalBinary(data), state.ErrStateSessionID)
+					other, _ = newState(partyIDs[0], threshold)
+					wrongVersion := append([]byte{}, data...)
+					wrongVersion[0]++
+					assert.ErrorIs(t, other.UnmarshalBinary(wrongVersion), state.ErrStateVersion)
+
+					states[0], outputs[0] = newState(partyIDs[0], threshold)
+					require.NoError(t, states[0].UnmarshalBinary(data))
+					s = states[0]
+				}
+				next = append(next, send(s.ProcessAll())...)
+			}
+			msgs = next
+		}
+
+		for _, s := range states {
+			require.NoError(t, s.WaitForError())
+		}
+		return outputs
+	}
+
+	expected := run(messages.MessageTypeNone)
+	for _, restart := range []messages.MessageType{messages.MessageTypeKeyGen1, messages.MessageTypeKeyGen2, messages.MessageTypeKeyGen3} {
+		outputs := run(restart)
+		for i := range partyIDs {
+			assert.True(t, expected[i].SecretKey.Equal(outputs[i].SecretKey), "restart after %s", restart)
+			assert.True(t, expected[i].Public.Equal(outputs[i].Public), "restart after %s", restart)
+		}
+	}
+}
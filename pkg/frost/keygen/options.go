@@ -0,0 +1,27 @@
+package keygen
+
+import (
+	"io"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+)
+
+// An Option modifies the parameters of a key generation.
+type Option func(*round0)
+
+// WithRandom sets the source of randomness from which the party samples its polynomial and proof of knowledge.
+// It defaults to crypto/rand.Reader, and should only be set to a deterministic reader when the shares
+// must be reproducible, for example in tests. Other parties may use different options.
+func WithRandom(r io.Reader) Option {
+	return func(round *round0) {
+		round.random = r
+	}
+}
+
+// WithHasher sets the eddsa.Hasher used to compute the challenges of the proofs of knowledge.
+// It defaults to eddsa.SHA512, and all parties must use the same one.
+func WithHasher(h eddsa.Hasher) Option {
+	return func(round *round0) {
+		round.hasher = h
+	}
+}
@@ -1,8 +1,20 @@
 package keygen
 
-import "github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+import (
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
 
+// Output contains the result of the key generation, once the protocol has finished successfully.
 type Output struct {
-	Public    *eddsa.Public
+	// Public contains the GroupKey, as well as the public verification share of every party.
+	// It is the same for all parties, and can be distributed to verifiers of partial signatures.
+	Public *eddsa.Public
+
+	// SecretKey is the secret share of this party. Its public key is Public.Shares[SecretKey.ID].
 	SecretKey *eddsa.SecretShare
+
+	// Commitments are the Feldman VSS commitments [a₀]•B, ..., [aₜ]•B to the coefficients of the polynomial
+	// f whose evaluations are the secret shares. The public share of party i is f(i)•B, and the GroupKey is [a₀]•B.
+	Commitments []*ristretto.Element
 }
@@ -1,10 +1,12 @@
 package keygen
 
 import (
+	"fmt"
+
 	"github.com/taurusgroup/frost-ed25519/pkg/internal/polynomial"
-	"github.com/taurusgroup/frost-ed25519/pkg/internal/scalar"
 	"github.com/taurusgroup/frost-ed25519/pkg/internal/zk"
 	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
 	"github.com/taurusgroup/frost-ed25519/pkg/state"
 )
 
@@ -14,11 +16,18 @@ func (round *round0) ProcessMessage(*messages.Message) *state.Error {
 
 func (round *round0) GenerateMessages() ([]*messages.Message, *state.Error) {
 	// Sample a_i,0 which is the constant factor of the polynomial
-	scalar.SetScalarRandom(&round.Secret)
+	secret, err := ristretto.RandomScalar(round.random)
+	if err != nil {
+		return nil, state.NewError(0, fmt.Errorf("keygen: failed to read random bytes: %w", err))
+	}
+	round.Secret.Set(secret)
 
 	// Sample the remaining coefficients, and obtain a polynomial
 	// of degree t.
-	round.Polynomial = polynomial.NewPolynomial(round.Threshold, &round.Secret)
+	round.Polynomial, err = polynomial.NewPolynomialFromReader(round.Threshold, &round.Secret, round.random)
+	if err != nil {
+		return nil, state.NewError(0, fmt.Errorf("keygen: failed to read random bytes: %w", err))
+	}
 
 	// Generate all commitments [a_{i j}] B for j = 0, 1, ..., t
 	// CommitmentsSum holds the sum of all commitments, so we initialize it to our commitment
@@ -28,7 +37,10 @@ func (round *round0) GenerateMessages() ([]*messages.Message, *state.Error) {
 	ctx := make([]byte, 32)
 	public := round.CommitmentsSum.Constant()
 	// Generate proof of knowledge of a_i,0 = f(0)
-	proof := zk.NewSchnorrProof(round.SelfID(), public, ctx, &round.Secret)
+	proof, err := zk.NewSchnorrProofFromReader(round.SelfID(), public, ctx, &round.Secret, round.random, round.hasher)
+	if err != nil {
+		return nil, state.NewError(0, fmt.Errorf("keygen: failed to read random bytes: %w", err))
+	}
 
 	// We use the variable Secret to hold the sum of all shares received.
 	// Therefore, we can set it to the share we would send to our selves.
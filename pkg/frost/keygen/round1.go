@@ -12,9 +12,19 @@ func (round *round1) ProcessMessage(msg *messages.Message) *state.Error {
 	ctx := make([]byte, 32)
 	from := msg.From
 
+	if msg.KeyGen1.Commitments.Degree() != round.Threshold {
+		return state.NewValidationError(from, errors.New("commitments have the wrong degree"))
+	}
+	// An identity coefficient indicates a degenerate polynomial, such as one with a known constant term or a lower degree.
+	for _, c := range msg.KeyGen1.Commitments.Coefficients() {
+		if c.IsIdentity() == 1 {
+			return state.NewValidationError(from, errors.New("commitments contain the identity"))
+		}
+	}
+
 	public := msg.KeyGen1.Commitments.Constant()
-	if !msg.KeyGen1.Proof.Verify(from, public, ctx) {
-		return state.NewError(from, errors.New("ZK Schnorr failed"))
+	if !msg.KeyGen1.Proof.VerifyWithHasher(from, public, ctx, round.hasher) {
+		return state.NewAbortError(errors.New("ZK Schnorr failed"), from)
 	}
 
 	round.Commitments[from] = msg.KeyGen1.Commitments
@@ -3,7 +3,6 @@ package keygen
 import (
 	"errors"
 
-	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
 	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
 	"github.com/taurusgroup/frost-ed25519/pkg/messages"
 	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
@@ -11,16 +10,10 @@ import (
 )
 
 func (round *round2) ProcessMessage(msg *messages.Message) *state.Error {
-	var computedShareExp ristretto.Element
-	computedShareExp.ScalarBaseMult(&msg.KeyGen2.Share)
-
-	id := msg.From
-	shareExp := round.Commitments[id].Evaluate(round.SelfID().Scalar())
-
-	if computedShareExp.Equal(shareExp) != 1 {
-		return state.NewError(id, errors.New("VSS failed to validate"))
-	}
-	round.Secret.Add(&round.Secret, &msg.KeyGen2.Share)
+	// The shares are verified in GenerateMessages, so that all parties with an invalid share are reported
+	var share ristretto.Scalar
+	share.Set(&msg.KeyGen2.Share)
+	round.shares[msg.From] = &share
 
 	// We can reset the share in the message now
 	msg.KeyGen2.Share.Set(ristretto.NewScalar())
@@ -28,23 +21,44 @@ func (round *round2) ProcessMessage(msg *messages.Message) *state.Error {
 	return nil
 }
 
-func (round *round2) GenerateMessages() ([]*messages.Message, *state.Error) {
-	shares := make(map[party.ID]*ristretto.Element, round.PartyIDs().N())
+// verifyShares returns the parties whose share does not match the commitments of their polynomial,
+// in increasing order.
+//
+// The shares are checked individually, since a random linear combination of the checks is only faster
+// for a few parties, and not above about 20 as measured by BenchmarkVerifyShares:
+// the evaluation of each polynomial uses the short scalars iᵏ, while the combination needs full-size ones.
+func (round *round2) verifyShares() []party.ID {
+	var culprits []party.ID
 	for _, id := range round.PartyIDs() {
-		shares[id] = round.CommitmentsSum.Evaluate(id.Scalar())
+		if _, ok := round.shares[id]; ok && !round.verifyShare(id) {
+			culprits = append(culprits, id)
+		}
+	}
+	return culprits
+}
+
+// verifyShare returns true if [sⱼ] B = Fⱼ(i), where sⱼ is the share received from party j and i is our ID.
+func (round *round2) verifyShare(id party.ID) bool {
+	var computedShareExp ristretto.Element
+	computedShareExp.ScalarBaseMult(round.shares[id])
+	shareExp := round.Commitments[id].Evaluate(round.SelfID().Scalar())
+	return computedShareExp.Equal(shareExp) == 1
+}
+
+func (round *round2) GenerateMessages() ([]*messages.Message, *state.Error) {
+	if culprits := round.verifyShares(); len(culprits) > 0 {
+		return nil, state.NewAbortError(errors.New("VSS failed to validate"), culprits...)
 	}
-	round.Output.Public = &eddsa.Public{
-		PartyIDs:  round.BaseRound.PartyIDs().Copy(),
-		Threshold: round.Threshold,
-		Shares:    shares,
-		GroupKey:  eddsa.NewPublicKeyFromPoint(round.CommitmentsSum.Constant()),
+	for _, share := range round.shares {
+		round.Secret.Add(&round.Secret, share)
 	}
-	round.Output.SecretKey = eddsa.NewSecretShare(round.SelfID(), &round.Secret)
-	return nil, nil
+
+	// The other parties check that they obtained the same commitments
+	return []*messages.Message{messages.NewKeyGen3(round.SelfID(), round.groupDigest())}, nil
 }
 
 func (round *round2) NextRound() state.Round {
-	return nil
+	return &round3{round}
 }
 
 func (round *round2) MessageType() messages.MessageType {
@@ -0,0 +1,108 @@
+package keygen
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/helpers"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/polynomial"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/scalar"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+// newRound2 returns the second round of party n in a keygen with n parties,
+// in which the commitments and shares of all other parties were received.
+func newRound2(t testing.TB, n party.Size) *round2 {
+	partyIDs := helpers.GenerateSet(n)
+	r, _, err := NewRound(party.ID(n), partyIDs, n/2)
+	require.NoError(t, err)
+	round := &round2{&round1{r.(*round0)}}
+	for _, id := range partyIDs[:n-1] {
+		f := polynomial.NewPolynomial(round.Threshold, scalar.NewScalarRandom())
+		round.Commitments[id] = polynomial.NewPolynomialExponent(f)
+		round.shares[id] = f.Evaluate(round.SelfID().Scalar())
+	}
+	return round
+}
+
+func TestRound2_verifyShares(t *testing.T) {
+	round := newRound2(t, 10)
+	assert.Empty(t, round.verifyShares())
+
+	one := ristretto.NewScalar().SetUint64(1)
+	round.shares[4].Add(round.shares[4], one)
+	assert.Equal(t, []party.ID{4}, round.verifyShares())
+
+	round.shares[7].Add(round.shares[7], one)
+	assert.Equal(t, []party.ID{4, 7}, round.verifyShares())
+}
+
+// verifySharesBatched returns true if all shares received by round are valid, using a random linear combination
+// of the checks with 128 bit coefficients rⱼ read from random:
+//
+//	[∑ⱼ rⱼ • sⱼ] B = ∑ⱼ ∑ₖ [rⱼ • iᵏ] Cⱼ,ₖ
+//
+// It is only used by BenchmarkVerifyShares, to compare it with the individual checks of round2.verifyShares.
+func verifySharesBatched(round *round2, random io.Reader) (bool, error) {
+	degree := int(round.Threshold)
+	powers := make([]ristretto.Scalar, degree+1)
+	for k := range powers {
+		if k == 0 {
+			powers[0].SetUint64(1)
+		} else {
+			powers[k].Multiply(&powers[k-1], round.SelfID().Scalar())
+		}
+	}
+
+	var sum, r ristretto.Scalar
+	var buf [32]byte
+	scalars := make([]ristretto.Scalar, 0, len(round.shares)*(degree+1))
+	scalarsPointers := make([]*ristretto.Scalar, 0, len(round.shares)*(degree+1))
+	points := make([]*ristretto.Element, 0, len(round.shares)*(degree+1))
+	for _, id := range round.PartyIDs() {
+		share, ok := round.shares[id]
+		if !ok {
+			continue
+		}
+		if _, err := io.ReadFull(random, buf[:16]); err != nil {
+			return false, err
+		}
+		_, _ = r.SetCanonicalBytes(buf[:])
+		sum.MultiplyAdd(&r, share, &sum)
+
+		for k, c := range round.Commitments[id].Coefficients() {
+			scalars = append(scalars, ristretto.Scalar{})
+			scalarsPointers = append(scalarsPointers, scalars[len(scalars)-1].Multiply(&r, &powers[k]))
+			points = append(points, c)
+		}
+	}
+
+	var expected, computed ristretto.Element
+	expected.ScalarBaseMult(&sum)
+	_, _ = computed.VarTimeMultiScalarMult(scalarsPointers, points)
+	return computed.Equal(&expected) == 1, nil
+}
+
+func BenchmarkVerifyShares(b *testing.B) {
+	for _, n := range []party.Size{10, 50} {
+		round := newRound2(b, n)
+		b.Run(fmt.Sprintf("individual/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if len(round.verifyShares()) > 0 {
+					b.Fatal("invalid share")
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("batched/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if ok, err := verifySharesBatched(round, round.random); err != nil || !ok {
+					b.Fatal("invalid share")
+				}
+			}
+		})
+	}
+}
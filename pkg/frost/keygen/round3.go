@@ -0,0 +1,70 @@
+package keygen
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"errors"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+// groupDigestDomainSeparation is used to derive the digest compared in the last round.
+var groupDigestDomainSeparation = []byte("FROST-KEYGEN-GROUP")
+
+// groupDigest returns a hash of the sum of all commitments, which determines the group key and the public shares:
+//
+//	SHA-512/256("FROST-KEYGEN-GROUP" ∥ Threshold ∥ ID₁ ∥ … ∥ IDₙ ∥ CommitmentsSum)
+//
+// where the IDs of all parties are in increasing order. These are the parameters of the session which are common to all parties.
+func (round *round0) groupDigest() []byte {
+	h := sha512.New512_256()
+	_, _ = h.Write(groupDigestDomainSeparation)
+	_, _ = h.Write(round.Threshold.Bytes())
+	for _, id := range round.PartyIDs() {
+		_, _ = h.Write(id.Bytes())
+	}
+	data, _ := round.CommitmentsSum.MarshalBinary()
+	_, _ = h.Write(data)
+	return h.Sum(nil)
+}
+
+// ProcessMessage checks that the sender obtained the same commitments as us.
+//
+// A party which sent different commitments to different parties in the first round, or a bug, would otherwise leave the parties
+// with different group keys, and the failure would only appear later, when the signatures do not verify.
+// This check makes the key generation fail instead.
+// The error does not designate a culprit, since the sender may be honest and the faulty party may be any of the others.
+func (round *round3) ProcessMessage(msg *messages.Message) *state.Error {
+	if !bytes.Equal(msg.KeyGen3.Digest[:], round.groupDigest()) {
+		return state.NewAbortError(errors.New("group key is inconsistent"))
+	}
+	return nil
+}
+
+func (round *round3) GenerateMessages() ([]*messages.Message, *state.Error) {
+	shares := make(map[party.ID]*ristretto.Element, round.PartyIDs().N())
+	for _, id := range round.PartyIDs() {
+		shares[id] = round.CommitmentsSum.Evaluate(id.Scalar())
+	}
+	round.Output.Public = &eddsa.Public{
+		PartyIDs:  round.BaseRound.PartyIDs().Copy(),
+		Threshold: round.Threshold,
+		Shares:    shares,
+		GroupKey:  eddsa.NewPublicKeyFromPoint(round.CommitmentsSum.Constant()),
+	}
+	round.Output.SecretKey = eddsa.NewSecretShare(round.SelfID(), &round.Secret)
+	round.Output.Commitments = round.CommitmentsSum.Coefficients()
+	return nil, nil
+}
+
+func (round *round3) NextRound() state.Round {
+	return nil
+}
+
+func (round *round3) MessageType() messages.MessageType {
+	return messages.MessageTypeKeyGen3
+}
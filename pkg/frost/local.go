@@ -0,0 +1,121 @@
+package frost
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/keygen"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/sign"
+	"github.com/taurusgroup/frost-ed25519/pkg/helpers"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+// KeygenAll runs a key generation among all parties of partyIDs in the current process,
+// and returns the SecretShare of every party along with the resulting eddsa.Public.
+// It is meant for tests and simulations, since all shares are known to the caller.
+//
+// The messages are exchanged in their serialized form, as they would be over a network.
+func KeygenAll(threshold party.Size, partyIDs []party.ID) (map[party.ID]*eddsa.SecretShare, *eddsa.Public, error) {
+	if err := party.ValidateIDs(partyIDs); err != nil {
+		return nil, nil, fmt.Errorf("frost.KeygenAll: %w", err)
+	}
+	if len(partyIDs) == 0 {
+		return nil, nil, errors.New("frost.KeygenAll: partyIDs is empty")
+	}
+	set := party.NewIDSlice(partyIDs)
+	states := make(map[party.ID]*state.State, len(set))
+	outputs := make(map[party.ID]*keygen.Output, len(set))
+	for _, id := range set {
+		var err error
+		states[id], outputs[id], err = NewKeygenState(id, set, threshold, 0)
+		if err != nil {
+			return nil, nil, fmt.Errorf("frost.KeygenAll: %w", err)
+		}
+	}
+
+	if err := runAll(set, states); err != nil {
+		return nil, nil, fmt.Errorf("frost.KeygenAll: %w", err)
+	}
+
+	public := outputs[set[0]].Public
+	shares := make(map[party.ID]*eddsa.SecretShare, len(set))
+	for _, id := range set {
+		if !outputs[id].Public.Equal(public) {
+			return nil, nil, fmt.Errorf("frost.KeygenAll: party %d obtained a different public key", id)
+		}
+		shares[id] = outputs[id].SecretKey
+	}
+	return shares, public, nil
+}
+
+// SignAll runs a signing session among all parties of quorum in the current process, and returns the signature of message.
+// It is meant for tests and simulations, where all the shares are known, since it defeats the purpose of threshold signing otherwise.
+//
+// The messages are exchanged in their serialized form, as they would be over a network.
+func SignAll(shares map[party.ID]*eddsa.SecretShare, public *eddsa.Public, quorum []party.ID, message []byte) (*eddsa.Signature, error) {
+	partyIDs := party.NewIDSlice(quorum)
+	if len(partyIDs) == 0 {
+		return nil, errors.New("frost.SignAll: quorum is empty")
+	}
+	states := make(map[party.ID]*state.State, len(partyIDs))
+	outputs := make(map[party.ID]*sign.Output, len(partyIDs))
+	for _, id := range partyIDs {
+		share, ok := shares[id]
+		if !ok {
+			return nil, fmt.Errorf("frost.SignAll: no share given for party %d", id)
+		}
+		var err error
+		states[id], outputs[id], err = NewSignState(partyIDs, share, public, message, 0)
+		if err != nil {
+			return nil, fmt.Errorf("frost.SignAll: %w", err)
+		}
+	}
+
+	if err := runAll(partyIDs, states); err != nil {
+		return nil, fmt.Errorf("frost.SignAll: %w", err)
+	}
+	return outputs[partyIDs[0]].Signature, nil
+}
+
+// runAll executes the protocol of all states, by giving each of them the messages produced by all states in the previous round,
+// until all of them have finished.
+func runAll(partyIDs party.IDSlice, states map[party.ID]*state.State) error {
+	var msgs [][]byte
+	for {
+		var next [][]byte
+		for _, id := range partyIDs {
+			s := states[id]
+			if s.IsFinished() {
+				continue
+			}
+			out, err := helpers.PartyRoutine(msgs, s)
+			if err != nil {
+				return err
+			}
+			next = append(next, out...)
+		}
+
+		finished := true
+		for _, id := range partyIDs {
+			if !states[id].IsFinished() {
+				finished = false
+			}
+		}
+		if finished {
+			break
+		}
+		if len(next) == 0 {
+			return errors.New("protocol did not finish")
+		}
+		msgs = next
+	}
+
+	for _, id := range partyIDs {
+		if err := states[id].WaitForError(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
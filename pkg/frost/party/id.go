@@ -12,39 +12,30 @@ import (
 )
 
 // IDByteSize is the number of bytes required to store and ID or Size
-const IDByteSize = 2
+const IDByteSize = 4
 
 // _MAX is the maximum integer that can represent a party.
 // It can be used to bound the number of parties, and the maximum integer value
 // an ID can be.
 const _MAX = uint64(math.MaxUint32)
 
-// ID represents the identifier of a particular party, encoded as a 16 bit unsigned integer.
+// ID represents the identifier of a particular party, encoded as a 32 bit unsigned integer.
 // The ID 0 is considered invalid.
-type ID uint16
+type ID uint32
 
 // Size is an alias for ID that allows us to differentiate between a party's ID and the threshold for example.
 type Size = ID
 
 // Scalar returns the corresponding ristretto.Scalar
 func (id ID) Scalar() *ristretto.Scalar {
-	var s ristretto.Scalar
-	bytes := make([]byte, 32)
-
-	binary.LittleEndian.PutUint16(bytes, uint16(id))
-
-	_, err := s.SetCanonicalBytes(bytes[:])
-	if err != nil {
-		panic(fmt.Errorf("edwards25519: failed to set uint32 Scalar: %w", err))
-	}
-	return &s
+	return ristretto.NewScalar().SetUint64(uint64(id))
 }
 
 // Bytes returns a []byte slice of length party.IDByteSize
 func (id ID) Bytes() []byte {
 	bytes := make([]byte, IDByteSize)
 
-	binary.BigEndian.PutUint16(bytes, uint16(id))
+	binary.BigEndian.PutUint32(bytes, uint32(id))
 	return bytes
 }
 
@@ -59,14 +50,14 @@ func FromBytes(b []byte) (ID, error) {
 	if len(b) < IDByteSize {
 		return 0, errors.New("party.FromBytes: b is not long enough to hold an ID")
 	}
-	id := ID(binary.BigEndian.Uint16(b))
+	id := ID(binary.BigEndian.Uint32(b))
 	return id, nil
 }
 
 // RandID returns a pseudo-random value as a ID
 // from the default Source.
 func RandID() ID {
-	id := rand.Int31n(math.MaxUint16 + 1)
+	id := rand.Int63n(math.MaxUint32 + 1)
 	if id == 0 {
 		return ID(id + 1)
 	}
@@ -79,19 +70,34 @@ func (id ID) MarshalText() (text []byte, err error) {
 }
 
 // UnmarshalText implements encoding/TextMarshaler interface
-// Returns an error when the encoded text is too large
+// Returns an error when the encoded text is too large, or represents the 0 ID.
 func (id *ID) UnmarshalText(text []byte) error {
-	idUint, err := strconv.ParseUint(string(text), 10, 16)
+	idParsed, err := IDFromString(string(text))
 	if err != nil {
-		return fmt.Errorf("party.ID: UnmarshalText: %v", err)
-	}
-	if idUint > _MAX {
-		return errors.New("party.ID: UnmarshalText: party ID overflows")
+		return fmt.Errorf("party.ID: UnmarshalText: %w", err)
 	}
-	*id = ID(idUint)
+	*id = idParsed
 	return nil
 }
 
+// IDFromString parses the base 10 representation of an ID, as returned by ID.String.
+// Returns an error if s does not represent an integer in the range of ID, or if it represents the 0 ID,
+// which is reserved for the evaluation of the secret.
+func IDFromString(s string) (ID, error) {
+	idUint, err := strconv.ParseUint(s, 10, 8*IDByteSize)
+	if err != nil {
+		var numErr *strconv.NumError
+		if errors.As(err, &numErr) && numErr.Err == strconv.ErrRange {
+			return 0, fmt.Errorf("party.IDFromString: %q overflows an ID", s)
+		}
+		return 0, fmt.Errorf("party.IDFromString: %q is not a decimal integer", s)
+	}
+	if idUint == 0 {
+		return 0, errors.New("party.IDFromString: id was 0 (invalid)")
+	}
+	return ID(idUint), nil
+}
+
 // Lagrange gives the Lagrange coefficient lⱼ(x) for x = 0.
 //
 // We iterate over all points in the set.
@@ -114,13 +120,10 @@ func (id ID) Lagrange(partyIDs IDSlice) (*ristretto.Scalar, error) {
 	if id == 0 {
 		return nil, errors.New("party.ID: Lagrange: id was 0 (invalid)")
 	}
-	var one, num, denum, xM, xJ ristretto.Scalar
-
-	// we can't use scalar.NewScalarUInt32() since that would cause an import cycle
-	_, _ = one.SetCanonicalBytes([]byte{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0})
+	var num, denum, xM, xJ ristretto.Scalar
 
-	num.Set(&one)
-	denum.Set(&one)
+	num.SetUint64(1)
+	denum.SetUint64(1)
 
 	xJ = *id.Scalar()
 
@@ -144,7 +147,7 @@ func (id ID) Lagrange(partyIDs IDSlice) (*ristretto.Scalar, error) {
 		return nil, errors.New("party.ID: Lagrange: partyIDs does not containd id")
 	}
 	// check against 0
-	if denum.Equal(ristretto.NewScalar()) == 1 {
+	if denum.IsZero() == 1 {
 		return nil, errors.New("party.ID: Lagrange: denominator was 0")
 	}
 
@@ -152,3 +155,78 @@ func (id ID) Lagrange(partyIDs IDSlice) (*ristretto.Scalar, error) {
 	num.Multiply(&num, &denum)
 	return &num, nil
 }
+
+// LagrangeCoefficient returns the Lagrange coefficient lⱼ(0) of self, for the set of
+// evaluation points given by quorum. It is equivalent to id.Lagrange, but additionally
+// validates the quorum.
+//
+// Returns an error if self is not included in quorum, or if quorum contains the 0 ID or duplicates.
+// When the coefficients of all parties in the quorum are needed, LagrangeCoefficients should be preferred.
+func LagrangeCoefficient(self ID, quorum []ID) (*ristretto.Scalar, error) {
+	coefficients, err := LagrangeCoefficients(quorum)
+	if err != nil {
+		return nil, err
+	}
+	lagrange, ok := coefficients[self]
+	if !ok {
+		return nil, fmt.Errorf("party.LagrangeCoefficient: quorum does not contain id %d", self)
+	}
+	return lagrange, nil
+}
+
+// LagrangeCoefficients returns a map from each ID in quorum to its Lagrange coefficient lⱼ(0).
+//
+// Since
+//
+//	                   x₀ ... xₖ
+//	lⱼ(0) = ------------------------------
+//	        xⱼ • (x₀ - xⱼ) ... (xₖ - xⱼ)
+//
+// (where the product in the denominator skips xⱼ), we can compute all coefficients
+// with a single call to ristretto.BatchInvert.
+//
+// Returns an error if quorum is empty, or contains the 0 ID or duplicates.
+func LagrangeCoefficients(quorum []ID) (map[ID]*ristretto.Scalar, error) {
+	if len(quorum) == 0 {
+		return nil, errors.New("party.LagrangeCoefficients: quorum is empty")
+	}
+	if err := ValidateIDs(quorum); err != nil {
+		return nil, fmt.Errorf("party.LagrangeCoefficients: %w", err)
+	}
+	sorted := NewIDSlice(quorum)
+
+	n := len(sorted)
+	xs := make([]*ristretto.Scalar, n)
+	for i, id := range sorted {
+		xs[i] = id.Scalar()
+	}
+
+	// num = x₀ * ... * xₖ
+	num := ristretto.NewScalar().SetUint64(1)
+	for _, x := range xs {
+		num.Multiply(num, x)
+	}
+
+	// denums[j] = xⱼ • (x₀ - xⱼ) ... (xₖ - xⱼ)
+	var diff ristretto.Scalar
+	denums := make([]*ristretto.Scalar, n)
+	for j, xJ := range xs {
+		denums[j] = new(ristretto.Scalar).Set(xJ)
+		for m, xM := range xs {
+			if m == j {
+				continue
+			}
+			diff.Subtract(xM, xJ)
+			denums[j].Multiply(denums[j], &diff)
+		}
+	}
+
+	// Since all IDs are distinct and non-zero, none of the denominators are 0.
+	invDenums := ristretto.BatchInvert(denums)
+
+	coefficients := make(map[ID]*ristretto.Scalar, n)
+	for j, id := range sorted {
+		coefficients[id] = invDenums[j].Multiply(invDenums[j], num)
+	}
+	return coefficients, nil
+}
@@ -2,6 +2,7 @@ package party
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/taurusgroup/frost-ed25519/pkg/internal/scalar"
@@ -20,31 +21,31 @@ func TestFromBytes(t *testing.T) {
 	}{
 		{
 			"1",
-			args{b: []byte{0, 1}},
+			args{b: []byte{0, 0, 0, 1}},
 			1,
 			false,
 		},
 		{
 			"max",
-			args{b: []byte{255, 255}},
-			65535,
+			args{b: []byte{255, 255, 255, 255}},
+			4294967295,
 			false,
 		},
 		{
 			"larger size",
-			args{b: []byte{0, 1, 0}},
+			args{b: []byte{0, 0, 0, 1, 0}},
 			1,
 			false,
 		},
 		{
 			"0",
-			args{b: []byte{0, 0, 1}},
+			args{b: []byte{0, 0, 0, 0, 1}},
 			0,
 			false,
 		},
 		{
-			"1 byte long",
-			args{b: []byte{1}},
+			"3 bytes long",
+			args{b: []byte{0, 0, 1}},
 			0,
 			true,
 		},
@@ -117,18 +118,18 @@ func TestID_UnmarshalText(t *testing.T) {
 			"0",
 			0,
 			args{text: []byte("0")},
-			false,
+			true,
 		},
 		{
 			"max",
-			65535,
-			args{text: []byte("65535")},
+			4294967295,
+			args{text: []byte("4294967295")},
 			false,
 		},
 		{
 			"max+1",
 			0,
-			args{text: []byte("65536")},
+			args{text: []byte("4294967296")},
 			true,
 		},
 		{
@@ -153,6 +154,44 @@ func TestID_UnmarshalText(t *testing.T) {
 	}
 }
 
+func TestIDFromString(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    ID
+		wantErr bool
+	}{
+		{"1", "1", 1, false},
+		{"normal", "42", 42, false},
+		{"max", "4294967295", 4294967295, false},
+		{"above 16 bits", "65536", 65536, false},
+		{"0", "0", 0, true},
+		{"leading zeros", "007", 7, false},
+		{"max+1", "4294967296", 0, true},
+		{"overflow", "18446744073709551616", 0, true},
+		{"negative", "-1", 0, true},
+		{"sign", "+1", 0, true},
+		{"empty", "", 0, true},
+		{"space", " 1", 0, true},
+		{"gibberish", "dfhg", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := IDFromString(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IDFromString() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IDFromString() got = %v, want %v", got, tt.want)
+			}
+			if !tt.wantErr && got.String() != strings.TrimLeft(tt.s, "0") {
+				t.Errorf("String() got = %v, want %v", got.String(), tt.s)
+			}
+		})
+	}
+}
+
 func TestID_Lagrange(t *testing.T) {
 	N := 16
 
@@ -213,3 +252,96 @@ func TestID_Lagrange(t *testing.T) {
 		})
 	}
 }
+
+func TestLagrangeCoefficient(t *testing.T) {
+	// inverse returns 1/x
+	inverse := func(x uint64) *ristretto.Scalar {
+		return ristretto.NewScalar().Invert(ristretto.NewScalar().SetUint64(x))
+	}
+	// fraction returns num/denum, where num may be negative
+	fraction := func(num int64, denum uint64) *ristretto.Scalar {
+		var n ristretto.Scalar
+		if num < 0 {
+			n.Negate(ristretto.NewScalar().SetUint64(uint64(-num)))
+		} else {
+			n.SetUint64(uint64(num))
+		}
+		return n.Multiply(&n, inverse(denum))
+	}
+
+	tests := []struct {
+		name   string
+		quorum []ID
+		want   map[ID]*ristretto.Scalar
+	}{
+		{
+			// l₁ = 2/(2-1), l₂ = 1/(1-2)
+			"2-of-3 {1,2}",
+			[]ID{1, 2},
+			map[ID]*ristretto.Scalar{1: fraction(2, 1), 2: fraction(-1, 1)},
+		},
+		{
+			// l₁ = 3/(3-1), l₃ = 1/(1-3)
+			"2-of-3 {1,3}",
+			[]ID{3, 1},
+			map[ID]*ristretto.Scalar{1: fraction(3, 2), 3: fraction(-1, 2)},
+		},
+		{
+			// l₁ = 2•3/(2-1)(3-1), l₂ = 1•3/(1-2)(3-2), l₃ = 1•2/(1-3)(2-3)
+			"3-of-5 {1,2,3}",
+			[]ID{1, 2, 3},
+			map[ID]*ristretto.Scalar{1: fraction(3, 1), 2: fraction(-3, 1), 3: fraction(1, 1)},
+		},
+		{
+			// l₂ = 4•5/(4-2)(5-2), l₄ = 2•5/(2-4)(5-4), l₅ = 2•4/(2-5)(4-5)
+			"3-of-5 {2,4,5}",
+			[]ID{2, 4, 5},
+			map[ID]*ristretto.Scalar{2: fraction(10, 3), 4: fraction(-5, 1), 5: fraction(8, 3)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for id, want := range tt.want {
+				got, err := LagrangeCoefficient(id, tt.quorum)
+				if err != nil {
+					t.Fatalf("LagrangeCoefficient() unexpected error: %v", err)
+				}
+				if got.Equal(want) != 1 {
+					t.Errorf("LagrangeCoefficient(%d) = %v, want %v", id, got, want)
+				}
+				// Must agree with ID.Lagrange
+				expected, _ := id.Lagrange(NewIDSlice(tt.quorum))
+				if got.Equal(expected) != 1 {
+					t.Errorf("LagrangeCoefficient(%d) differs from ID.Lagrange", id)
+				}
+			}
+		})
+	}
+
+	errorTests := []struct {
+		name   string
+		self   ID
+		quorum []ID
+	}{
+		{"not in quorum", 4, []ID{1, 2, 3}},
+		{"duplicate", 1, []ID{1, 2, 2}},
+		{"zero ID", 1, []ID{0, 1, 2}},
+		{"zero self", 0, []ID{1, 2}},
+		{"empty", 1, nil},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LagrangeCoefficient(tt.self, tt.quorum); err == nil {
+				t.Error("LagrangeCoefficient() should fail")
+			}
+		})
+	}
+
+	// The batched computation names the offending ID
+	if _, err := LagrangeCoefficients([]ID{1, 2, 2}); err == nil || !strings.Contains(err.Error(), "ID 2 appears more than once") {
+		t.Errorf("LagrangeCoefficients() error = %v, want a duplicate error", err)
+	}
+	if _, err := LagrangeCoefficients([]ID{0, 1, 2}); err == nil || !strings.Contains(err.Error(), "contain 0") {
+		t.Errorf("LagrangeCoefficients() error = %v, want a zero ID error", err)
+	}
+}
@@ -1,17 +1,45 @@
 package party
 
 import (
+	"errors"
+	"fmt"
 	"sort"
 )
 
-// IDSlice is an alias for []ID
+// IDSlice is an alias for []ID, representing a set of IDs.
+// The IDs are sorted in increasing order and unique, which is guaranteed when it was created with NewIDSlice.
+// The methods of IDSlice assume this is the case, and their results satisfy it as well.
 type IDSlice []ID
 
-// NewIDSlice returns an IDSlice which is the partyIDs sorted
+// NewIDSlice returns an IDSlice which is the partyIDs sorted, with duplicates removed.
+// partyIDs is not modified.
 func NewIDSlice(partyIDs []ID) IDSlice {
 	ids := IDSlice(partyIDs).Copy()
 	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
-	return ids
+	unique := ids[:0]
+	for i, id := range ids {
+		if i == 0 || ids[i-1] != id {
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// ValidateIDs returns an error if ids cannot be used as the evaluation points of a Shamir sharing,
+// either because it contains the ID 0, at which the secret itself is evaluated, or because an ID appears more than once.
+// The error names the offending ID. ids does not need to be sorted.
+func ValidateIDs(ids []ID) error {
+	seen := make(map[ID]bool, len(ids))
+	for _, id := range ids {
+		if id == 0 {
+			return errors.New("IDs contain 0, which is not a valid ID")
+		}
+		if seen[id] {
+			return fmt.Errorf("IDs contain duplicates: ID %d appears more than once", id)
+		}
+		seen[id] = true
+	}
+	return nil
 }
 
 // Contains returns true if id is included in the slice.
@@ -59,3 +87,47 @@ func (ids IDSlice) Copy() IDSlice {
 	copy(newIds, ids)
 	return newIds
 }
+
+// Union returns a new IDSlice containing the IDs in either ids or o.
+func (ids IDSlice) Union(o IDSlice) IDSlice {
+	union := make(IDSlice, 0, len(ids)+len(o))
+	i, j := 0, 0
+	for i < len(ids) && j < len(o) {
+		switch {
+		case ids[i] < o[j]:
+			union = append(union, ids[i])
+			i++
+		case ids[i] > o[j]:
+			union = append(union, o[j])
+			j++
+		default:
+			union = append(union, ids[i])
+			i++
+			j++
+		}
+	}
+	union = append(union, ids[i:]...)
+	return append(union, o[j:]...)
+}
+
+// Intersect returns a new IDSlice containing the IDs in both ids and o.
+func (ids IDSlice) Intersect(o IDSlice) IDSlice {
+	intersection := make(IDSlice, 0, len(ids))
+	for _, id := range ids {
+		if o.Contains(id) {
+			intersection = append(intersection, id)
+		}
+	}
+	return intersection
+}
+
+// Difference returns a new IDSlice containing the IDs in ids which are not in o.
+func (ids IDSlice) Difference(o IDSlice) IDSlice {
+	difference := make(IDSlice, 0, len(ids))
+	for _, id := range ids {
+		if !o.Contains(id) {
+			difference = append(difference, id)
+		}
+	}
+	return difference
+}
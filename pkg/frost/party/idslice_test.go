@@ -0,0 +1,120 @@
+package party
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewIDSlice(t *testing.T) {
+	tests := []struct {
+		name     string
+		partyIDs []ID
+		want     IDSlice
+	}{
+		{"nil", nil, IDSlice{}},
+		{"empty", []ID{}, IDSlice{}},
+		{"single", []ID{4}, IDSlice{4}},
+		{"sorted", []ID{1, 2, 3}, IDSlice{1, 2, 3}},
+		{"unsorted", []ID{3, 42, 1, 8}, IDSlice{1, 3, 8, 42}},
+		{"duplicates", []ID{3, 1, 3, 2, 1, 3}, IDSlice{1, 2, 3}},
+		{"all equal", []ID{7, 7, 7}, IDSlice{7}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := append([]ID{}, tt.partyIDs...)
+			got := NewIDSlice(tt.partyIDs)
+			if !got.Equal(tt.want) {
+				t.Errorf("NewIDSlice() = %v, want %v", got, tt.want)
+			}
+			if !IDSlice(input).Equal(tt.partyIDs) {
+				t.Errorf("NewIDSlice() modified its input")
+			}
+			for _, id := range tt.partyIDs {
+				if !got.Contains(id) {
+					t.Errorf("NewIDSlice() does not contain %d", id)
+				}
+			}
+		})
+	}
+}
+
+func TestIDSlice_SetOperations(t *testing.T) {
+	tests := []struct {
+		name                            string
+		a, b                            IDSlice
+		union, intersection, difference IDSlice
+	}{
+		{"both empty", IDSlice{}, IDSlice{}, IDSlice{}, IDSlice{}, IDSlice{}},
+		{"nil", nil, nil, IDSlice{}, IDSlice{}, IDSlice{}},
+		{"empty left", IDSlice{}, IDSlice{1, 2}, IDSlice{1, 2}, IDSlice{}, IDSlice{}},
+		{"empty right", IDSlice{1, 2}, IDSlice{}, IDSlice{1, 2}, IDSlice{}, IDSlice{1, 2}},
+		{"equal", IDSlice{1, 2, 3}, IDSlice{1, 2, 3}, IDSlice{1, 2, 3}, IDSlice{1, 2, 3}, IDSlice{}},
+		{"disjoint", IDSlice{1, 3}, IDSlice{2, 4}, IDSlice{1, 2, 3, 4}, IDSlice{}, IDSlice{1, 3}},
+		{"subset", IDSlice{2, 4}, IDSlice{1, 2, 3, 4, 5}, IDSlice{1, 2, 3, 4, 5}, IDSlice{2, 4}, IDSlice{}},
+		{"superset", IDSlice{1, 2, 3, 4, 5}, IDSlice{2, 4}, IDSlice{1, 2, 3, 4, 5}, IDSlice{2, 4}, IDSlice{1, 3, 5}},
+		{"overlap", IDSlice{1, 2, 5, 8}, IDSlice{2, 3, 8, 9}, IDSlice{1, 2, 3, 5, 8, 9}, IDSlice{2, 8}, IDSlice{1, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Union(tt.b); !got.Equal(tt.union) {
+				t.Errorf("Union() = %v, want %v", got, tt.union)
+			}
+			if got := tt.b.Union(tt.a); !got.Equal(tt.union) {
+				t.Errorf("Union() is not commutative: %v, want %v", got, tt.union)
+			}
+			if got := tt.a.Intersect(tt.b); !got.Equal(tt.intersection) {
+				t.Errorf("Intersect() = %v, want %v", got, tt.intersection)
+			}
+			if got := tt.b.Intersect(tt.a); !got.Equal(tt.intersection) {
+				t.Errorf("Intersect() is not commutative: %v, want %v", got, tt.intersection)
+			}
+			if got := tt.a.Difference(tt.b); !got.Equal(tt.difference) {
+				t.Errorf("Difference() = %v, want %v", got, tt.difference)
+			}
+			if got := tt.a.Intersect(tt.b).Equal(tt.a); got != tt.a.IsSubsetOf(tt.b) {
+				t.Errorf("Intersect() = a should hold iff a IsSubsetOf b")
+			}
+		})
+	}
+}
+
+func TestIDSlice_SetOperationsDoNotAlias(t *testing.T) {
+	a, b := IDSlice{1, 2, 3}, IDSlice{3, 4}
+	union := a.Union(b)
+	intersection := a.Intersect(b)
+	difference := a.Difference(b)
+	union[0], intersection[0], difference[0] = 10, 10, 10
+	if !a.Equal(IDSlice{1, 2, 3}) || !b.Equal(IDSlice{3, 4}) {
+		t.Errorf("set operations modified their inputs: %v, %v", a, b)
+	}
+}
+
+func TestValidateIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		ids     []ID
+		wantErr string
+	}{
+		{"nil", nil, ""},
+		{"single", []ID{4}, ""},
+		{"unsorted", []ID{3, 42, 1, 8}, ""},
+		{"zero", []ID{1, 0, 2}, "contain 0"},
+		{"only zero", []ID{0}, "contain 0"},
+		{"duplicate", []ID{1, 2, 3, 2}, "ID 2 appears more than once"},
+		{"duplicate and zero", []ID{5, 5, 0}, "ID 5 appears more than once"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateIDs(tt.ids)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateIDs() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateIDs() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
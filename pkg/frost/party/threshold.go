@@ -0,0 +1,16 @@
+package party
+
+import "fmt"
+
+// ValidateThreshold returns an error if threshold cannot be used by n parties.
+// A threshold t means that t+1 parties are required to sign, so it must satisfy 1 ≤ t ≤ n-1.
+// The degenerate 1-of-n configuration, t = 0, is not allowed since any party could then sign alone.
+func ValidateThreshold(threshold, n Size) error {
+	if threshold == 0 {
+		return fmt.Errorf("threshold %d is invalid for %d parties: it must be at least 1, or a minimum of T+1=2 signers", threshold, n)
+	}
+	if threshold >= n {
+		return fmt.Errorf("threshold %d is invalid for %d parties: it must be at most N-1, or a maximum of T+1=N signers", threshold, n)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package party
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateThreshold(t *testing.T) {
+	for _, tt := range []struct {
+		threshold, n Size
+		valid        bool
+	}{
+		{0, 3, false},
+		{1, 3, true},
+		{2, 3, true},
+		{3, 3, false},
+		{4, 3, false},
+		{1, 2, true},
+		{1, 1, false},
+		{1, 0, false},
+		{math.MaxUint32 - 1, math.MaxUint32, true},
+	} {
+		err := ValidateThreshold(tt.threshold, tt.n)
+		if tt.valid {
+			assert.NoError(t, err, "threshold %d and n %d", tt.threshold, tt.n)
+		} else if assert.Error(t, err, "threshold %d and n %d", tt.threshold, tt.n) {
+			assert.Contains(t, err.Error(), "threshold "+tt.threshold.String())
+			assert.Contains(t, err.Error(), tt.n.String()+" parties")
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package refresh
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/polynomial"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+type (
+	round0 struct {
+		*state.BaseRound
+
+		// Threshold is the degree of the polynomial used for Shamir.
+		// It is the number of tolerated party corruptions, and does not change during the refresh.
+		Threshold party.Size
+
+		// Secret is first set to the party's current share.
+		// All received updates are added to it, and the result is the party's refreshed share.
+		Secret ristretto.Scalar
+
+		// Public is the set of public shares before the refresh.
+		Public *eddsa.Public
+
+		// Polynomial is a random polynomial with constant coefficient 0,
+		// used to generate the updates we send to the other parties.
+		Polynomial *polynomial.Polynomial
+
+		// CommitmentsSum is the sum of all commitments, we use it to update the public key shares
+		CommitmentsSum *polynomial.Exponent
+
+		// Commitments contains all other parties commitment polynomials
+		Commitments map[party.ID]*polynomial.Exponent
+
+		Output *Output
+	}
+	round1 struct {
+		*round0
+	}
+	round2 struct {
+		*round1
+	}
+)
+
+// NewRound returns the first round of the refresh protocol for the owner of secret.
+// All parties of public must take part, and they all obtain new shares of the same group key.
+func NewRound(secret *eddsa.SecretShare, public *eddsa.Public) (state.Round, *Output, error) {
+	publicShare, ok := public.Shares[secret.ID]
+	if !ok {
+		return nil, nil, errors.New("refresh.NewRound: owner of SecretShare is not contained in public")
+	}
+	if publicShare.Equal(&secret.Public) != 1 {
+		return nil, nil, errors.New("refresh.NewRound: SecretShare does not match its public share")
+	}
+
+	baseRound, err := state.NewBaseRound(secret.ID, public.PartyIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("refresh.NewRound: %w", err)
+	}
+
+	r := round0{
+		BaseRound:   baseRound,
+		Threshold:   public.Threshold,
+		Public:      public,
+		Commitments: make(map[party.ID]*polynomial.Exponent, public.PartyIDs.N()),
+		Output:      &Output{},
+	}
+	r.Secret.Set(&secret.Secret)
+
+	return &r, r.Output, nil
+}
+
+func (round *round0) Reset() {
+	round.Secret.Set(ristretto.NewScalar())
+	round.Polynomial.Reset()
+	round.CommitmentsSum.Reset()
+	for _, p := range round.Commitments {
+		p.Reset()
+	}
+	round.Output = nil
+}
+
+// ---
+// Messages
+// ---
+
+func (round *round0) AcceptedMessageTypes() []messages.MessageType {
+	return []messages.MessageType{messages.MessageTypeNone, messages.MessageTypeRefresh1, messages.MessageTypeRefresh2}
+}
@@ -0,0 +1,32 @@
+package refresh
+
+import (
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/polynomial"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+func (round *round0) ProcessMessage(*messages.Message) *state.Error {
+	return nil
+}
+
+func (round *round0) GenerateMessages() ([]*messages.Message, *state.Error) {
+	// Sample a polynomial of degree t such that f_i(0) = 0.
+	// The sum of all updates is then a sharing of 0, so the group key does not change.
+	round.Polynomial = polynomial.NewPolynomial(round.Threshold, ristretto.NewScalar())
+
+	// Generate all commitments [a_{i j}] B for j = 1, ..., t, where [a_{i 0}] B is the identity.
+	// CommitmentsSum holds the sum of all commitments, so we initialize it to our commitment
+	round.CommitmentsSum = polynomial.NewPolynomialExponent(round.Polynomial)
+
+	// Add the update we would send to our selves.
+	round.Secret.Add(&round.Secret, round.Polynomial.Evaluate(round.SelfID().Scalar()))
+
+	msg := messages.NewRefresh1(round.SelfID(), round.CommitmentsSum.Copy())
+	return []*messages.Message{msg}, nil
+}
+
+func (round *round0) NextRound() state.Round {
+	return &round1{round}
+}
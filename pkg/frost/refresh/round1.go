@@ -0,0 +1,51 @@
+package refresh
+
+import (
+	"errors"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+func (round *round1) ProcessMessage(msg *messages.Message) *state.Error {
+	from := msg.From
+	commitments := msg.Refresh1.Commitments
+
+	if commitments.Degree() != round.Threshold {
+		return state.NewValidationError(from, errors.New("commitments have the wrong degree"))
+	}
+	// If the constant coefficient is not the identity, the update would change the group key.
+	if commitments.Constant().IsIdentity() != 1 {
+		return state.NewValidationError(from, errors.New("commitments do not share 0"))
+	}
+
+	round.Commitments[from] = commitments
+
+	// Add the commitments to our own, so that we can update the public shares
+	_ = round.CommitmentsSum.Add(commitments)
+	return nil
+}
+
+func (round *round1) GenerateMessages() ([]*messages.Message, *state.Error) {
+	msgsOut := make([]*messages.Message, 0, len(round.PartyIDs())-1)
+	for _, id := range round.PartyIDs() {
+		if id == round.SelfID() {
+			continue
+		}
+		msgsOut = append(msgsOut, messages.NewRefresh2(round.SelfID(), id, round.Polynomial.Evaluate(id.Scalar())))
+	}
+
+	// Now that we have sent the updates to every one,
+	// we no longer require the original polynomial, so we reset it
+	round.Polynomial.Reset()
+
+	return msgsOut, nil
+}
+
+func (round *round1) NextRound() state.Round {
+	return &round2{round}
+}
+
+func (round *round1) MessageType() messages.MessageType {
+	return messages.MessageTypeRefresh1
+}
@@ -0,0 +1,63 @@
+package refresh
+
+import (
+	"errors"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+func (round *round2) ProcessMessage(msg *messages.Message) *state.Error {
+	var computedShareExp ristretto.Element
+	computedShareExp.ScalarBaseMult(&msg.Refresh2.Share)
+
+	id := msg.From
+	shareExp := round.Commitments[id].Evaluate(round.SelfID().Scalar())
+
+	if computedShareExp.Equal(shareExp) != 1 {
+		return state.NewAbortError(errors.New("VSS failed to validate"), id)
+	}
+	round.Secret.Add(&round.Secret, &msg.Refresh2.Share)
+
+	// We can reset the share in the message now
+	msg.Refresh2.Share.Set(ristretto.NewScalar())
+
+	return nil
+}
+
+func (round *round2) GenerateMessages() ([]*messages.Message, *state.Error) {
+	// A'ⱼ = Aⱼ + ∑ Fᵢ(j)
+	shares := make(map[party.ID]*ristretto.Element, round.PartyIDs().N())
+	for _, id := range round.PartyIDs() {
+		var share ristretto.Element
+		shares[id] = share.Add(round.Public.Shares[id], round.CommitmentsSum.Evaluate(id.Scalar()))
+	}
+
+	public, err := eddsa.NewPublic(shares, round.Threshold)
+	if err != nil {
+		return nil, state.NewError(0, err)
+	}
+	if !public.GroupKey.Equal(round.Public.GroupKey) {
+		return nil, state.NewError(0, errors.New("refreshed shares do not match the group key"))
+	}
+
+	secret := eddsa.NewSecretShare(round.SelfID(), &round.Secret)
+	if secret.Public.Equal(public.Shares[round.SelfID()]) != 1 {
+		return nil, state.NewError(0, errors.New("refreshed secret does not match its public share"))
+	}
+
+	round.Output.Public = public
+	round.Output.SecretKey = secret
+	return nil, nil
+}
+
+func (round *round2) NextRound() state.Round {
+	return nil
+}
+
+func (round *round2) MessageType() messages.MessageType {
+	return messages.MessageTypeRefresh2
+}
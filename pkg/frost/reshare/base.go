@@ -0,0 +1,158 @@
+package reshare
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/polynomial"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+type (
+	round0 struct {
+		*state.BaseRound
+
+		// Dealers is the quorum of current shareholders who re-share the group's secret.
+		Dealers party.IDSlice
+
+		// Receivers is the set of parties who obtain a share in the new access structure.
+		Receivers party.IDSlice
+
+		// Threshold is the degree of the new polynomial used for Shamir.
+		Threshold party.Size
+
+		// Previous holds the public shares of the current access structure.
+		Previous *eddsa.Public
+
+		// Lagrange maps each dealer to its Lagrange coefficient for the quorum Dealers.
+		Lagrange map[party.ID]*ristretto.Scalar
+
+		// Secret is first set to the dealer's additive share 𝛌ᵢ • sᵢ of the group's secret.
+		// It is then reused to hold the sum of all shares received, which is the party's new secret key.
+		Secret ristretto.Scalar
+
+		// Polynomial used by a dealer to sample the new shares
+		Polynomial *polynomial.Polynomial
+
+		// CommitmentsSum is the sum of all commitments, we use it to compute the new public key shares
+		CommitmentsSum *polynomial.Exponent
+
+		// Commitments contains all dealers' commitment polynomials
+		Commitments map[party.ID]*polynomial.Exponent
+
+		Output *Output
+	}
+	round1 struct {
+		*round0
+	}
+	round2 struct {
+		*round1
+	}
+)
+
+// NewRound returns the first round of the resharing protocol for the party selfID.
+//
+// The parties in dealers must be a quorum of the current shareholders described by previous, and each of them
+// must provide its current SecretShare. The parties in receivers obtain new shares of the same group key,
+// with the given threshold. A party which is only a receiver may give a nil secret.
+func NewRound(selfID party.ID, secret *eddsa.SecretShare, previous *eddsa.Public, dealers, receivers party.IDSlice, threshold party.Size) (state.Round, *Output, error) {
+	if err := party.ValidateIDs(receivers); err != nil {
+		return nil, nil, fmt.Errorf("reshare.NewRound: receivers: %w", err)
+	}
+	if err := party.ValidateIDs(dealers); err != nil {
+		return nil, nil, fmt.Errorf("reshare.NewRound: dealers: %w", err)
+	}
+	if err := party.ValidateThreshold(threshold, receivers.N()); err != nil {
+		return nil, nil, fmt.Errorf("reshare.NewRound: %w", err)
+	}
+	if dealers.N() <= previous.Threshold {
+		return nil, nil, errors.New("reshare.NewRound: dealers must contain at least T+1 parties of the current access structure")
+	}
+	if !dealers.IsSubsetOf(previous.PartyIDs) {
+		return nil, nil, errors.New("reshare.NewRound: not all dealers are contained in previous")
+	}
+
+	isDealer := dealers.Contains(selfID)
+	if !isDealer && !receivers.Contains(selfID) {
+		return nil, nil, errors.New("reshare.NewRound: selfID is neither a dealer nor a receiver")
+	}
+	if isDealer {
+		if secret == nil || secret.ID != selfID {
+			return nil, nil, errors.New("reshare.NewRound: a dealer must provide its own SecretShare")
+		}
+		if previous.Shares[selfID].Equal(&secret.Public) != 1 {
+			return nil, nil, errors.New("reshare.NewRound: SecretShare does not match its public share")
+		}
+	}
+
+	lagrange, err := party.LagrangeCoefficients(dealers)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reshare.NewRound: %w", err)
+	}
+
+	baseRound, err := state.NewBaseRound(selfID, union(dealers, receivers))
+	if err != nil {
+		return nil, nil, fmt.Errorf("reshare.NewRound: %w", err)
+	}
+
+	r := round0{
+		BaseRound:   baseRound,
+		Dealers:     dealers.Copy(),
+		Receivers:   receivers.Copy(),
+		Threshold:   threshold,
+		Previous:    previous,
+		Lagrange:    lagrange,
+		Commitments: make(map[party.ID]*polynomial.Exponent, dealers.N()),
+		Output:      &Output{},
+	}
+	if isDealer {
+		r.Secret.Multiply(lagrange[selfID], &secret.Secret)
+	}
+
+	return &r, r.Output, nil
+}
+
+// union returns the sorted set of parties in a or b.
+func union(a, b party.IDSlice) party.IDSlice {
+	ids := a.Copy()
+	for _, id := range b {
+		if !a.Contains(id) {
+			ids = append(ids, id)
+		}
+	}
+	return party.NewIDSlice(ids)
+}
+
+func (round *round0) Reset() {
+	round.Secret.Set(ristretto.NewScalar())
+	if round.Polynomial != nil {
+		round.Polynomial.Reset()
+	}
+	if round.CommitmentsSum != nil {
+		round.CommitmentsSum.Reset()
+	}
+	for _, p := range round.Commitments {
+		p.Reset()
+	}
+	round.Output = nil
+}
+
+func (round *round0) isDealer() bool {
+	return round.Dealers.Contains(round.SelfID())
+}
+
+func (round *round0) isReceiver() bool {
+	return round.Receivers.Contains(round.SelfID())
+}
+
+// ---
+// Messages
+// ---
+
+func (round *round0) AcceptedMessageTypes() []messages.MessageType {
+	return []messages.MessageType{messages.MessageTypeNone, messages.MessageTypeReshare1, messages.MessageTypeReshare2}
+}
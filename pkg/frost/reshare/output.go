@@ -0,0 +1,12 @@
+package reshare
+
+import "github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+
+type Output struct {
+	// Public contains the public shares of the new access structure.
+	Public *eddsa.Public
+
+	// SecretKey is the party's share in the new access structure.
+	// It is nil if the party is not a receiver.
+	SecretKey *eddsa.SecretShare
+}
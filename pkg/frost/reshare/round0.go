@@ -0,0 +1,41 @@
+package reshare
+
+import (
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/polynomial"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+func (round *round0) ProcessMessage(*messages.Message) *state.Error {
+	return nil
+}
+
+func (round *round0) GenerateMessages() ([]*messages.Message, *state.Error) {
+	if !round.isDealer() {
+		return nil, nil
+	}
+
+	// Sample a polynomial of the new degree t' whose constant is 𝛌ᵢ • sᵢ
+	round.Polynomial = polynomial.NewPolynomial(round.Threshold, &round.Secret)
+
+	// Generate all commitments [a_{i j}] B for j = 0, 1, ..., t'
+	commitments := polynomial.NewPolynomialExponent(round.Polynomial)
+	round.Commitments[round.SelfID()] = commitments
+
+	// We use the variable Secret to hold the sum of all shares received.
+	// Therefore, we can set it to the share we would send to our selves,
+	// and we overwrite 𝛌ᵢ • sᵢ which is no longer needed.
+	if round.isReceiver() {
+		round.Secret.Set(round.Polynomial.Evaluate(round.SelfID().Scalar()))
+	} else {
+		round.Secret.Set(ristretto.NewScalar())
+	}
+
+	msg := messages.NewReshare1(round.SelfID(), commitments.Copy())
+	return []*messages.Message{msg}, nil
+}
+
+func (round *round0) NextRound() state.Round {
+	return &round1{round}
+}
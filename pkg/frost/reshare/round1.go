@@ -0,0 +1,76 @@
+package reshare
+
+import (
+	"errors"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/polynomial"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+// Senders implements state.SenderRound, since only the dealers send commitments.
+func (round *round1) Senders() party.IDSlice {
+	return round.Dealers
+}
+
+func (round *round1) ProcessMessage(msg *messages.Message) *state.Error {
+	from := msg.From
+	if !round.Dealers.Contains(from) {
+		return state.NewValidationError(from, errors.New("party is not a dealer"))
+	}
+
+	commitments := msg.Reshare1.Commitments
+	if commitments.Degree() != round.Threshold {
+		return state.NewValidationError(from, errors.New("commitments have the wrong degree"))
+	}
+
+	// The constant coefficient must be [𝛌ᵢ • sᵢ] B = 𝛌ᵢ • Aᵢ, otherwise the group key would change.
+	var expected ristretto.Element
+	expected.ScalarMult(round.Lagrange[from], round.Previous.Shares[from])
+	if commitments.Constant().Equal(&expected) != 1 {
+		return state.NewAbortError(errors.New("commitments do not share the dealer's public share"), from)
+	}
+
+	round.Commitments[from] = commitments
+	return nil
+}
+
+func (round *round1) GenerateMessages() ([]*messages.Message, *state.Error) {
+	commitments := make([]*polynomial.Exponent, 0, len(round.Dealers))
+	for _, id := range round.Dealers {
+		commitments = append(commitments, round.Commitments[id])
+	}
+	sum, err := polynomial.Sum(commitments)
+	if err != nil {
+		return nil, state.NewError(0, err)
+	}
+	round.CommitmentsSum = sum
+
+	if !round.isDealer() {
+		return nil, nil
+	}
+
+	msgsOut := make([]*messages.Message, 0, len(round.Receivers))
+	for _, id := range round.Receivers {
+		if id == round.SelfID() {
+			continue
+		}
+		msgsOut = append(msgsOut, messages.NewReshare2(round.SelfID(), id, round.Polynomial.Evaluate(id.Scalar())))
+	}
+
+	// Now that we have sent the shares to every receiver,
+	// we no longer require the original polynomial, so we reset it
+	round.Polynomial.Reset()
+
+	return msgsOut, nil
+}
+
+func (round *round1) NextRound() state.Round {
+	return &round2{round}
+}
+
+func (round *round1) MessageType() messages.MessageType {
+	return messages.MessageTypeReshare1
+}
@@ -0,0 +1,70 @@
+package reshare
+
+import (
+	"errors"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+// Senders implements state.SenderRound, since only receivers get a share from the dealers.
+func (round *round2) Senders() party.IDSlice {
+	if round.isReceiver() {
+		return round.Dealers
+	}
+	return party.IDSlice{}
+}
+
+func (round *round2) ProcessMessage(msg *messages.Message) *state.Error {
+	id := msg.From
+	if !round.Dealers.Contains(id) {
+		return state.NewValidationError(id, errors.New("party is not a dealer"))
+	}
+
+	var computedShareExp ristretto.Element
+	computedShareExp.ScalarBaseMult(&msg.Reshare2.Share)
+
+	shareExp := round.Commitments[id].Evaluate(round.SelfID().Scalar())
+
+	if computedShareExp.Equal(shareExp) != 1 {
+		return state.NewAbortError(errors.New("VSS failed to validate"), id)
+	}
+	round.Secret.Add(&round.Secret, &msg.Reshare2.Share)
+
+	// We can reset the share in the message now
+	msg.Reshare2.Share.Set(ristretto.NewScalar())
+
+	return nil
+}
+
+func (round *round2) GenerateMessages() ([]*messages.Message, *state.Error) {
+	shares := make(map[party.ID]*ristretto.Element, round.Receivers.N())
+	for _, id := range round.Receivers {
+		shares[id] = round.CommitmentsSum.Evaluate(id.Scalar())
+	}
+
+	public, err := eddsa.NewPublic(shares, round.Threshold)
+	if err != nil {
+		return nil, state.NewError(0, err)
+	}
+	if !public.GroupKey.Equal(round.Previous.GroupKey) {
+		return nil, state.NewError(0, errors.New("new shares do not match the group key"))
+	}
+	round.Output.Public = public
+
+	if round.isReceiver() {
+		round.Output.SecretKey = eddsa.NewSecretShare(round.SelfID(), &round.Secret)
+	}
+	return nil, nil
+}
+
+func (round *round2) NextRound() state.Round {
+	return nil
+}
+
+func (round *round2) MessageType() messages.MessageType {
+	return messages.MessageTypeReshare2
+}
@@ -0,0 +1,106 @@
+package sign
+
+import (
+	"fmt"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+const (
+	// CommitmentSize is the length of an encoded commitment Dᵢ ∥ Eᵢ.
+	CommitmentSize = 32 + 32
+
+	// SignatureShareSize is the length of an encoded signature share zᵢ.
+	SignatureShareSize = 32
+)
+
+// EncodeCommitment returns the encoding Dᵢ ∥ Eᵢ of the commitments of a signer, where both points use the Ed25519 encoding.
+// It is the SerializeElement(hiding_nonce_commitment) ∥ SerializeElement(binding_nonce_commitment)
+// encoding of RFC 9591, and can be given to another implementation of the FROST(Ed25519, SHA-512) ciphersuite.
+// The signature share zᵢ of a signer is encoded with ristretto.Scalar.Bytes, as SerializeScalar.
+func EncodeCommitment(D, E *ristretto.Element) []byte {
+	out := make([]byte, 0, CommitmentSize)
+	out = append(out, D.BytesEd25519()...)
+	out = append(out, E.BytesEd25519()...)
+	return out
+}
+
+// Aggregate computes the signature of message from the commitments and signature shares of all signers of a session,
+// as the Coordinator of RFC 9591, Section 5.3.
+// The signers may use another implementation, since only their encoded outputs are needed:
+// commitments maps every signer to the encoding returned by EncodeCommitment,
+// and signatureShares maps the same signers to their encoded signature share.
+//
+// The signers are the keys of commitments, and must form a quorum of shares. opts must configure the session as
+// the signers did, in particular with WithCiphersuite(CiphersuiteRFC9591) to aggregate the outputs of another implementation.
+// Options which only affect the nonces of a signer, such as WithRandom, are ignored.
+//
+// An input which cannot be decoded is reported with a state.ValidationError naming its signer.
+// Every signature share is verified as in the signing protocol. If some are invalid, the returned error is
+// an IdentifiableAbortError whose Culprits are their signers.
+func Aggregate(shares *eddsa.Public, message []byte, commitments, signatureShares map[party.ID][]byte, opts ...Option) (*eddsa.Signature, error) {
+	ids := make([]party.ID, 0, len(commitments))
+	for id := range commitments {
+		ids = append(ids, id)
+	}
+	partyIDs, err := validateQuorum(ids, shares)
+	if err != nil {
+		return nil, fmt.Errorf("sign.Aggregate: %w", err)
+	}
+	if len(signatureShares) != len(commitments) {
+		return nil, fmt.Errorf("sign.Aggregate: %d signature shares were given for %d signers", len(signatureShares), len(commitments))
+	}
+
+	// The round is only used to combine the outputs of the signers, so its own ID is irrelevant
+	round, _, err := newRound(partyIDs[0], partyIDs, shares, message, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("sign.Aggregate: %w", err)
+	}
+	r1 := &round1{round0: round}
+	r2 := &round2{round1: r1}
+
+	for _, id := range partyIDs {
+		data := commitments[id]
+		if len(data) != CommitmentSize {
+			return nil, state.NewValidationError(id, fmt.Errorf("commitment: %w", messages.ErrInvalidMessage))
+		}
+		var D, E ristretto.Element
+		if _, err = D.SetBytesEd25519(data[:32]); err != nil {
+			return nil, state.NewValidationError(id, fmt.Errorf("commitment D: %w", err))
+		}
+		if _, err = E.SetBytesEd25519(data[32:]); err != nil {
+			return nil, state.NewValidationError(id, fmt.Errorf("commitment E: %w", err))
+		}
+		if stateErr := r1.ProcessMessage(messages.NewSign1(id, &D, &E)); stateErr != nil {
+			return nil, stateErr
+		}
+	}
+	if stateErr := r1.computeChallenge(); stateErr != nil {
+		return nil, stateErr
+	}
+
+	for _, id := range partyIDs {
+		data, ok := signatureShares[id]
+		if !ok {
+			return nil, fmt.Errorf("sign.Aggregate: signature share of party %d is missing", id)
+		}
+		if len(data) != SignatureShareSize {
+			return nil, state.NewValidationError(id, fmt.Errorf("signature share: %w", messages.ErrInvalidMessage))
+		}
+		var z ristretto.Scalar
+		if _, err = z.SetCanonicalBytes(data); err != nil {
+			return nil, state.NewValidationError(id, fmt.Errorf("signature share: %w", err))
+		}
+		if stateErr := r2.ProcessMessage(messages.NewSign2(id, &z)); stateErr != nil {
+			return nil, stateErr
+		}
+	}
+	if _, stateErr := r2.GenerateMessages(); stateErr != nil {
+		return nil, stateErr
+	}
+	return round.Output.Signature, nil
+}
@@ -0,0 +1,136 @@
+package sign
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+// rfc9591Outputs returns the encoded commitments and signature shares of the signers of rfc9591Vectors,
+// as they would be received from another implementation.
+func rfc9591Outputs(t *testing.T) (commitments, sigShares map[party.ID][]byte) {
+	commitments = make(map[party.ID][]byte, len(rfc9591Vectors.signers))
+	sigShares = make(map[party.ID][]byte, len(rfc9591Vectors.signers))
+	for id, signer := range rfc9591Vectors.signers {
+		commitments[id] = decodeHex(t, signer.hidingCommitment+signer.bindingCommitment)
+		sigShares[id] = decodeHex(t, signer.sigShare)
+	}
+	return commitments, sigShares
+}
+
+func TestAggregate_RFC9591(t *testing.T) {
+	_, public := rfc9591Keys(t)
+	message := decodeHex(t, rfc9591Vectors.message)
+	commitments, sigShares := rfc9591Outputs(t)
+
+	sig, err := Aggregate(public, message, commitments, sigShares, WithCiphersuite(CiphersuiteRFC9591))
+	require.NoError(t, err)
+	assert.Equal(t, rfc9591Vectors.signature, hex.EncodeToString(sig.ToEd25519()))
+	assert.True(t, ed25519.Verify(public.GroupKey.ToEd25519(), message, sig.ToEd25519()))
+
+	// The binding factors of the legacy ciphersuite differ, so the shares are invalid
+	_, err = Aggregate(public, message, commitments, sigShares)
+	var abortErr *IdentifiableAbortError
+	require.ErrorAs(t, err, &abortErr)
+	assert.Equal(t, party.IDSlice{1, 3}, abortErr.Culprits)
+
+	// An invalid share is attributed to its signer
+	tampered := map[party.ID][]byte{1: sigShares[1], 3: append([]byte(nil), sigShares[3]...)}
+	tampered[3][0] ^= 1
+	_, err = Aggregate(public, message, commitments, tampered, WithCiphersuite(CiphersuiteRFC9591))
+	require.ErrorAs(t, err, &abortErr)
+	assert.Equal(t, party.IDSlice{3}, abortErr.Culprits)
+	assert.ErrorIs(t, err, ErrValidateSigShare)
+}
+
+func TestAggregate_Invalid(t *testing.T) {
+	_, public := rfc9591Keys(t)
+	message := decodeHex(t, rfc9591Vectors.message)
+	opt := WithCiphersuite(CiphersuiteRFC9591)
+
+	// modify returns the outputs of rfc9591Vectors, after f has been applied to them
+	modify := func(f func(commitments, sigShares map[party.ID][]byte)) (map[party.ID][]byte, map[party.ID][]byte) {
+		commitments, sigShares := rfc9591Outputs(t)
+		f(commitments, sigShares)
+		return commitments, sigShares
+	}
+
+	for _, tt := range []struct {
+		name   string
+		modify func(commitments, sigShares map[party.ID][]byte)
+		sender party.ID
+	}{
+		{"short commitment", func(c, _ map[party.ID][]byte) { c[3] = c[3][:63] }, 3},
+		{"long share", func(_, s map[party.ID][]byte) { s[1] = append(s[1], 0) }, 1},
+		{"non-canonical share", func(_, s map[party.ID][]byte) { s[3][31] = 0xff }, 3},
+		{"identity commitment", func(c, _ map[party.ID][]byte) {
+			copy(c[1][32:], decodeHex(t, "0100000000000000000000000000000000000000000000000000000000000000"))
+		}, 1},
+		{"invalid point", func(c, _ map[party.ID][]byte) { c[3][0] ^= 1 }, 3},
+	} {
+		commitments, sigShares := modify(tt.modify)
+		_, err := Aggregate(public, message, commitments, sigShares, opt)
+		var validationErr *state.ValidationError
+		if assert.ErrorAs(t, err, &validationErr, tt.name) {
+			assert.Equal(t, tt.sender, validationErr.Sender, tt.name)
+		}
+	}
+
+	// The signers must form a quorum, and all of them must give a share
+	commitments, sigShares := modify(func(c, s map[party.ID][]byte) { delete(c, 3); delete(s, 3) })
+	_, err := Aggregate(public, message, commitments, sigShares, opt)
+	assert.Error(t, err)
+	commitments, sigShares = modify(func(_, s map[party.ID][]byte) { delete(s, 3) })
+	_, err = Aggregate(public, message, commitments, sigShares, opt)
+	assert.Error(t, err)
+	commitments, sigShares = modify(func(_, s map[party.ID][]byte) { s[2] = s[3]; delete(s, 3) })
+	_, err = Aggregate(public, message, commitments, sigShares, opt)
+	assert.Error(t, err)
+}
+
+// TestAggregate_Signers aggregates the encoded outputs of local signers, as another implementation would receive them.
+func TestAggregate_Signers(t *testing.T) {
+	secretShares, public := rfc9591Keys(t)
+	message := []byte("interoperability")
+	signIDs := party.IDSlice{1, 2, 3}
+
+	for _, c := range []Ciphersuite{CiphersuiteLegacy, CiphersuiteRFC9591, CiphersuiteTranscript} {
+		rounds := make(map[party.ID]*round1, len(signIDs))
+		var msgs1 []*messages.Message
+		for _, id := range signIDs {
+			r, _, err := NewRound(signIDs, secretShares[id], public, message, WithCiphersuite(c))
+			require.NoError(t, err)
+			rounds[id] = &round1{r.(*round0)}
+			out, stateErr := rounds[id].round0.GenerateMessages()
+			require.Nil(t, stateErr)
+			msgs1 = append(msgs1, out...)
+		}
+
+		commitments := make(map[party.ID][]byte, len(signIDs))
+		for _, msg := range msgs1 {
+			commitments[msg.From] = EncodeCommitment(&msg.Sign1.Di, &msg.Sign1.Ei)
+			for _, id := range signIDs {
+				if id != msg.From {
+					require.Nil(t, rounds[id].ProcessMessage(msg))
+				}
+			}
+		}
+
+		sigShares := make(map[party.ID][]byte, len(signIDs))
+		for _, id := range signIDs {
+			out, stateErr := rounds[id].GenerateMessages()
+			require.Nil(t, stateErr)
+			sigShares[id] = out[0].Sign2.Zi.Bytes()
+		}
+
+		sig, err := Aggregate(public, message, commitments, sigShares, WithCiphersuite(c))
+		require.NoError(t, err, c)
+		assert.True(t, ed25519.Verify(public.GroupKey.ToEd25519(), message, sig.ToEd25519()), c)
+	}
+}
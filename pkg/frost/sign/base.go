@@ -1,8 +1,10 @@
 package sign
 
 import (
+	"crypto/rand"
 	"errors"
 	"fmt"
+	"io"
 
 	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
 	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
@@ -25,6 +27,33 @@ type (
 		GroupKey       eddsa.PublicKey
 		SecretKeyShare ristretto.Scalar
 
+		// Ciphersuite determines how the binding factors and nonces are derived.
+		Ciphersuite Ciphersuite
+
+		// Options selects the Ed25519 variant used to compute the challenge.
+		Options eddsa.Options
+
+		// secret is the unmodified Shamir share of the signer, which RFC 9591 uses when generating nonces.
+		secret ristretto.Scalar
+
+		// random is the source of randomness for the nonces.
+		random io.Reader
+
+		// hedged is true if the nonces are derived with hedgedNonces.
+		hedged bool
+
+		// session is the identifier given with WithSessionID, which is included in the binding factors if it is not empty.
+		session []byte
+
+		// quorum contains the Lagrange coefficients of the signers, if given with WithQuorumContext.
+		quorum *QuorumContext
+
+		// nonceStore records the commitments used by this signer, if set.
+		nonceStore UsedNonceStore
+
+		// tweak is the public tweak given with WithTweak, which is added to the secret of the group if set.
+		tweak *ristretto.Scalar
+
 		// e and d are the scalars committed to in the first round
 		e, d ristretto.Scalar
 
@@ -40,53 +69,122 @@ type (
 	}
 	round2 struct {
 		*round1
+
+		// culprits are the parties whose signature share was invalid
+		culprits []party.ID
 	}
 )
 
-func NewRound(partyIDs party.IDSlice, secret *eddsa.SecretShare, shares *eddsa.Public, message []byte) (state.Round, *Output, error) {
+// NewRound returns the first round of the signing protocol.
+// Without options, the session uses CiphersuiteTranscript.
+//
+// partyIDs is the quorum of signers. It must contain at least shares.Threshold+1 distinct parties,
+// all of which must be contained in shares, and it may be given in any order.
+//
+// The nonces of the signer are sampled here, so that an error of the source of randomness is returned
+// before the session starts.
+func NewRound(partyIDs party.IDSlice, secret *eddsa.SecretShare, shares *eddsa.Public, message []byte, opts ...Option) (state.Round, *Output, error) {
+	partyIDs, err := validateQuorum(partyIDs, shares)
+	if err != nil {
+		return nil, nil, fmt.Errorf("base.NewRound: %w", err)
+	}
 	if !partyIDs.Contains(secret.ID) {
 		return nil, nil, errors.New("base.NewRound: owner of SecretShare is not contained in partyIDs")
 	}
-	if !partyIDs.IsSubsetOf(shares.PartyIDs) {
-		return nil, nil, errors.New("base.NewRound: not all parties of partyIDs are contained in shares")
-	}
 
-	baseRound, err := state.NewBaseRound(secret.ID, partyIDs)
+	round, quorum, err := newRound(secret.ID, partyIDs, shares, message, opts...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("base.NewRound: %w", err)
 	}
 
+	// Normalize secret share so that we can assume we are dealing with an additive sharing
+	round.SecretKeyShare.Multiply(quorum.lagrange[round.SelfID()], &secret.Secret)
+	round.secret.Set(&secret.Secret)
+	if round.tweak != nil {
+		// 𝛌 • (s + t), so that the shares sum to the tweaked secret
+		round.SecretKeyShare.MultiplyAdd(quorum.lagrange[round.SelfID()], round.tweak, &round.SecretKeyShare)
+	}
+
+	if err = round.sampleNonces(); err != nil {
+		round.Reset()
+		return nil, nil, fmt.Errorf("base.NewRound: %w", err)
+	}
+
+	return round, round.Output, nil
+}
+
+// newRound returns the first round of a session for the sorted quorum partyIDs, without the secret of selfID,
+// and the QuorumContext of the session.
+func newRound(selfID party.ID, partyIDs party.IDSlice, shares *eddsa.Public, message []byte, opts ...Option) (*round0, *QuorumContext, error) {
+	baseRound, err := state.NewBaseRound(selfID, partyIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	round := &round0{
 		BaseRound: baseRound,
 		Message:   message,
 		Parties:   make(map[party.ID]*signer, partyIDs.N()),
 		GroupKey:  *shares.GroupKey,
 		Output:    &Output{},
+		random:    rand.Reader,
+	}
+	round.Ciphersuite = CiphersuiteTranscript
+	round.R.Set(ristretto.NewIdentityElement())
+	for _, opt := range opts {
+		opt(round)
+	}
+	if !round.Ciphersuite.valid() {
+		return nil, nil, fmt.Errorf("unknown ciphersuite %v", round.Ciphersuite)
+	}
+	if round.Ciphersuite == CiphersuiteLegacy {
+		if err = validateLegacyIDs(partyIDs); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err = round.Options.Validate(message); err != nil {
+		return nil, nil, err
+	}
+
+	quorum, err := round.quorumContext(shares)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Setup parties
+	var tweakPublic ristretto.Element
+	if round.tweak != nil {
+		round.GroupKey = *round.GroupKey.Tweak(round.tweak)
+	}
 	for _, id := range partyIDs {
 		var s signer
-		if id == 0 {
-			return nil, nil, errors.New("base.NewRound: id 0 is not valid")
-		}
-		originalShare := shares.Shares[id]
-		lagrange, err := id.Lagrange(partyIDs)
-		if err != nil {
-			return nil, nil, fmt.Errorf("base.NewRound: %w", err)
+		s.Reset()
+		s.Public.Set(quorum.public[id])
+		if round.tweak != nil {
+			// [𝛌](Yᵢ + [t]B)
+			tweakPublic.ScalarBaseMult(ristretto.NewScalar().Multiply(quorum.lagrange[id], round.tweak))
+			s.Public.Add(&s.Public, &tweakPublic)
 		}
-		s.Public.ScalarMult(lagrange, originalShare)
 		round.Parties[id] = &s
 	}
+	return round, quorum, nil
+}
 
-	// Normalize secret share so that we can assume we are dealing with an additive sharing
-	lagrange, err := round.SelfID().Lagrange(partyIDs)
-	if err != nil {
-		return nil, nil, fmt.Errorf("base.NewRound: %w", err)
+// validateQuorum checks that partyIDs can produce a signature for shares.GroupKey,
+// and returns the sorted quorum.
+func validateQuorum(partyIDs party.IDSlice, shares *eddsa.Public) (party.IDSlice, error) {
+	if err := party.ValidateIDs(partyIDs); err != nil {
+		return nil, err
 	}
-	round.SecretKeyShare.Multiply(lagrange, &secret.Secret)
-
-	return round, round.Output, nil
+	quorum := party.NewIDSlice(partyIDs)
+	if unknown := quorum.Difference(shares.PartyIDs); len(unknown) > 0 {
+		return nil, fmt.Errorf("partyIDs contains parties %v which are not contained in shares", unknown)
+	}
+	if quorum.N() <= shares.Threshold {
+		return nil, fmt.Errorf("a quorum of at least %d parties is required for threshold %d, but partyIDs contains %d",
+			shares.Threshold+1, shares.Threshold, quorum.N())
+	}
+	return quorum, nil
 }
 
 func (round *round0) Reset() {
@@ -94,10 +192,11 @@ func (round *round0) Reset() {
 	one := ristretto.NewIdentityElement()
 
 	round.Message = nil
-	round.SecretKeyShare.Set(zero)
+	round.SecretKeyShare.Zeroize()
+	round.secret.Zeroize()
 
-	round.e.Set(zero)
-	round.d.Set(zero)
+	round.e.Zeroize()
+	round.d.Zeroize()
 	round.C.Set(zero)
 	round.R.Set(one)
 
@@ -115,3 +214,17 @@ func (round *round0) AcceptedMessageTypes() []messages.MessageType {
 		messages.MessageTypeSign2,
 	}
 }
+
+// signedMessage returns the message of the session, preceded by its associated data if any,
+// from which the binding factors and the challenge are derived.
+func (round *round0) signedMessage() []byte {
+	return eddsa.MessageWithAssociatedData(round.Message, round.Options.AssociatedData)
+}
+
+// hasher returns the eddsa.Hasher of the session.
+func (round *round0) hasher() eddsa.Hasher {
+	if round.Options.Hasher == nil {
+		return eddsa.SHA512
+	}
+	return round.Options.Hasher
+}
@@ -0,0 +1,234 @@
+package sign
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+type (
+	// batchRound0 runs one signing session per message of the batch, or per key with NewMultiKeyRound.
+	// The messages of all sessions are sent together, so that the batch requires only two rounds of communication.
+	batchRound0 struct {
+		*state.BaseRound
+
+		sessions []*round0
+	}
+	batchRound1 struct {
+		*batchRound0
+
+		rounds []*round1
+	}
+	batchRound2 struct {
+		*batchRound1
+
+		rounds []*round2
+	}
+)
+
+// NewBatchRound returns the first round of a protocol which signs all messages at once.
+// Each message is signed in an independent session with its own nonces and binding factors,
+// as if NewRound was called with the same parameters for each message.
+// The i-th Output will contain the signature of the i-th message.
+func NewBatchRound(partyIDs party.IDSlice, secret *eddsa.SecretShare, shares *eddsa.Public, msgs [][]byte, opts ...Option) (state.Round, []*Output, error) {
+	if len(msgs) == 0 {
+		return nil, nil, errors.New("sign.NewBatchRound: no messages to sign")
+	}
+	if len(msgs) > messages.MaxBatchSize {
+		return nil, nil, fmt.Errorf("sign.NewBatchRound: at most %d messages can be signed at once", messages.MaxBatchSize)
+	}
+
+	partyIDs, err := validateQuorum(partyIDs, shares)
+	if err != nil {
+		return nil, nil, fmt.Errorf("sign.NewBatchRound: %w", err)
+	}
+	round, err := newBatchRound(secret.ID, partyIDs, len(msgs))
+	if err != nil {
+		return nil, nil, fmt.Errorf("sign.NewBatchRound: %w", err)
+	}
+
+	outputs := make([]*Output, 0, len(msgs))
+	for i, message := range msgs {
+		r, output, err := NewRound(partyIDs, secret, shares, message, opts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("sign.NewBatchRound: message %d: %w", i, err)
+		}
+		round.sessions = append(round.sessions, r.(*round0))
+		outputs = append(outputs, output)
+	}
+	return round, outputs, nil
+}
+
+// NewMultiKeyRound returns the first round of a protocol which signs message under several keys at once.
+// The i-th key is given by its shares[i] and the signer's share secrets[i], all of which must belong to the same party.
+// As with NewBatchRound, each key is used in an independent session with its own nonces and binding factors,
+// as if NewRound was called with the i-th secret and shares, and the i-th Output will contain the signature
+// which verifies under shares[i].GroupKey.
+//
+// The same parties must sign for all keys, so partyIDs must be a quorum of every shares[i].
+// All signers must give the keys in the same order.
+func NewMultiKeyRound(partyIDs party.IDSlice, secrets []*eddsa.SecretShare, shares []*eddsa.Public, message []byte, opts ...Option) (state.Round, []*Output, error) {
+	if len(secrets) == 0 {
+		return nil, nil, errors.New("sign.NewMultiKeyRound: no keys to sign with")
+	}
+	if len(secrets) != len(shares) {
+		return nil, nil, fmt.Errorf("sign.NewMultiKeyRound: %d secret shares were given for %d keys", len(secrets), len(shares))
+	}
+	if len(secrets) > messages.MaxBatchSize {
+		return nil, nil, fmt.Errorf("sign.NewMultiKeyRound: at most %d keys can be used at once", messages.MaxBatchSize)
+	}
+
+	selfID := secrets[0].ID
+	for i, secret := range secrets {
+		if secret.ID != selfID {
+			return nil, nil, fmt.Errorf("sign.NewMultiKeyRound: key %d: secret share of party %d, instead of %d", i, secret.ID, selfID)
+		}
+	}
+	// The quorum is checked against every key by NewRound
+	quorum, err := validateQuorum(partyIDs, shares[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("sign.NewMultiKeyRound: key 0: %w", err)
+	}
+	round, err := newBatchRound(selfID, quorum, len(secrets))
+	if err != nil {
+		return nil, nil, fmt.Errorf("sign.NewMultiKeyRound: %w", err)
+	}
+
+	outputs := make([]*Output, 0, len(secrets))
+	for i := range secrets {
+		r, output, err := NewRound(partyIDs, secrets[i], shares[i], message, opts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("sign.NewMultiKeyRound: key %d: %w", i, err)
+		}
+		round.sessions = append(round.sessions, r.(*round0))
+		outputs = append(outputs, output)
+	}
+	return round, outputs, nil
+}
+
+// newBatchRound returns a batchRound0 for n sessions between partyIDs, to which the sessions must be added.
+func newBatchRound(selfID party.ID, partyIDs party.IDSlice, n int) (*batchRound0, error) {
+	baseRound, err := state.NewBaseRound(selfID, partyIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &batchRound0{
+		BaseRound: baseRound,
+		sessions:  make([]*round0, 0, n),
+	}, nil
+}
+
+// batchError wraps the error of the i-th session in the batch.
+func batchError(i int, err *state.Error) *state.Error {
+	return state.NewError(err.PartyID, fmt.Errorf("batch session %d: %w", i, err.Unwrap()))
+}
+
+func (round *batchRound0) ProcessMessage(*messages.Message) *state.Error {
+	return nil
+}
+
+func (round *batchRound0) GenerateMessages() ([]*messages.Message, *state.Error) {
+	commitments := make([]messages.Sign1, len(round.sessions))
+	for i, session := range round.sessions {
+		msgs, err := session.GenerateMessages()
+		if err != nil {
+			return nil, batchError(i, err)
+		}
+		commitments[i] = *msgs[0].Sign1
+	}
+	return []*messages.Message{messages.NewSignBatch1(round.SelfID(), commitments)}, nil
+}
+
+func (round *batchRound0) NextRound() state.Round {
+	rounds := make([]*round1, len(round.sessions))
+	for i, session := range round.sessions {
+		rounds[i] = session.NextRound().(*round1)
+	}
+	return &batchRound1{batchRound0: round, rounds: rounds}
+}
+
+func (round *batchRound0) Reset() {
+	for _, session := range round.sessions {
+		session.Reset()
+	}
+}
+
+func (round *batchRound0) AcceptedMessageTypes() []messages.MessageType {
+	return []messages.MessageType{
+		messages.MessageTypeNone,
+		messages.MessageTypeSignBatch1,
+		messages.MessageTypeSignBatch2,
+	}
+}
+
+func (round *batchRound1) ProcessMessage(msg *messages.Message) *state.Error {
+	commitments := msg.SignBatch1.Commitments
+	if len(commitments) != len(round.rounds) {
+		return state.NewValidationError(msg.From, fmt.Errorf("expected %d commitments, got %d", len(round.rounds), len(commitments)))
+	}
+	for i, r := range round.rounds {
+		sign1 := &messages.Message{
+			Header: messages.Header{Type: messages.MessageTypeSign1, From: msg.From},
+			Sign1:  &commitments[i],
+		}
+		if err := r.ProcessMessage(sign1); err != nil {
+			return batchError(i, err)
+		}
+	}
+	return nil
+}
+
+func (round *batchRound1) GenerateMessages() ([]*messages.Message, *state.Error) {
+	shares := make([]ristretto.Scalar, len(round.rounds))
+	for i, r := range round.rounds {
+		msgs, err := r.GenerateMessages()
+		if err != nil {
+			return nil, batchError(i, err)
+		}
+		shares[i].Set(&msgs[0].Sign2.Zi)
+	}
+	return []*messages.Message{messages.NewSignBatch2(round.SelfID(), shares)}, nil
+}
+
+func (round *batchRound1) NextRound() state.Round {
+	rounds := make([]*round2, len(round.rounds))
+	for i, r := range round.rounds {
+		rounds[i] = r.NextRound().(*round2)
+	}
+	return &batchRound2{batchRound1: round, rounds: rounds}
+}
+
+func (round *batchRound2) ProcessMessage(msg *messages.Message) *state.Error {
+	shares := msg.SignBatch2.Zi
+	if len(shares) != len(round.rounds) {
+		return state.NewValidationError(msg.From, fmt.Errorf("expected %d signature shares, got %d", len(round.rounds), len(shares)))
+	}
+	for i, r := range round.rounds {
+		sign2 := &messages.Message{
+			Header: messages.Header{Type: messages.MessageTypeSign2, From: msg.From},
+			Sign2:  &messages.Sign2{Zi: shares[i]},
+		}
+		if err := r.ProcessMessage(sign2); err != nil {
+			return batchError(i, err)
+		}
+	}
+	return nil
+}
+
+func (round *batchRound2) GenerateMessages() ([]*messages.Message, *state.Error) {
+	for i, r := range round.rounds {
+		if _, err := r.GenerateMessages(); err != nil {
+			return nil, batchError(i, err)
+		}
+	}
+	return nil, nil
+}
+
+func (round *batchRound2) NextRound() state.Round {
+	return nil
+}
@@ -0,0 +1,178 @@
+package sign
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+// Ciphersuite selects the hash functions and encodings used to derive the binding factors
+// and the challenge during a signing session.
+type Ciphersuite uint8
+
+const (
+	// CiphersuiteLegacy is the construction of the releases using messages.WireVersion 1.
+	// Binding factors are computed over the Ristretto encoding of the commitments, and party IDs are encoded
+	// with 2 bytes, so that it can only be used when all IDs are at most 65535.
+	// Nonces are sampled uniformly at random.
+	CiphersuiteLegacy Ciphersuite = iota
+
+	// CiphersuiteRFC9591 is the FROST(Ed25519, SHA-512) ciphersuite of RFC 9591.
+	// Signers using it interoperate with other conformant implementations.
+	CiphersuiteRFC9591
+
+	// CiphersuiteTranscript is the construction specific to this library, and the default.
+	// It differs from CiphersuiteLegacy in the binding factors only, which are derived from
+	// a transcript in which the commitments are labeled and prefixed with their length.
+	CiphersuiteTranscript
+)
+
+// rfc9591ContextString is the contextString of the FROST(Ed25519, SHA-512) ciphersuite.
+const rfc9591ContextString = "FROST-ED25519-SHA512-v1"
+
+// String implements fmt.Stringer.
+func (c Ciphersuite) String() string {
+	switch c {
+	case CiphersuiteLegacy:
+		return "legacy"
+	case CiphersuiteRFC9591:
+		return rfc9591ContextString
+	case CiphersuiteTranscript:
+		return "transcript"
+	default:
+		return fmt.Sprintf("Ciphersuite(%d)", uint8(c))
+	}
+}
+
+func (c Ciphersuite) valid() bool {
+	return c == CiphersuiteLegacy || c == CiphersuiteRFC9591 || c == CiphersuiteTranscript
+}
+
+// legacyIDSize is the number of bytes of the party IDs hashed by CiphersuiteLegacy.
+const legacyIDSize = 2
+
+// legacyIDBytes returns the 2 byte big-endian encoding of id used by CiphersuiteLegacy.
+// The caller must ensure that id is at most math.MaxUint16.
+func legacyIDBytes(id party.ID) []byte {
+	var b [legacyIDSize]byte
+	binary.BigEndian.PutUint16(b[:], uint16(id))
+	return b[:]
+}
+
+// legacyBindingFactors computes the binding factor ρᵢ of each party i as the releases using messages.WireVersion 1:
+//
+//	ρᵢ = H("FROST-SHA512" ∥ i ∥ H(Message) ∥ B)
+//
+// where H is SHA-512 unless another eddsa.Hasher is used, and i is encoded with 2 bytes.
+// The list B is the concatenation of ( j ∥ Dⱼ ∥ Eⱼ ) for all signers j in sorted order.
+//
+//	B = (ID1 ∥ D₁ ∥ E₁) ∥ (ID_2 ∥ D₂ ∥ E₂) ∥ ... ∥ (ID_N ∥ D_N ∥ E_N)
+//
+// With WithAssociatedData, Message is preceded by the associated data as in eddsa.MessageWithAssociatedData.
+// When the session has an identifier S given by WithSessionID, H(Message) is followed by H("FROST-SHA512-SESSION" ∥ S).
+// Neither option existed in those releases.
+func (round *round1) legacyBindingFactors() {
+	/*
+		While profiling, we noticed that using hash.Hash forces all values to be allocated on the heap.
+		To limit this, we create a single big buffer and hash it with the session's eddsa.Hasher.
+
+		We need to compute a very simple hash N times, and Go's caching isn't great for hashing.
+		Therefore, we can simply change the buffer and rehash it many times.
+	*/
+	h := round.hasher()
+	messageHash := h.Hash("", round.signedMessage())
+	if len(round.session) > 0 {
+		messageHash = append(messageHash, h.Hash(hashDomainSeparation+"-SESSION", round.session)...)
+	}
+
+	sizeB := int(round.PartyIDs().N()) * (legacyIDSize + 32 + 32)
+	buffer := make([]byte, 0, legacyIDSize+len(messageHash)+sizeB)
+	buffer = append(buffer, legacyIDBytes(round.SelfID())...)
+	buffer = append(buffer, messageHash...)
+
+	// compute B
+	for _, id := range round.PartyIDs() {
+		otherParty := round.Parties[id]
+		buffer = append(buffer, legacyIDBytes(id)...)
+		buffer = append(buffer, otherParty.Di.Bytes()...)
+		buffer = append(buffer, otherParty.Ei.Bytes()...)
+	}
+
+	for _, id := range round.PartyIDs() {
+		// Update the first bytes with the ID
+		copy(buffer, legacyIDBytes(id))
+
+		// Pi = ρ = H ("FROST-SHA512" ∥ ID ∥ H(Message) ∥ B )
+		h.HashToScalar(&round.Parties[id].Pi, hashDomainSeparation, buffer)
+	}
+}
+
+// validateLegacyIDs returns an error if an ID of partyIDs can not be encoded by CiphersuiteLegacy.
+func validateLegacyIDs(partyIDs party.IDSlice) error {
+	for _, id := range partyIDs {
+		if id > math.MaxUint16 {
+			return fmt.Errorf("party %d can not sign with %v, whose IDs are at most %d", id, CiphersuiteLegacy, math.MaxUint16)
+		}
+	}
+	return nil
+}
+
+// rfc9591Hash returns H(contextString ∥ tag ∥ m₁ ∥ ... ∥ mₙ), where H is SHA-512 unless another eddsa.Hasher is used.
+// It corresponds to H1, H3, H4 and H5 of the ciphersuite, without the reduction modulo l.
+func rfc9591Hash(h eddsa.Hasher, tag string, m ...[]byte) []byte {
+	return h.Hash(rfc9591ContextString+tag, m...)
+}
+
+// rfc9591NonceGenerate implements nonce_generate from RFC 9591, Section 4.1:
+//
+//	nonce = H3(random_bytes(32) ∥ SerializeScalar(secret))
+func rfc9591NonceGenerate(h eddsa.Hasher, nonce *ristretto.Scalar, secret *ristretto.Scalar, random io.Reader) error {
+	var randomBytes [32]byte
+	if _, err := io.ReadFull(random, randomBytes[:]); err != nil {
+		return fmt.Errorf("sign: failed to read random bytes: %w", err)
+	}
+	h.HashToScalar(nonce, rfc9591ContextString+"nonce", randomBytes[:], secret.Bytes())
+	return nil
+}
+
+// rfc9591BindingFactors implements compute_binding_factors from RFC 9591, Section 4.4.
+// For every signer i, in sorted order,
+//
+//	ρᵢ = H1(PK ∥ H4(Message) ∥ H5(encoded_commitments) ∥ SerializeScalar(i))
+//
+// where encoded_commitments is the concatenation of ( SerializeScalar(j) ∥ Dⱼ ∥ Eⱼ )
+// for all signers j, and all points use the Ed25519 encoding.
+//
+// With WithAssociatedData, Message is preceded by the associated data as in eddsa.MessageWithAssociatedData,
+// which is the message signed by the resulting Ed25519 signature.
+// When the session has an identifier S given by WithSessionID, H5(encoded_commitments) is followed by H(contextString ∥ "session" ∥ S),
+// which is not part of RFC 9591, and the binding factors then differ from those of the specification.
+func (round *round1) rfc9591BindingFactors() {
+	partyIDs := round.PartyIDs()
+	h := round.hasher()
+
+	encodedCommitments := make([]byte, 0, partyIDs.N()*(32+32+32))
+	for _, id := range partyIDs {
+		otherParty := round.Parties[id]
+		encodedCommitments = append(encodedCommitments, id.Scalar().Bytes()...)
+		encodedCommitments = append(encodedCommitments, otherParty.Di.BytesEd25519()...)
+		encodedCommitments = append(encodedCommitments, otherParty.Ei.BytesEd25519()...)
+	}
+
+	prefix := make([]byte, 0, 32+64+64)
+	prefix = append(prefix, round.GroupKey.ToEd25519()...)
+	prefix = append(prefix, rfc9591Hash(h, "msg", round.signedMessage())...)
+	prefix = append(prefix, rfc9591Hash(h, "com", encodedCommitments)...)
+	if len(round.session) > 0 {
+		prefix = append(prefix, rfc9591Hash(h, "session", round.session)...)
+	}
+
+	for _, id := range partyIDs {
+		h.HashToScalar(&round.Parties[id].Pi, rfc9591ContextString+"rho", prefix, id.Scalar().Bytes())
+	}
+}
@@ -0,0 +1,221 @@
+package sign
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+func decodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+type rfc9591Signer struct {
+	hidingRandomness, bindingRandomness string
+	hidingNonce, bindingNonce           string
+	hidingCommitment                    string
+	bindingCommitment                   string
+	bindingFactor                       string
+	sigShare                            string
+}
+
+// rfc9591Vectors contains the test vectors of RFC 9591, Appendix E.1, FROST(Ed25519, SHA-512),
+// with threshold 1 and signers 1 and 3.
+var rfc9591Vectors = struct {
+	groupPublicKey string
+	message        string
+	signature      string
+	shares         map[party.ID]string
+	signers        map[party.ID]*rfc9591Signer
+}{
+	groupPublicKey: "15d21ccd7ee42959562fc8aa63224c8851fb3ec85a3faf66040d380fb9738673",
+	message:        "74657374",
+	signature: "36282629c383bb820a88b71cae937d41f2f2adfcc3d02e55507e2fb9e2dd3cbe" +
+		"bd9d2b0844e49ae0f3fa935161e1419aab7b47d21a37ebeae1f17d4987b3160b",
+	shares: map[party.ID]string{
+		1: "929dcc590407aae7d388761cddb0c0db6f5627aea8e217f4a033f2ec83d93509",
+		2: "a91e66e012e4364ac9aaa405fcafd370402d9859f7b6685c07eed76bf409e80d",
+		3: "d3cb090a075eb154e82fdb4b3cb507f110040905468bb9c46da8bdea643a9a02",
+	},
+	signers: map[party.ID]*rfc9591Signer{
+		1: {
+			hidingRandomness:  "0fd2e39e111cdc266f6c0f4d0fd45c947761f1f5d3cb583dfcb9bbaf8d4c9fec",
+			bindingRandomness: "69cd85f631d5f7f2721ed5e40519b1366f340a87c2f6856363dbdcda348a7501",
+			hidingNonce:       "812d6104142944d5a55924de6d49940956206909f2acaeedecda2b726e630407",
+			bindingNonce:      "b1110165fc2334149750b28dd813a39244f315cff14d4e89e6142f262ed83301",
+			hidingCommitment:  "b5aa8ab305882a6fc69cbee9327e5a45e54c08af61ae77cb8207be3d2ce13de3",
+			bindingCommitment: "67e98ab55aa310c3120418e5050c9cf76cf387cb20ac9e4b6fdb6f82a469f932",
+			bindingFactor:     "f2cb9d7dd9beff688da6fcc83fa89046b3479417f47f55600b106760eb3b5603",
+			sigShare:          "001719ab5a53ee1a12095cd088fd149702c0720ce5fd2f29dbecf24b7281b603",
+		},
+		3: {
+			hidingRandomness:  "86d64a260059e495d0fb4fcc17ea3da7452391baa494d4b00321098ed2a0062f",
+			bindingRandomness: "13e6b25afb2eba51716a9a7d44130c0dbae0004a9ef8d7b5550c8a0e07c61775",
+			hidingNonce:       "c256de65476204095ebdc01bd11dc10e57b36bc96284595b8215222374f99c0e",
+			bindingNonce:      "243d71944d929063bc51205714ae3c2218bd3451d0214dfb5aeec2a90c35180d",
+			hidingCommitment:  "cfbdb165bd8aad6eb79deb8d287bcc0ab6658ae57fdcc98ed12c0669e90aec91",
+			bindingCommitment: "7487bc41a6e712eea2f2af24681b58b1cf1da278ea11fe4e8b78398965f13552",
+			bindingFactor:     "b087686bf35a13f3dc78e780a34b0fe8a77fef1b9938c563f5573d71d8d7890f",
+			sigShare:          "bd86125de990acc5e1f13781d8e32c03a9bbd4c53539bbc106058bfd14326007",
+		},
+	},
+}
+
+// rfc9591Keys returns the secret shares of rfc9591Vectors, and the corresponding eddsa.Public.
+func rfc9591Keys(t *testing.T) (map[party.ID]*eddsa.SecretShare, *eddsa.Public) {
+	secretShares := make(map[party.ID]*eddsa.SecretShare, len(rfc9591Vectors.shares))
+	publicShares := make(map[party.ID]*ristretto.Element, len(rfc9591Vectors.shares))
+	for id, share := range rfc9591Vectors.shares {
+		var s ristretto.Scalar
+		_, err := s.SetCanonicalBytes(decodeHex(t, share))
+		require.NoError(t, err)
+		secretShares[id] = eddsa.NewSecretShare(id, &s)
+		publicShares[id] = &secretShares[id].Public
+	}
+	public, err := eddsa.NewPublic(publicShares, 1)
+	require.NoError(t, err)
+	require.Equal(t, rfc9591Vectors.groupPublicKey, hex.EncodeToString(public.GroupKey.ToEd25519()))
+	return secretShares, public
+}
+
+// TestRFC9591Vectors runs the test vectors of RFC 9591, Appendix E.1, FROST(Ed25519, SHA-512).
+func TestRFC9591Vectors(t *testing.T) {
+	message := decodeHex(t, rfc9591Vectors.message)
+	signature := rfc9591Vectors.signature
+	signers := rfc9591Vectors.signers
+	secretShares, public := rfc9591Keys(t)
+
+	signIDs := party.NewIDSlice([]party.ID{1, 3})
+	rounds := make(map[party.ID]*round0, len(signIDs))
+	for _, id := range signIDs {
+		random := append(decodeHex(t, signers[id].hidingRandomness), decodeHex(t, signers[id].bindingRandomness)...)
+		r, _, err := NewRound(signIDs, secretShares[id], public, message,
+			WithCiphersuite(CiphersuiteRFC9591), WithRandom(bytes.NewReader(random)))
+		require.NoError(t, err)
+		rounds[id] = r.(*round0)
+	}
+
+	// deliver sends every message to all parties other than the sender.
+	deliver := func(msgs []*messages.Message, process func(id party.ID, msg *messages.Message)) {
+		for _, msg := range msgs {
+			for _, id := range signIDs {
+				if id != msg.From {
+					process(id, msg)
+				}
+			}
+		}
+	}
+
+	var msgs1 []*messages.Message
+	for _, id := range signIDs {
+		out, stateErr := rounds[id].GenerateMessages()
+		require.Nil(t, stateErr)
+		msgs1 = append(msgs1, out...)
+
+		assert.Equal(t, signers[id].hidingNonce, hex.EncodeToString(rounds[id].d.Bytes()), "hiding nonce %d", id)
+		assert.Equal(t, signers[id].bindingNonce, hex.EncodeToString(rounds[id].e.Bytes()), "binding nonce %d", id)
+	}
+	deliver(msgs1, func(id party.ID, msg *messages.Message) {
+		require.Nil(t, (&round1{rounds[id]}).ProcessMessage(msg))
+	})
+
+	var msgs2 []*messages.Message
+	for _, id := range signIDs {
+		out, stateErr := (&round1{rounds[id]}).GenerateMessages()
+		require.Nil(t, stateErr)
+		msgs2 = append(msgs2, out...)
+
+		for _, otherID := range signIDs {
+			p := rounds[id].Parties[otherID]
+			assert.Equal(t, signers[otherID].hidingCommitment, hex.EncodeToString(p.Di.BytesEd25519()), "hiding commitment %d", otherID)
+			assert.Equal(t, signers[otherID].bindingCommitment, hex.EncodeToString(p.Ei.BytesEd25519()), "binding commitment %d", otherID)
+			assert.Equal(t, signers[otherID].bindingFactor, hex.EncodeToString(p.Pi.Bytes()), "binding factor %d", otherID)
+		}
+		assert.Equal(t, signers[id].sigShare, hex.EncodeToString(rounds[id].Parties[id].Zi.Bytes()), "signature share %d", id)
+
+		factors, err := rounds[id].Output.BindingFactors()
+		require.NoError(t, err)
+		require.Len(t, factors, len(signIDs))
+		for _, otherID := range signIDs {
+			assert.Equal(t, signers[otherID].bindingFactor, hex.EncodeToString(factors[otherID].Bytes()), "binding factor %d", otherID)
+		}
+	}
+	deliver(msgs2, func(id party.ID, msg *messages.Message) {
+		require.Nil(t, (&round2{round1: &round1{rounds[id]}}).ProcessMessage(msg))
+	})
+
+	for _, id := range signIDs {
+		_, stateErr := (&round2{round1: &round1{rounds[id]}}).GenerateMessages()
+		require.Nil(t, stateErr)
+		sig := rounds[id].Output.Signature.ToEd25519()
+		assert.Equal(t, signature, hex.EncodeToString(sig))
+		assert.True(t, ed25519.Verify(public.GroupKey.ToEd25519(), message, sig))
+	}
+}
+
+func TestNewRound_Ciphersuite(t *testing.T) {
+	var s ristretto.Scalar
+	s.SetUint64(42)
+	secret := eddsa.NewSecretShare(1, &s)
+	public, err := eddsa.NewPublic(map[party.ID]*ristretto.Element{1: &secret.Public}, 0)
+	require.NoError(t, err)
+	partyIDs := party.NewIDSlice([]party.ID{1})
+
+	r, _, err := NewRound(partyIDs, secret, public, nil)
+	require.NoError(t, err)
+	assert.Equal(t, CiphersuiteTranscript, r.(*round0).Ciphersuite)
+
+	_, _, err = NewRound(partyIDs, secret, public, nil, WithCiphersuite(Ciphersuite(42)))
+	assert.Error(t, err)
+
+	// CiphersuiteLegacy encodes the IDs with 2 bytes
+	secret = eddsa.NewSecretShare(65536, &s)
+	public, err = eddsa.NewPublic(map[party.ID]*ristretto.Element{65536: &secret.Public}, 0)
+	require.NoError(t, err)
+	partyIDs = party.NewIDSlice([]party.ID{65536})
+	_, _, err = NewRound(partyIDs, secret, public, nil, WithCiphersuite(CiphersuiteLegacy))
+	assert.Error(t, err)
+	_, _, err = NewRound(partyIDs, secret, public, nil)
+	assert.NoError(t, err)
+}
+
+// TestLegacyBindingFactors checks the binding factors of CiphersuiteLegacy against those computed by
+// the releases using messages.WireVersion 1, for Dⱼ = [2j]B and Eⱼ = [2j+1]B.
+func TestLegacyBindingFactors(t *testing.T) {
+	partyIDs := party.NewIDSlice([]party.ID{1, 3, 300})
+	expected := map[party.ID]string{
+		1:   "51a795576e6e8c7cb6ec229fc910751d6e95d073091c76d8e8dc26ee695faa01",
+		3:   "0fb5ec02f27b90ff13347fd8ed5e58fda7edaf9bf5c50de952a20a93c2abf301",
+		300: "708acc0463416a0a32c430ca06fb508bc751681e6055734ecca7c12b13403b09",
+	}
+
+	baseRound, err := state.NewBaseRound(1, partyIDs)
+	require.NoError(t, err)
+	round := &round1{&round0{
+		BaseRound: baseRound,
+		Message:   []byte("legacy binding factors"),
+		Parties:   make(map[party.ID]*signer, len(partyIDs)),
+	}}
+	for _, id := range partyIDs {
+		var p signer
+		p.Di.ScalarBaseMult((2 * id).Scalar())
+		p.Ei.ScalarBaseMult((2*id + 1).Scalar())
+		round.Parties[id] = &p
+	}
+	round.legacyBindingFactors()
+
+	for _, id := range partyIDs {
+		assert.Equal(t, expected[id], hex.EncodeToString(round.Parties[id].Pi.Bytes()), "party %d", id)
+	}
+}
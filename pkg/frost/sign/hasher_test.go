@@ -0,0 +1,78 @@
+package sign
+
+import (
+	"crypto/sha256"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+// mockHasher is a eddsa.Hasher based on SHA-256, which records the labels it is called with.
+type mockHasher struct {
+	mtx    sync.Mutex
+	labels map[string]int
+}
+
+func (h *mockHasher) Hash(label string, m ...[]byte) []byte {
+	h.mtx.Lock()
+	h.labels[label]++
+	h.mtx.Unlock()
+
+	d := sha256.New()
+	_, _ = d.Write([]byte(label))
+	for _, b := range m {
+		_, _ = d.Write(b)
+	}
+	// Expand the digest to 64 bytes, as required by ristretto.Scalar.SetUniformBytes
+	first := d.Sum(nil)
+	second := sha256.Sum256(first)
+	return append(first, second[:]...)
+}
+
+func (h *mockHasher) HashToScalar(s *ristretto.Scalar, label string, m ...[]byte) *ristretto.Scalar {
+	_, _ = s.SetUniformBytes(h.Hash(label, m...))
+	return s
+}
+
+func TestWithHasher(t *testing.T) {
+	message := []byte("message")
+	for _, c := range []Ciphersuite{CiphersuiteLegacy, CiphersuiteRFC9591, CiphersuiteTranscript} {
+		for _, hedged := range []bool{false, true} {
+			h := &mockHasher{labels: map[string]int{}}
+			opts := []Option{WithCiphersuite(c), WithHasher(h)}
+			if hedged {
+				opts = append(opts, WithHedgedNonces())
+			}
+			public, sig := runSign(t, 4, 2, message, opts...)
+
+			// The challenge has no label in plain Ed25519
+			assert.NotZero(t, h.labels[""], "%s: challenge", c)
+			switch c {
+			case CiphersuiteLegacy, CiphersuiteTranscript:
+				assert.NotZero(t, h.labels[hashDomainSeparation], "binding factors")
+			case CiphersuiteRFC9591:
+				assert.NotZero(t, h.labels[rfc9591ContextString+"rho"], "binding factors")
+				assert.NotZero(t, h.labels[rfc9591ContextString+"msg"])
+				assert.NotZero(t, h.labels[rfc9591ContextString+"com"])
+				if !hedged {
+					assert.NotZero(t, h.labels[rfc9591ContextString+"nonce"], "nonces")
+				}
+			}
+			if hedged {
+				assert.NotZero(t, h.labels[hedgedNonceDomainSeparation+"d"], "%s: nonces", c)
+				assert.NotZero(t, h.labels[hedgedNonceDomainSeparation+"e"], "%s: nonces", c)
+			}
+
+			// The signature is only valid with the same Hasher
+			assert.NoError(t, public.GroupKey.VerifyWithOptions(message, sig, &eddsa.Options{Hasher: h}))
+			assert.False(t, public.GroupKey.Verify(message, sig))
+		}
+	}
+
+	// The default Hasher gives a valid Ed25519 signature
+	public, sig := runSign(t, 3, 1, message, WithHasher(eddsa.SHA512))
+	assert.True(t, public.GroupKey.Verify(message, sig))
+}
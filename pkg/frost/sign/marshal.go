@@ -0,0 +1,132 @@
+package sign
+
+import (
+	"crypto/sha512"
+	"errors"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+// sessionDomainSeparation is used to derive the SessionID of a signing session.
+var sessionDomainSeparation = []byte("FROST-SIGN-SESSION")
+
+// SessionID implements state.MarshalableRound.
+// It is a hash of the protocol and all parameters given to NewRound, except for the SecretShare:
+//
+//	SHA-512/256("FROST-SIGN-SESSION" ∥ Ciphersuite ∥ Hash ∥ len(Context) ∥ Context ∥ SelfID ∥ PartyIDs ∥ GroupKey ∥ Tweak ∥ SHA-512(Message))
+//
+// where GroupKey is the key the session signs for, and Tweak is 0x00 without WithTweak, or 0x01 ∥ t given the tweak t.
+// The message is preceded by the associated data given with WithAssociatedData, if any, as in eddsa.MessageWithAssociatedData.
+// If an identifier was given with WithSessionID, its SHA-512 digest is appended after that of the message.
+func (round *round0) SessionID() []byte {
+	messageHash := sha512.Sum512(round.signedMessage())
+
+	data := make([]byte, 0, len(sessionDomainSeparation)+3+len(round.Options.Context)+
+		int(round.PartyIDs().N()+1)*party.IDByteSize+32+1+32+len(messageHash))
+	data = append(data, sessionDomainSeparation...)
+	data = append(data, byte(round.Ciphersuite), byte(round.Options.Hash), byte(len(round.Options.Context)))
+	data = append(data, round.Options.Context...)
+	data = append(data, round.SelfID().Bytes()...)
+	for _, id := range round.PartyIDs() {
+		data = append(data, id.Bytes()...)
+	}
+	data = append(data, round.GroupKey.ToEd25519()...)
+	if round.tweak == nil {
+		data = append(data, 0)
+	} else {
+		data = append(data, 1)
+		data = append(data, round.tweak.Bytes()...)
+	}
+	data = append(data, messageHash[:]...)
+	if len(round.session) > 0 {
+		sessionHash := sha512.Sum512(round.session)
+		data = append(data, sessionHash[:]...)
+	}
+
+	digest := sha512.Sum512_256(data)
+	return digest[:]
+}
+
+// signerSize is the size of the encoding of a signer: Di ∥ Ei ∥ Ri ∥ Pi ∥ Zi
+const signerSize = 5 * 32
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface, and is used by state.State.MarshalBinary.
+// The output contains the party's secret nonces d and e, followed by C, R and the data of each signer.
+func (round *round0) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 4*32+len(round.PartyIDs())*signerSize)
+	data = append(data, round.d.Bytes()...)
+	data = append(data, round.e.Bytes()...)
+	data = append(data, round.C.Bytes()...)
+	data = append(data, round.R.Bytes()...)
+	for _, id := range round.PartyIDs() {
+		p := round.Parties[id]
+		data = append(data, p.Di.Bytes()...)
+		data = append(data, p.Ei.Bytes()...)
+		data = append(data, p.Ri.Bytes()...)
+		data = append(data, p.Pi.Bytes()...)
+		data = append(data, p.Zi.Bytes()...)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface, and is used by state.State.UnmarshalBinary.
+// The round must have been created by NewRound with the same parameters. It is unchanged if an error is returned.
+func (round *round0) UnmarshalBinary(data []byte) error {
+	if len(data) != 4*32+len(round.PartyIDs())*signerSize {
+		return errors.New("sign: serialized round has the wrong size")
+	}
+
+	var (
+		d, e, C ristretto.Scalar
+		R       ristretto.Element
+	)
+	signers := make(map[party.ID]*signer, len(round.PartyIDs()))
+
+	for _, s := range []*ristretto.Scalar{&d, &e, &C} {
+		if _, err := s.SetCanonicalBytesConstantTime(data[:32]); err != nil {
+			return err
+		}
+		data = data[32:]
+	}
+	if _, err := R.SetCanonicalBytes(data[:32]); err != nil {
+		return err
+	}
+	data = data[32:]
+
+	for _, id := range round.PartyIDs() {
+		var p signer
+		for _, el := range []*ristretto.Element{&p.Di, &p.Ei, &p.Ri} {
+			if _, err := el.SetCanonicalBytes(data[:32]); err != nil {
+				return err
+			}
+			data = data[32:]
+		}
+		for _, s := range []*ristretto.Scalar{&p.Pi, &p.Zi} {
+			if _, err := s.SetCanonicalBytes(data[:32]); err != nil {
+				return err
+			}
+			data = data[32:]
+		}
+		signers[id] = &p
+	}
+
+	round.d.Set(&d)
+	round.e.Set(&e)
+	round.C.Set(&C)
+	round.R.Set(&R)
+	for id, p := range signers {
+		s := round.Parties[id]
+		s.Di.Set(&p.Di)
+		s.Ei.Set(&p.Ei)
+		s.Ri.Set(&p.Ri)
+		s.Pi.Set(&p.Pi)
+		s.Zi.Set(&p.Zi)
+	}
+	// R is only set once all commitments were received
+	if R.IsIdentity() == 0 {
+		round.Output.setGroupCommitment(&R)
+		round.Output.setBindingFactors(round.Parties)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package sign
+
+import (
+	"crypto/ed25519"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/helpers"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+func TestState_MarshalBinary(t *testing.T) {
+	message := []byte("survive a restart")
+	partyIDs := helpers.GenerateSet(4)
+	_, secretShares := helpers.GenerateSecrets(partyIDs, 2)
+	public := helpers.GeneratePublic(2, secretShares)
+	signIDs := partyIDs[:3]
+
+	newState := func(i int, message []byte, opts ...Option) (*state.State, *Output) {
+		r, output, err := NewRound(signIDs, secretShares[signIDs[i]], public, message, opts...)
+		require.NoError(t, err)
+		s, err := state.NewBaseState(r, 0)
+		require.NoError(t, err)
+		return s, output
+	}
+
+	states := make([]*state.State, len(signIDs))
+	outputs := make([]*Output, len(signIDs))
+	for i := range signIDs {
+		states[i], outputs[i] = newState(i, message)
+	}
+
+	var msgs1 []*messages.Message
+	for _, s := range states {
+		msgs1 = append(msgs1, s.ProcessAll()...)
+	}
+
+	// The first signer receives one commitment before being restarted
+	require.NoError(t, states[0].HandleMessage(msgs1[1]))
+
+	data, err := states[0].MarshalBinary()
+	require.NoError(t, err)
+
+	// Restoring into a different session fails
+	other, _ := newState(0, []byte("another message"))
+	assert.ErrorIs(t, other.UnmarshalBinary(data), state.ErrStateSessionID)
+	other, _ = newState(1, message)
+	assert.ErrorIs(t, other.UnmarshalBinary(data), state.ErrStateSessionID)
+	for _, tweak := range []*ristretto.Scalar{ristretto.NewScalar(), ristretto.NewScalar().SetUint64(1)} {
+		other, _ = newState(0, message, WithTweak(tweak))
+		assert.ErrorIs(t, other.UnmarshalBinary(data), state.ErrStateSessionID, "tweak %x", tweak.Bytes())
+	}
+
+	// Restoring a different version fails
+	other, _ = newState(0, message)
+	wrongVersion := append([]byte{}, data...)
+	wrongVersion[0]++
+	assert.ErrorIs(t, other.UnmarshalBinary(wrongVersion), state.ErrStateVersion)
+	// Version 1 encoded the party IDs on 2 bytes
+	wrongVersion[0] = 1
+	assert.ErrorIs(t, other.UnmarshalBinary(wrongVersion), state.ErrStateVersion)
+
+	// A message count larger than the remaining input is rejected before allocating
+	offset := 1
+	for i := 0; i < 3; i++ {
+		// session ID, round number and round data
+		n := 4
+		if i != 1 {
+			n += int(binary.BigEndian.Uint32(data[offset:]))
+		}
+		offset += n
+	}
+	hugeCount := append([]byte{}, data[:offset]...)
+	hugeCount = append(hugeCount, 0xff, 0xff, 0xff, 0xff)
+	other, _ = newState(0, message)
+	assert.ErrorIs(t, other.UnmarshalBinary(hugeCount), messages.ErrInvalidMessage)
+	require.Equal(t, uint32(1), binary.BigEndian.Uint32(data[offset:]), "one message was received")
+	wrongCount := append([]byte{}, data...)
+	binary.BigEndian.PutUint32(wrongCount[offset:], binary.BigEndian.Uint32(data[offset:])+1)
+	other, _ = newState(0, message)
+	assert.Error(t, other.UnmarshalBinary(wrongCount))
+
+	// Restore into a fresh state, and continue from there
+	states[0], outputs[0] = newState(0, message)
+	require.NoError(t, states[0].UnmarshalBinary(data))
+
+	var msgs2 []*messages.Message
+	for i, s := range states {
+		for j, msg := range msgs1 {
+			if i == 0 && j == 1 {
+				// already received before the restart
+				continue
+			}
+			require.NoError(t, s.HandleMessage(msg))
+		}
+		msgs2 = append(msgs2, s.ProcessAll()...)
+	}
+
+	// The second signer is restarted once the binding factors are known
+	factors, err := outputs[1].BindingFactors()
+	require.NoError(t, err)
+	data, err = states[1].MarshalBinary()
+	require.NoError(t, err)
+	states[1], outputs[1] = newState(1, message)
+	require.NoError(t, states[1].UnmarshalBinary(data))
+	restored, err := outputs[1].BindingFactors()
+	require.NoError(t, err)
+	require.Len(t, restored, len(factors))
+	for id, rho := range factors {
+		assert.Equal(t, 1, restored[id].Equal(rho))
+	}
+
+	for _, s := range states {
+		for _, msg := range msgs2 {
+			require.NoError(t, s.HandleMessage(msg))
+		}
+		s.ProcessAll()
+	}
+
+	for i, s := range states {
+		require.NoError(t, s.WaitForError())
+		assert.True(t, ed25519.Verify(public.GroupKey.ToEd25519(), message, outputs[i].Signature.ToEd25519()))
+	}
+}
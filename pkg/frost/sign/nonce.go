@@ -0,0 +1,34 @@
+package sign
+
+import (
+	"fmt"
+	"io"
+)
+
+// hedgedNonceDomainSeparation is used when deriving hedged nonces.
+const hedgedNonceDomainSeparation = "FROST-SIGN-HEDGED-NONCE"
+
+// hedgedNonces sets the nonces dᵢ and eᵢ of the party to
+//
+//	dᵢ = H("FROST-SIGN-HEDGED-NONCE" ∥ "d" ∥ Z ∥ sᵢ ∥ SessionID) mod l
+//	eᵢ = H("FROST-SIGN-HEDGED-NONCE" ∥ "e" ∥ Z ∥ sᵢ ∥ SessionID) mod l
+//
+// where H is the eddsa.Hasher of the session, Z are 32 fresh random bytes, sᵢ is the party's secret share,
+// and SessionID commits to the message and all other parameters of the session.
+//
+// As in RFC 8032, the nonces are a deterministic function of the secret and the message,
+// so a broken source of randomness cannot produce the same nonces for two different messages.
+// Z still provides the fresh randomness required by FROST when the same message is signed in concurrent sessions.
+func (round *round0) hedgedNonces() error {
+	var z [32]byte
+	if _, err := io.ReadFull(round.random, z[:]); err != nil {
+		return fmt.Errorf("sign: failed to read random bytes: %w", err)
+	}
+	sessionID := round.SessionID()
+	secret := round.secret.Bytes()
+
+	h := round.hasher()
+	h.HashToScalar(&round.d, hedgedNonceDomainSeparation+"d", z[:], secret, sessionID)
+	h.HashToScalar(&round.e, hedgedNonceDomainSeparation+"e", z[:], secret, sessionID)
+	return nil
+}
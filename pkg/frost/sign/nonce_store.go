@@ -0,0 +1,54 @@
+package sign
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+// ErrNonceReuse is returned when a nonce commitment was already used in a previous signing session.
+var ErrNonceReuse = errors.New("nonce commitment was already used")
+
+// A UsedNonceStore records the nonce commitments (Dⱼ, Eⱼ) seen by a signer, so that a commitment
+// replayed from a previous session can be detected.
+// A store belongs to a single signer, and must not be shared between parties.
+type UsedNonceStore interface {
+	// MarkUsed records commitment as used.
+	// It must return ErrNonceReuse if commitment was already recorded,
+	// and be safe to call concurrently from multiple sessions.
+	MarkUsed(commitment []byte) error
+}
+
+// MemoryNonceStore is a UsedNonceStore which keeps all commitments in memory.
+type MemoryNonceStore struct {
+	mtx  sync.Mutex
+	used map[string]struct{}
+}
+
+// NewMemoryNonceStore returns an empty MemoryNonceStore.
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{used: make(map[string]struct{})}
+}
+
+// MarkUsed implements UsedNonceStore.
+func (s *MemoryNonceStore) MarkUsed(commitment []byte) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.used[string(commitment)]; ok {
+		return ErrNonceReuse
+	}
+	s.used[string(commitment)] = struct{}{}
+	return nil
+}
+
+// markUsed records the commitment Dⱼ ∥ Eⱼ in the UsedNonceStore of the session, if any.
+func (round *round0) markUsed(D, E *ristretto.Element) error {
+	if round.nonceStore == nil {
+		return nil
+	}
+	commitment := make([]byte, 0, 64)
+	commitment = append(commitment, D.Bytes()...)
+	commitment = append(commitment, E.Bytes()...)
+	return round.nonceStore.MarkUsed(commitment)
+}
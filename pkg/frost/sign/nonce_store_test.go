@@ -0,0 +1,85 @@
+package sign
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/helpers"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+func TestMemoryNonceStore(t *testing.T) {
+	s := NewMemoryNonceStore()
+	assert.NoError(t, s.MarkUsed([]byte("commitment 1")))
+	assert.NoError(t, s.MarkUsed([]byte("commitment 2")))
+	assert.ErrorIs(t, s.MarkUsed([]byte("commitment 1")), ErrNonceReuse)
+}
+
+func TestWithUsedNonceStore_Replay(t *testing.T) {
+	partyIDs := helpers.GenerateSet(3)
+	_, secretShares := helpers.GenerateSecrets(partyIDs, 2)
+	public := helpers.GeneratePublic(2, secretShares)
+
+	stores := make(map[party.ID]UsedNonceStore, len(partyIDs))
+	for _, id := range partyIDs {
+		stores[id] = NewMemoryNonceStore()
+	}
+
+	// session runs a signing session over message, and replaces the commitments sent in the first round with replay.
+	// It returns the commitments sent in the first round, and the states of all parties.
+	session := func(message []byte, replay []*messages.Message) ([]*messages.Message, map[party.ID]*state.State) {
+		states := make(map[party.ID]*state.State, len(partyIDs))
+		for _, id := range partyIDs {
+			r, _, err := NewRound(partyIDs, secretShares[id], public, message, WithUsedNonceStore(stores[id]))
+			require.NoError(t, err)
+			states[id], err = state.NewBaseState(r, 0)
+			require.NoError(t, err)
+		}
+
+		var msgs, commitments []*messages.Message
+		for round := 0; round < 3; round++ {
+			var next []*messages.Message
+			for _, s := range states {
+				for _, msg := range msgs {
+					_ = s.HandleMessage(msg)
+				}
+				next = append(next, s.ProcessAll()...)
+			}
+			if round == 0 {
+				commitments = next
+				if replay != nil {
+					next = replay
+				}
+			}
+			msgs = next
+		}
+		return commitments, states
+	}
+
+	commitments, states := session([]byte("first message"), nil)
+	for _, s := range states {
+		require.NoError(t, s.WaitForError())
+	}
+
+	// A fresh session succeeds with the same stores
+	_, states = session([]byte("second message"), nil)
+	for _, s := range states {
+		require.NoError(t, s.WaitForError())
+	}
+
+	// Replaying the commitments of the first session aborts
+	_, states = session([]byte("third message"), commitments)
+	for id, s := range states {
+		err := s.WaitForError()
+		require.Error(t, err, "party %d", id)
+		assert.ErrorIs(t, err, ErrNonceReuse)
+
+		var stateErr *state.Error
+		require.ErrorAs(t, err, &stateErr)
+		assert.NotEqual(t, id, stateErr.PartyID)
+		assert.NotEqual(t, party.ID(0), stateErr.PartyID)
+	}
+}
@@ -0,0 +1,107 @@
+package sign
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/helpers"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+func TestWithHedgedNonces(t *testing.T) {
+	partyIDs := helpers.GenerateSet(3)
+	_, secretShares := helpers.GenerateSecrets(partyIDs, 1)
+	public := helpers.GeneratePublic(1, secretShares)
+
+	// nonces returns the nonces of party 1, using entropy as the only source of randomness.
+	nonces := func(message, entropy []byte) (d, e []byte) {
+		r, _, err := NewRound(partyIDs, secretShares[1], public, message, WithHedgedNonces(), WithRandom(bytes.NewReader(entropy)))
+		require.NoError(t, err)
+		round := r.(*round0)
+		_, stateErr := round.GenerateMessages()
+		require.Nil(t, stateErr)
+		return round.d.Bytes(), round.e.Bytes()
+	}
+
+	broken := make([]byte, 32)
+	d1, e1 := nonces([]byte("message 1"), broken)
+	d2, e2 := nonces([]byte("message 1"), broken)
+	assert.Equal(t, d1, d2, "the same inputs and entropy should give the same nonces")
+	assert.Equal(t, e1, e2, "the same inputs and entropy should give the same nonces")
+	assert.NotEqual(t, d1, e1)
+
+	d3, e3 := nonces([]byte("message 2"), broken)
+	assert.NotEqual(t, d1, d3, "different messages should give different nonces")
+	assert.NotEqual(t, e1, e3, "different messages should give different nonces")
+
+	fresh := bytes.Repeat([]byte{1}, 32)
+	d4, e4 := nonces([]byte("message 1"), fresh)
+	assert.NotEqual(t, d1, d4, "different entropy should give different nonces")
+	assert.NotEqual(t, e1, e4, "different entropy should give different nonces")
+
+	// A failing source of randomness aborts rather than falling back to deterministic nonces
+	_, _, err := NewRound(partyIDs, secretShares[1], public, []byte("message 1"), WithHedgedNonces(), WithRandom(bytes.NewReader(nil)))
+	assert.ErrorIs(t, err, io.EOF)
+
+	// The resulting signatures are valid, for both ciphersuites
+	for _, c := range []Ciphersuite{CiphersuiteLegacy, CiphersuiteRFC9591, CiphersuiteTranscript} {
+		message := []byte("hedged")
+		public, sig := runSign(t, 5, 2, message, WithHedgedNonces(), WithCiphersuite(c))
+		assert.True(t, ed25519.Verify(public.GroupKey.ToEd25519(), message, sig.ToEd25519()), c.String())
+	}
+}
+
+func TestWithRandom(t *testing.T) {
+	partyIDs := helpers.GenerateSet(3)
+	_, secretShares := helpers.GenerateSecrets(partyIDs, 1)
+	public := helpers.GeneratePublic(1, secretShares)
+
+	// commitments returns the first message of party 1, sampling its nonces from r.
+	commitments := func(r io.Reader) *messages.Message {
+		round, _, err := NewRound(partyIDs, secretShares[1], public, []byte("message"), WithRandom(r))
+		require.NoError(t, err)
+		s, err := state.NewBaseState(round, 0)
+		require.NoError(t, err)
+		msgs := s.ProcessAll()
+		require.Len(t, msgs, 1)
+		return msgs[0]
+	}
+
+	entropy := make([]byte, 128)
+	for i := range entropy {
+		entropy[i] = byte(i)
+	}
+	msg1 := commitments(bytes.NewReader(entropy))
+	msg2 := commitments(bytes.NewReader(entropy))
+	assert.Equal(t, 1, msg1.Sign1.Di.Equal(&msg2.Sign1.Di), "the same randomness should give the same nonces")
+	assert.Equal(t, 1, msg1.Sign1.Ei.Equal(&msg2.Sign1.Ei), "the same randomness should give the same nonces")
+	assert.Equal(t, 0, msg1.Sign1.Di.Equal(&msg1.Sign1.Ei))
+
+	d, err := ristretto.RandomScalar(bytes.NewReader(entropy[:64]))
+	require.NoError(t, err)
+	var D ristretto.Element
+	assert.Equal(t, 1, D.ScalarBaseMult(d).Equal(&msg1.Sign1.Di), "the nonces are read from the reader")
+
+	// A failing reader is reported by NewRound, before the session starts
+	for _, c := range []Ciphersuite{CiphersuiteLegacy, CiphersuiteRFC9591, CiphersuiteTranscript} {
+		round, output, err := NewRound(partyIDs, secretShares[1], public, []byte("message"),
+			WithRandom(bytes.NewReader(entropy[:40])), WithCiphersuite(c))
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF, c.String())
+		assert.Nil(t, round)
+		assert.Nil(t, output)
+	}
+
+	// The signatures are valid, for both ciphersuites
+	for _, c := range []Ciphersuite{CiphersuiteLegacy, CiphersuiteRFC9591, CiphersuiteTranscript} {
+		message := []byte("random")
+		public, sig := runSign(t, 5, 2, message, WithRandom(rand.Reader), WithCiphersuite(c))
+		assert.True(t, ed25519.Verify(public.GroupKey.ToEd25519(), message, sig.ToEd25519()), c.String())
+	}
+}
@@ -0,0 +1,122 @@
+package sign
+
+import (
+	"crypto"
+	"io"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+// An Option modifies the parameters of a signing session.
+// Unless stated otherwise, all signers of a session must use the same options.
+// The exceptions are WithRandom, WithHedgedNonces, WithUsedNonceStore and WithQuorumContext,
+// which only change how a signer computes its own values: other signers can not tell whether they were used.
+type Option func(*round0)
+
+// WithCiphersuite sets the Ciphersuite used by the session.
+func WithCiphersuite(c Ciphersuite) Option {
+	return func(round *round0) {
+		round.Ciphersuite = c
+	}
+}
+
+// WithPrehash selects Ed25519ph, in which case the message given to the session
+// must be the 64 byte SHA-512 digest of the message to be signed.
+// The resulting signature verifies with ed25519.VerifyWithOptions and crypto.SHA512.
+func WithPrehash() Option {
+	return func(round *round0) {
+		round.Options.Hash = crypto.SHA512
+	}
+}
+
+// WithContext binds the signature to context, which must be at most eddsa.MaxContextLength bytes long.
+// Without WithPrehash, a non-empty context selects Ed25519ctx and the signature verifies
+// with ed25519.VerifyWithOptions given the same Options.Context.
+// The empty context is equivalent to not using this option.
+func WithContext(context string) Option {
+	return func(round *round0) {
+		round.Options.Context = context
+	}
+}
+
+// WithAssociatedData makes the signature cover ad along with the message, as eddsa.Options.AssociatedData.
+// All signers must use the same ad, and the signature verifies with ed25519.Verify given
+// eddsa.MessageWithAssociatedData(message, ad). The binding factors are derived from that encoding too.
+// The empty ad is equivalent to not using this option.
+func WithAssociatedData(ad []byte) Option {
+	return func(round *round0) {
+		round.Options.AssociatedData = append([]byte(nil), ad...)
+	}
+}
+
+// WithRandom sets the source of randomness from which the nonces of the signer are sampled, such as a validated DRBG.
+// It defaults to crypto/rand.Reader. The nonces are sampled by NewRound, which returns the error if reading from r fails.
+// With WithHedgedNonces, r only provides the fresh randomness mixed into the derivation.
+//
+// Reusing the output of r across sessions reveals the secret share, so a deterministic reader must only be used in tests.
+func WithRandom(r io.Reader) Option {
+	return func(round *round0) {
+		round.random = r
+	}
+}
+
+// WithHedgedNonces derives the nonces of the signer from its secret share, the message and
+// the parameters of the session, in addition to fresh randomness.
+// A faulty random number generator can then not cause the same nonces to be used for two different messages.
+func WithHedgedNonces() Option {
+	return func(round *round0) {
+		round.hedged = true
+	}
+}
+
+// WithUsedNonceStore makes the signer record all nonce commitments it creates or accepts in store,
+// and abort with ErrNonceReuse when a commitment was already used in a previous session.
+func WithUsedNonceStore(store UsedNonceStore) Option {
+	return func(round *round0) {
+		round.nonceStore = store
+	}
+}
+
+// WithSessionID binds the binding factors of all signers to sessionID, which identifies the session to the signers.
+// Commitments replayed from a session with a different identifier then result in different binding factors,
+// so that the signature shares computed in the other session are rejected.
+// All signers must use the same sessionID, and should choose a unique one for each session, such as a random nonce
+// agreed upon beforehand. The empty identifier is equivalent to not using this option.
+//
+// The challenge is that of Ed25519 and does not depend on sessionID directly, but only through the group commitment R,
+// so that the signature still verifies as a regular Ed25519 signature.
+func WithSessionID(sessionID []byte) Option {
+	return func(round *round0) {
+		round.session = append([]byte(nil), sessionID...)
+	}
+}
+
+// WithQuorumContext makes the session use the Lagrange coefficients precomputed in q, when the same quorum signs many messages.
+// If the quorum of the session, or the public shares, differ from those q was computed for, q is ignored
+// and the coefficients are recomputed for the session.
+func WithQuorumContext(q *QuorumContext) Option {
+	return func(round *round0) {
+		round.quorum = q
+	}
+}
+
+// WithHasher sets the eddsa.Hasher used to derive the binding factors, the nonces and the challenge.
+// All signers must use the same Hasher, and the signature only verifies with eddsa.PublicKey.VerifyWithOptions
+// given Options.Hasher set to h. It is not a valid Ed25519 signature unless h is eddsa.SHA512, the default.
+func WithHasher(h eddsa.Hasher) Option {
+	return func(round *round0) {
+		round.Options.Hasher = h
+	}
+}
+
+// WithTweak makes the session produce a signature for the tweaked group key GroupKey.Tweak(t), such as
+// a key derived with eddsa.DeriveTweak, instead of the group key of the shares.
+// Every signer i adds [λᵢ • c • t] to its signature share, so that the shares sum to the signature for the secret s + t.
+// The signature shares are verified against the public shares Yᵢ + [t]B.
+// All signers must use the same t, and the tweak being public, it must not be used to hide the group key.
+func WithTweak(t *ristretto.Scalar) Option {
+	return func(round *round0) {
+		round.tweak = new(ristretto.Scalar).Set(t)
+	}
+}
@@ -1,7 +1,87 @@
 package sign
 
-import "github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+import (
+	"errors"
+	"sync"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+var (
+	// ErrNoGroupCommitment is returned by Output.GroupCommitment before the commitments of all signers were processed.
+	ErrNoGroupCommitment = errors.New("sign: the group commitment is not yet known")
+
+	// ErrNoBindingFactors is returned by Output.BindingFactors before the commitments of all signers were processed.
+	ErrNoBindingFactors = errors.New("sign: the binding factors are not yet known")
+)
 
 type Output struct {
 	Signature *eddsa.Signature
+
+	mtx             sync.Mutex
+	groupCommitment *ristretto.Element
+	bindingFactors  map[party.ID]*ristretto.Scalar
+}
+
+// GroupCommitment returns the group commitment R = ∑ Rᵢ of the session, which is used to compute the challenge
+// and is the R of the final signature.
+// It is known once the commitments of all signers were processed, before the signature shares are sent,
+// and ErrNoGroupCommitment is returned until then.
+//
+// Unlike the Signature, it can safely be called while the protocol is running.
+func (o *Output) GroupCommitment() (*ristretto.Element, error) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	if o.groupCommitment == nil {
+		return nil, ErrNoGroupCommitment
+	}
+	return new(ristretto.Element).Set(o.groupCommitment), nil
+}
+
+func (o *Output) setGroupCommitment(R *ristretto.Element) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	o.groupCommitment = new(ristretto.Element).Set(R)
+}
+
+// BindingFactors returns the binding factor ρᵢ of every signer i, as used to compute the group commitment
+// and the signature shares of the session. Comparing them with those of another implementation helps to
+// find the cause of a mismatch, since they depend on the ciphersuite, the message and the commitments of all signers.
+// They are known at the same time as the GroupCommitment, and ErrNoBindingFactors is returned until then.
+//
+// The map and the scalars are copies, which can safely be modified. The binding factors are not secret.
+func (o *Output) BindingFactors() (map[party.ID]*ristretto.Scalar, error) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	if o.bindingFactors == nil {
+		return nil, ErrNoBindingFactors
+	}
+	factors := make(map[party.ID]*ristretto.Scalar, len(o.bindingFactors))
+	for id, rho := range o.bindingFactors {
+		factors[id] = new(ristretto.Scalar).Set(rho)
+	}
+	return factors, nil
+}
+
+func (o *Output) setBindingFactors(parties map[party.ID]*signer) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	o.bindingFactors = make(map[party.ID]*ristretto.Scalar, len(parties))
+	for id, p := range parties {
+		o.bindingFactors[id] = new(ristretto.Scalar).Set(&p.Pi)
+	}
+}
+
+// Finalize returns the signature of a session, given its state s and output, such as those returned by frost.NewSignState.
+// Unlike reading output.Signature, it never returns a partial result: before all signature shares were received
+// and processed, it returns a state.IncompleteError wrapping state.ErrIncomplete, whose Missing parties have not yet
+// sent their share. If the session aborted, its error is returned.
+func Finalize(s *state.State, output *Output) (*eddsa.Signature, error) {
+	if err := s.Result(); err != nil {
+		return nil, err
+	}
+	return output.Signature, nil
 }
@@ -0,0 +1,151 @@
+package sign
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/helpers"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+func TestOutput_GroupCommitment(t *testing.T) {
+	partyIDs := helpers.GenerateSet(3)
+	_, secretShares := helpers.GenerateSecrets(partyIDs, 2)
+	public := helpers.GeneratePublic(2, secretShares)
+	message := []byte("message")
+
+	states := make([]*state.State, 0, len(partyIDs))
+	outputs := make([]*Output, 0, len(partyIDs))
+	for _, id := range partyIDs {
+		r, output, err := NewRound(partyIDs, secretShares[id], public, message)
+		require.NoError(t, err)
+		s, err := state.NewBaseState(r, 0)
+		require.NoError(t, err)
+		states = append(states, s)
+		outputs = append(outputs, output)
+	}
+
+	// deliver runs one round for all parties, and returns the messages they send.
+	deliver := func(msgs []*messages.Message) []*messages.Message {
+		var next []*messages.Message
+		for _, s := range states {
+			for _, msg := range msgs {
+				require.NoError(t, s.HandleMessage(msg))
+			}
+			next = append(next, s.ProcessAll()...)
+		}
+		return next
+	}
+
+	msgs := deliver(nil)
+	for _, output := range outputs {
+		_, err := output.GroupCommitment()
+		assert.ErrorIs(t, err, ErrNoGroupCommitment)
+	}
+
+	// After the commitments are processed, but before the signature shares are received
+	msgs = deliver(msgs)
+	commitments := make([]*ristretto.Element, 0, len(outputs))
+	for _, output := range outputs {
+		R, err := output.GroupCommitment()
+		require.NoError(t, err)
+		assert.Nil(t, output.Signature)
+		commitments = append(commitments, R)
+	}
+
+	deliver(msgs)
+	for i, s := range states {
+		require.NoError(t, s.WaitForError())
+		assert.Equal(t, 1, commitments[i].Equal(&outputs[i].Signature.R))
+		assert.Equal(t, commitments[0].BytesEd25519(), outputs[i].Signature.ToEd25519()[:32])
+	}
+}
+
+func TestOutput_BindingFactors(t *testing.T) {
+	partyIDs := party.IDSlice{1, 2}
+	_, secretShares := helpers.GenerateSecrets(partyIDs, 1)
+	public := helpers.GeneratePublic(1, secretShares)
+	message := []byte("message")
+
+	rounds := make(map[party.ID]*round1, len(partyIDs))
+	var msgs1 []*messages.Message
+	for _, id := range partyIDs {
+		r, _, err := NewRound(partyIDs, secretShares[id], public, message)
+		require.NoError(t, err)
+		rounds[id] = &round1{r.(*round0)}
+		out, stateErr := rounds[id].round0.GenerateMessages()
+		require.Nil(t, stateErr)
+		msgs1 = append(msgs1, out...)
+
+		_, err = rounds[id].Output.BindingFactors()
+		assert.ErrorIs(t, err, ErrNoBindingFactors)
+	}
+	for _, id := range partyIDs {
+		for _, msg := range msgs1 {
+			if msg.From != id {
+				require.Nil(t, rounds[id].ProcessMessage(msg))
+			}
+		}
+		_, stateErr := rounds[id].GenerateMessages()
+		require.Nil(t, stateErr)
+	}
+
+	// entry returns the encoding of a transcript entry: kind ∥ uint32(len(label)) ∥ label ∥ uint64(len(data)) ∥ data
+	entry := func(kind byte, label string, data []byte) []byte {
+		var lengths [12]byte
+		binary.BigEndian.PutUint32(lengths[:4], uint32(len(label)))
+		binary.BigEndian.PutUint64(lengths[4:], uint64(len(data)))
+		out := append([]byte{kind}, lengths[:4]...)
+		out = append(out, label...)
+		out = append(out, lengths[4:]...)
+		return append(out, data...)
+	}
+	messageHash := sha512.Sum512(message)
+	common := append([]byte("FROST-SHA512"), entry('m', "message", messageHash[:])...)
+	for _, msg := range msgs1 {
+		common = append(common, entry('m', "id", msg.From.Bytes())...)
+		common = append(common, entry('m', "D", msg.Sign1.Di.Bytes())...)
+		common = append(common, entry('m', "E", msg.Sign1.Ei.Bytes())...)
+	}
+
+	commonDigest := sha512.Sum512(append(common, entry('c', "binding", nil)...))
+	prefix, err := ristretto.NewScalar().SetUniformBytes(commonDigest[:])
+	require.NoError(t, err)
+
+	R := ristretto.NewIdentityElement()
+	for _, id := range partyIDs {
+		// ρᵢ = H("FROST-SHA512" ∥ P ∥ i)
+		data := append([]byte("FROST-SHA512"), prefix.Bytes()...)
+		digest := sha512.Sum512(append(data, id.Bytes()...))
+		expected, err := ristretto.NewScalar().SetUniformBytes(digest[:])
+		require.NoError(t, err)
+
+		for _, otherID := range partyIDs {
+			factors, err := rounds[otherID].Output.BindingFactors()
+			require.NoError(t, err)
+			assert.Equal(t, 1, factors[id].Equal(expected), "binding factor of %d seen by %d", id, otherID)
+		}
+
+		// Rᵢ = Dᵢ + [ρᵢ]Eᵢ
+		var Ri ristretto.Element
+		Ri.ScalarMult(expected, &msgs1[id-1].Sign1.Ei)
+		R.Add(R, Ri.Add(&Ri, &msgs1[id-1].Sign1.Di))
+	}
+	groupCommitment, err := rounds[1].Output.GroupCommitment()
+	require.NoError(t, err)
+	assert.Equal(t, 1, groupCommitment.Equal(R), "the binding factors are those of the group commitment")
+
+	// The returned values are copies
+	factors, err := rounds[1].Output.BindingFactors()
+	require.NoError(t, err)
+	factors[1].Add(factors[1], ristretto.NewScalar().SetUint64(1))
+	again, err := rounds[1].Output.BindingFactors()
+	require.NoError(t, err)
+	assert.Equal(t, 0, again[1].Equal(factors[1]))
+}
@@ -0,0 +1,50 @@
+package sign
+
+import (
+	"fmt"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+// Commitment contains the nonce commitments (Dᵢ, Eᵢ) sent by a signer in the first round,
+// and its binding factor ρᵢ derived from the commitments of all signers.
+type Commitment = eddsa.Commitment
+
+// VerifyPartialSignature returns true if partial is a valid signature share zᵢ of a signer, that is if
+//
+//	[zᵢ]B = Rᵢ + [λᵢ • c]Yᵢ, where Rᵢ = Dᵢ + [ρᵢ]Eᵢ
+//
+// This check is performed on every share by the signing protocol, but lets a relay reject invalid shares
+// before they are forwarded. It is the same as eddsa.VerifyPartialSignature, which a verifier can use without
+// importing this package.
+func VerifyPartialSignature(partial *ristretto.Scalar, commitment *Commitment, publicShare *ristretto.Element, challenge, lambda *ristretto.Scalar) bool {
+	return eddsa.VerifyPartialSignature(partial, commitment, publicShare, challenge, lambda)
+}
+
+// HandleCommitments gives s the commitments (Dⱼ, Eⱼ) of all signers at once, for example when a coordinator
+// collects and rebroadcasts them. s must be the state of a signing session created by NewRound, which has
+// finished its first round.
+// The commitments must be given for exactly the signers of the session. The one of the party itself may be omitted,
+// and is ignored otherwise. If an error is returned, none of the commitments are used.
+//
+// The BindingFactor of each Commitment is ignored, since it is derived from all commitments.
+// Otherwise, the session proceeds as if the messages of the first round had been received one by one.
+func HandleCommitments(s *state.State, commitments map[party.ID]*Commitment) error {
+	ids := make([]party.ID, 0, len(commitments))
+	for id := range commitments {
+		ids = append(ids, id)
+	}
+	msgs := make([]*messages.Message, 0, len(commitments))
+	for _, id := range party.NewIDSlice(ids) {
+		commitment := commitments[id]
+		if commitment == nil {
+			return fmt.Errorf("sign.HandleCommitments: commitment of party %d is nil", id)
+		}
+		msgs = append(msgs, messages.NewSign1(id, &commitment.D, &commitment.E))
+	}
+	return s.HandleMessages(msgs)
+}
@@ -0,0 +1,134 @@
+package sign
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/helpers"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/scalar"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+func TestVerifyPartialSignature(t *testing.T) {
+	partyIDs := helpers.GenerateSet(3)
+	_, secretShares := helpers.GenerateSecrets(partyIDs, 2)
+	public := helpers.GeneratePublic(2, secretShares)
+	lagrange, err := party.LagrangeCoefficients(partyIDs)
+	require.NoError(t, err)
+
+	id := party.ID(2)
+	d, e := scalar.NewScalarRandom(), scalar.NewScalarRandom()
+	var commitment Commitment
+	commitment.D.ScalarBaseMult(d)
+	commitment.E.ScalarBaseMult(e)
+	commitment.BindingFactor.Set(scalar.NewScalarRandom())
+	c := scalar.NewScalarRandom()
+
+	// zᵢ = dᵢ + (eᵢ • ρᵢ) + λᵢ • sᵢ • c
+	var z ristretto.Scalar
+	z.Multiply(lagrange[id], &secretShares[id].Secret)
+	z.Multiply(&z, c)
+	z.MultiplyAdd(e, &commitment.BindingFactor, &z)
+	z.Add(&z, d)
+
+	assert.True(t, VerifyPartialSignature(&z, &commitment, public.Shares[id], c, lagrange[id]))
+
+	var tampered ristretto.Scalar
+	tampered.Add(&z, ristretto.NewScalar().SetUint64(1))
+	assert.False(t, VerifyPartialSignature(&tampered, &commitment, public.Shares[id], c, lagrange[id]), "tampered share")
+	assert.False(t, VerifyPartialSignature(&z, &commitment, public.Shares[1], c, lagrange[id]), "wrong public share")
+	assert.False(t, VerifyPartialSignature(&z, &commitment, public.Shares[id], c, lagrange[1]), "wrong Lagrange coefficient")
+	assert.False(t, VerifyPartialSignature(&z, &commitment, public.Shares[id], scalar.NewScalarRandom(), lagrange[id]), "wrong challenge")
+
+	swapped := Commitment{D: commitment.E, E: commitment.D, BindingFactor: commitment.BindingFactor}
+	assert.False(t, VerifyPartialSignature(&z, &swapped, public.Shares[id], c, lagrange[id]), "swapped commitments")
+}
+
+func TestHandleCommitments(t *testing.T) {
+	partyIDs := helpers.GenerateSet(3)
+	_, secretShares := helpers.GenerateSecrets(partyIDs, 2)
+	public := helpers.GeneratePublic(2, secretShares)
+	message := []byte("message")
+
+	// newSession returns the states, rounds and outputs of all signers, whose nonces only depend on their ID.
+	newSession := func() ([]*state.State, []*round0, []*Output) {
+		states := make([]*state.State, 0, len(partyIDs))
+		rounds := make([]*round0, 0, len(partyIDs))
+		outputs := make([]*Output, 0, len(partyIDs))
+		for _, id := range partyIDs {
+			r, output, err := NewRound(partyIDs, secretShares[id], public, message,
+				WithHedgedNonces(), WithRandom(bytes.NewReader(bytes.Repeat(id.Bytes(), 16))))
+			require.NoError(t, err)
+			round := r.(*round0)
+			s, err := state.NewBaseState(round, 0)
+			require.NoError(t, err)
+			states = append(states, s)
+			rounds = append(rounds, round)
+			outputs = append(outputs, output)
+		}
+		return states, rounds, outputs
+	}
+	finish := func(states []*state.State, msgs []*messages.Message) {
+		for _, s := range states {
+			for _, msg := range msgs {
+				require.NoError(t, s.HandleMessage(msg))
+			}
+		}
+		for _, s := range states {
+			require.Empty(t, s.ProcessAll())
+			require.NoError(t, s.WaitForError())
+		}
+	}
+
+	// Incremental ingestion
+	incremental, incrementalRounds, incrementalOutputs := newSession()
+	var msgs1, msgs2 []*messages.Message
+	for _, s := range incremental {
+		msgs1 = append(msgs1, s.ProcessAll()...)
+	}
+	for _, s := range incremental {
+		for _, msg := range msgs1 {
+			require.NoError(t, s.HandleMessage(msg))
+		}
+		msgs2 = append(msgs2, s.ProcessAll()...)
+	}
+	binding := make(map[party.ID]*ristretto.Scalar, len(partyIDs))
+	for id, p := range incrementalRounds[0].Parties {
+		binding[id] = new(ristretto.Scalar).Set(&p.Pi)
+	}
+	finish(incremental, msgs2)
+
+	// Bulk ingestion of all commitments, including the party's own
+	bulk, bulkRounds, bulkOutputs := newSession()
+	commitments := make(map[party.ID]*Commitment, len(partyIDs))
+	for _, s := range bulk {
+		for _, msg := range s.ProcessAll() {
+			commitments[msg.From] = &Commitment{D: msg.Sign1.Di, E: msg.Sign1.Ei}
+		}
+	}
+
+	missing := map[party.ID]*Commitment{1: commitments[1], 2: commitments[2]}
+	assert.Error(t, HandleCommitments(bulk[0], missing), "missing commitment")
+	extra := map[party.ID]*Commitment{1: commitments[1], 2: commitments[2], 3: commitments[3], 4: commitments[1]}
+	assert.Error(t, HandleCommitments(bulk[0], extra), "commitment of a party outside the quorum")
+
+	msgs2 = nil
+	for _, s := range bulk {
+		require.NoError(t, HandleCommitments(s, commitments))
+		msgs2 = append(msgs2, s.ProcessAll()...)
+	}
+	assert.Error(t, HandleCommitments(bulk[0], commitments), "commitments were already received")
+	for id, p := range bulkRounds[0].Parties {
+		assert.Equal(t, 1, binding[id].Equal(&p.Pi), "binding factor of party %d", id)
+	}
+	finish(bulk, msgs2)
+
+	for i := range partyIDs {
+		assert.Equal(t, 1, incrementalOutputs[i].Signature.Equal(bulkOutputs[i].Signature))
+	}
+}
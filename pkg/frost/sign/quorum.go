@@ -0,0 +1,77 @@
+package sign
+
+import (
+	"fmt"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+// QuorumContext holds the Lagrange coefficients of a quorum, and the public shares of its members multiplied by them,
+// so that they are not recomputed by every signing session of the same quorum.
+// It is given to NewRound with WithQuorumContext, and can be shared by concurrent sessions since it is never modified.
+type QuorumContext struct {
+	partyIDs party.IDSlice
+
+	// shares are the public shares the context was computed from, used to detect a different eddsa.Public.
+	shares map[party.ID]*ristretto.Element
+
+	// lagrange[i] = λᵢ
+	lagrange map[party.ID]*ristretto.Scalar
+
+	// public[i] = [λᵢ] Yᵢ, where Yᵢ is the public share of i
+	public map[party.ID]*ristretto.Element
+}
+
+// NewQuorumContext precomputes the data of the quorum partyIDs, which is checked as in NewRound.
+func NewQuorumContext(partyIDs party.IDSlice, shares *eddsa.Public) (*QuorumContext, error) {
+	partyIDs, err := validateQuorum(partyIDs, shares)
+	if err != nil {
+		return nil, fmt.Errorf("sign.NewQuorumContext: %w", err)
+	}
+	lagrange, err := party.LagrangeCoefficients(partyIDs)
+	if err != nil {
+		return nil, fmt.Errorf("sign.NewQuorumContext: %w", err)
+	}
+
+	q := &QuorumContext{
+		partyIDs: partyIDs,
+		shares:   make(map[party.ID]*ristretto.Element, partyIDs.N()),
+		lagrange: lagrange,
+		public:   make(map[party.ID]*ristretto.Element, partyIDs.N()),
+	}
+	for _, id := range partyIDs {
+		var share, public ristretto.Element
+		q.shares[id] = share.Set(shares.Shares[id])
+		q.public[id] = public.ScalarMult(lagrange[id], shares.Shares[id])
+	}
+	return q, nil
+}
+
+// PartyIDs returns a copy of the sorted quorum of q.
+func (q *QuorumContext) PartyIDs() party.IDSlice {
+	return q.partyIDs.Copy()
+}
+
+// matches returns true if q was computed for the sorted quorum partyIDs and the same public shares.
+func (q *QuorumContext) matches(partyIDs party.IDSlice, shares *eddsa.Public) bool {
+	if q == nil || !q.partyIDs.Equal(partyIDs) {
+		return false
+	}
+	for _, id := range partyIDs {
+		if q.shares[id].Equal(shares.Shares[id]) != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// quorumContext returns the QuorumContext given with WithQuorumContext if it matches the session,
+// or a new one computed for the session otherwise.
+func (round *round0) quorumContext(shares *eddsa.Public) (*QuorumContext, error) {
+	if round.quorum.matches(round.PartyIDs(), shares) {
+		return round.quorum, nil
+	}
+	return NewQuorumContext(round.PartyIDs(), shares)
+}
@@ -0,0 +1,100 @@
+package sign
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/helpers"
+)
+
+func TestWithQuorumContext(t *testing.T) {
+	partyIDs := helpers.GenerateSet(5)
+	_, secretShares := helpers.GenerateSecrets(partyIDs, 2)
+	public := helpers.GeneratePublic(2, secretShares)
+	quorum := party.IDSlice{4, 1, 3}
+
+	q, err := NewQuorumContext(quorum, public)
+	require.NoError(t, err)
+	assert.Equal(t, party.IDSlice{1, 3, 4}, q.PartyIDs())
+
+	lagrange, err := party.LagrangeCoefficients(quorum)
+	require.NoError(t, err)
+	for _, id := range quorum {
+		r, _, err := NewRound(quorum, secretShares[id], public, []byte("message"), WithQuorumContext(q))
+		require.NoError(t, err)
+		round := r.(*round0)
+		assert.Same(t, q, round.quorum)
+		cached, err := round.quorumContext(public)
+		require.NoError(t, err)
+		assert.Same(t, q, cached, "the context should be reused")
+		for _, other := range quorum {
+			assert.Equal(t, 1, lagrange[other].Equal(cached.lagrange[other]))
+		}
+	}
+
+	// With another quorum, or other shares, the coefficients are recomputed
+	other := party.IDSlice{1, 2, 3}
+	r, _, err := NewRound(other, secretShares[1], public, []byte("message"), WithQuorumContext(q))
+	require.NoError(t, err)
+	recomputed, err := r.(*round0).quorumContext(public)
+	require.NoError(t, err)
+	assert.NotSame(t, q, recomputed)
+	assert.Equal(t, other, recomputed.PartyIDs())
+
+	_, refreshedShares := helpers.GenerateSecrets(partyIDs, 2)
+	refreshed := helpers.GeneratePublic(2, refreshedShares)
+	r, _, err = NewRound(quorum, refreshedShares[1], refreshed, []byte("message"), WithQuorumContext(q))
+	require.NoError(t, err)
+	recomputed, err = r.(*round0).quorumContext(refreshed)
+	require.NoError(t, err)
+	assert.NotSame(t, q, recomputed)
+
+	// The signatures are valid
+	for _, quorum := range []party.IDSlice{quorum, other} {
+		message := []byte("quorum")
+		states, outputs := runSession(t, quorum, secretShares, public, message, nil, WithQuorumContext(q))
+		for _, s := range states {
+			require.NoError(t, s.WaitForError())
+		}
+		assert.True(t, public.GroupKey.Verify(message, outputs[0].Signature))
+	}
+
+	_, err = NewQuorumContext(party.IDSlice{1, 2}, public)
+	assert.Error(t, err, "the quorum is too small")
+}
+
+// BenchmarkQuorumContext compares the creation of the rounds of all signers in 100 signing sessions of the same quorum,
+// where the Lagrange coefficients are used, with and without a QuorumContext.
+func BenchmarkQuorumContext(b *testing.B) {
+	partyIDs := helpers.GenerateSet(10)
+	_, secretShares := helpers.GenerateSecrets(partyIDs, 9)
+	public := helpers.GeneratePublic(9, secretShares)
+	message := []byte("message")
+
+	sessions := func(opts ...Option) {
+		for j := 0; j < 100; j++ {
+			for _, id := range partyIDs {
+				if _, _, err := NewRound(partyIDs, secretShares[id], public, message, opts...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	}
+
+	b.Run("recomputed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sessions()
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			q, err := NewQuorumContext(partyIDs, public)
+			if err != nil {
+				b.Fatal(err)
+			}
+			sessions(WithQuorumContext(q))
+		}
+	})
+}
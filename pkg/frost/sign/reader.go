@@ -0,0 +1,27 @@
+package sign
+
+import (
+	"crypto/sha512"
+	"fmt"
+	"io"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+// NewRoundFromReader is like NewRound, but reads the message to be signed from r,
+// so that it never needs to be held in memory.
+// Since the challenge of pure Ed25519 can only be computed once all commitments are known,
+// the message is hashed incrementally with SHA-512 and signed with Ed25519ph, as with WithPrehash.
+// The resulting signature verifies with ed25519.VerifyWithOptions, given the SHA-512 digest of the message and crypto.SHA512.
+func NewRoundFromReader(partyIDs party.IDSlice, secret *eddsa.SecretShare, shares *eddsa.Public, r io.Reader, opts ...Option) (state.Round, *Output, error) {
+	h := sha512.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return nil, nil, fmt.Errorf("sign.NewRoundFromReader: %w", err)
+	}
+	allOpts := make([]Option, 0, len(opts)+1)
+	allOpts = append(allOpts, opts...)
+	allOpts = append(allOpts, WithPrehash())
+	return NewRound(partyIDs, secret, shares, h.Sum(nil), allOpts...)
+}
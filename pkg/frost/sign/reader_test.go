@@ -0,0 +1,57 @@
+package sign
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha512"
+	"errors"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/helpers"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/scalar"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+func TestNewRoundFromReader(t *testing.T) {
+	partyIDs := helpers.GenerateSet(3)
+	_, secretShares := helpers.GenerateSecrets(partyIDs, 1)
+	public := helpers.GeneratePublic(1, secretShares)
+
+	message := make([]byte, 8<<20)
+	_, err := rand.Read(message)
+	require.NoError(t, err)
+	digest := sha512.Sum512(message)
+
+	streamed, _, err := NewRoundFromReader(partyIDs, secretShares[1], public, iotest.HalfReader(bytes.NewReader(message)),
+		WithCiphersuite(CiphersuiteRFC9591))
+	require.NoError(t, err)
+	oneShot, _, err := NewRound(partyIDs, secretShares[1], public, digest[:], WithPrehash(), WithCiphersuite(CiphersuiteRFC9591))
+	require.NoError(t, err)
+
+	// Both rounds have the same parameters
+	assert.Equal(t, oneShot.(*round0).SessionID(), streamed.(*round0).SessionID())
+	assert.Equal(t, CiphersuiteRFC9591, streamed.(*round0).Ciphersuite)
+
+	// and therefore compute the same challenge for any commitment R
+	R := new(ristretto.Element).ScalarBaseMult(scalar.NewScalarRandom())
+	expected, err := eddsa.ComputeChallengeWithOptions(R, public.GroupKey, digest[:], &eddsa.Options{Hash: crypto.SHA512})
+	require.NoError(t, err)
+	for _, r := range []*round0{streamed.(*round0), oneShot.(*round0)} {
+		c, err := eddsa.ComputeChallengeWithOptions(R, &r.GroupKey, r.Message, &r.Options)
+		require.NoError(t, err)
+		assert.Equal(t, 1, expected.Equal(c))
+	}
+
+	readErr := errors.New("read failed")
+	_, _, err = NewRoundFromReader(partyIDs, secretShares[1], public, errReader{readErr})
+	assert.ErrorIs(t, err, readErr)
+}
+
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
@@ -1,8 +1,8 @@
 package sign
 
 import (
-	"github.com/taurusgroup/frost-ed25519/pkg/internal/scalar"
 	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
 	"github.com/taurusgroup/frost-ed25519/pkg/state"
 )
 
@@ -10,17 +10,54 @@ func (round *round0) ProcessMessage(*messages.Message) *state.Error {
 	return nil
 }
 
+// randomNonces samples the nonces dᵢ and eᵢ uniformly from the session's source of randomness.
+func (round *round0) randomNonces() error {
+	d, err := ristretto.RandomScalar(round.random)
+	if err != nil {
+		return err
+	}
+	e, err := ristretto.RandomScalar(round.random)
+	if err != nil {
+		return err
+	}
+	round.d.Set(d)
+	round.e.Set(e)
+	d.Zeroize()
+	e.Zeroize()
+	return nil
+}
+
+// sampleNonces sets the nonces dᵢ and eᵢ of the party, as selected by the options of the session.
+func (round *round0) sampleNonces() error {
+	switch {
+	case round.hedged:
+		return round.hedgedNonces()
+	case round.Ciphersuite == CiphersuiteRFC9591:
+		// dᵢ = nonce_generate(sᵢ), eᵢ = nonce_generate(sᵢ)
+		if err := rfc9591NonceGenerate(round.hasher(), &round.d, &round.secret, round.random); err != nil {
+			return err
+		}
+		return rfc9591NonceGenerate(round.hasher(), &round.e, &round.secret, round.random)
+	default:
+		// Sample dᵢ, eᵢ
+		return round.randomNonces()
+	}
+}
+
+// GenerateMessages sends the commitments to the nonces sampled by NewRound.
 func (round *round0) GenerateMessages() ([]*messages.Message, *state.Error) {
 	selfParty := round.Parties[round.SelfID()]
 
-	// Sample dᵢ, Dᵢ = [dᵢ] B
-	scalar.SetScalarRandom(&round.d)
+	// Dᵢ = [dᵢ] B
 	selfParty.Di.ScalarBaseMult(&round.d)
 
-	// Sample eᵢ, Dᵢ = [eᵢ] B
-	scalar.SetScalarRandom(&round.e)
+	// Eᵢ = [eᵢ] B
 	selfParty.Ei.ScalarBaseMult(&round.e)
 
+	if err := round.markUsed(&selfParty.Di, &selfParty.Ei); err != nil {
+		return nil, state.NewError(0, err)
+	}
+
 	msg := messages.NewSign1(round.SelfID(), &selfParty.Di, &selfParty.Ei)
 
 	return []*messages.Message{msg}, nil
@@ -1,96 +1,91 @@
 package sign
 
 import (
-	"crypto/sha512"
 	"errors"
 
 	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
 	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/transcript"
 	"github.com/taurusgroup/frost-ed25519/pkg/messages"
 	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
 	"github.com/taurusgroup/frost-ed25519/pkg/state"
 )
 
-var hashDomainSeparation = []byte("FROST-SHA512")
+const hashDomainSeparation = "FROST-SHA512"
 
+// ProcessMessage stores the commitments Di and Ei of the sender.
+//
+// When the message was received over the wire, messages.Sign1.UnmarshalBinary decoded them with
+// ristretto.Element.SetCanonicalBytes, which rejects non-canonical encodings.
+// Every ristretto255 element is in the prime-order group, so no small-order point can be encoded,
+// and no subgroup check is needed. The identity is a valid ristretto255 element however,
+// and is rejected here since it would cancel the contribution of the sender's nonce.
 func (round *round1) ProcessMessage(msg *messages.Message) *state.Error {
 	id := msg.From
 	otherParty := round.Parties[id]
-	identity := ristretto.NewIdentityElement()
-	if msg.Sign1.Di.Equal(identity) == 1 || msg.Sign1.Ei.Equal(identity) == 1 {
-		return state.NewError(id, errors.New("commitment Ei or Di was the identity"))
+	if msg.Sign1.Di.IsIdentity() == 1 {
+		return state.NewValidationError(id, errors.New("commitment Di was the identity"))
+	}
+	if msg.Sign1.Ei.IsIdentity() == 1 {
+		return state.NewValidationError(id, errors.New("commitment Ei was the identity"))
+	}
+	if err := round.markUsed(&msg.Sign1.Di, &msg.Sign1.Ei); err != nil {
+		return state.NewValidationError(id, err)
 	}
 	otherParty.Di.Set(&msg.Sign1.Di)
 	otherParty.Ei.Set(&msg.Sign1.Ei)
 	return nil
 }
 
+// computeRhos computes the binding factor 𝜌ᵢ of each party i for CiphersuiteTranscript.
+//
+// The entries common to all parties are recorded in a transcript.Transcript whose domain is "FROST-SHA512":
+//
+//	message:      H(Message)
+//	session:      S, if the session has an identifier S given by WithSessionID
+//	id, D, E:     j, Dⱼ, Eⱼ for all signers j in sorted order
+//	P =           challenge "binding"
+//
+// where H is SHA-512 unless another eddsa.Hasher is used, and Message is preceded by the associated data
+// given with WithAssociatedData, as in eddsa.MessageWithAssociatedData.
+// The binding factor of each party i is then
+//
+//	𝜌ᵢ = H("FROST-SHA512" ∥ P ∥ i)
+//
+// so that the commitments are hashed once, and not once per party.
 func (round *round1) computeRhos() {
-	/*
-		While profiling, we noticed that using hash.Hash forces all values to be allocated on the heap.
-		To prevent this, we can simply create a big buffer on the stack and call sha512.Sum().
-
-		We need to compute a very simple hash N times, and Go's caching isn't great for hashing.
-		Therefore, we can simply change the buffer and rehash it many times.
-	*/
-	messageHash := sha512.Sum512(round.Message)
-
-	sizeB := int(round.PartyIDs().N() * (party.IDByteSize + 32 + 32))
-	bufferHeader := len(hashDomainSeparation) + party.IDByteSize + len(messageHash)
-	sizeBuffer := bufferHeader + sizeB
-	offsetID := len(hashDomainSeparation)
-
-	// We compute the binding factor 𝜌_{i} for each party as such:
-	//
-	//     𝜌_d = SHA-512 ("FROST-SHA512" ∥ i ∥ SHA-512(Message) ∥ B )
-	//
-	// For each party ID i.
-	//
-	// The list B is the concatenation of ( j ∥ Dⱼ ∥ Eⱼ ) for all signers j in sorted order.
-	//     B = (ID1 ∥ D₁ ∥ E₁) ∥ (ID_2 ∥ D₂ ∥ E₂) ∥ ... ∥ (ID_N ∥ D_N ∥ E_N)
-
-	// We compute the big buffer "FROST-SHA512" ∥ ... ∥ SHA-512(Message) ∥ B
-	// and remember the offset of ... . Later we will write the ID of each party at this place.
-	buffer := make([]byte, 0, sizeBuffer)
-	buffer = append(buffer, hashDomainSeparation...)
-	buffer = append(buffer, round.SelfID().Bytes()...)
-	buffer = append(buffer, messageHash[:]...)
+	h := round.hasher()
 
-	// compute B
+	t := transcript.New(hashDomainSeparation, h)
+	t.AppendMessage("message", h.Hash("", round.signedMessage()))
+	if len(round.session) > 0 {
+		t.AppendMessage("session", round.session)
+	}
 	for _, id := range round.PartyIDs() {
 		otherParty := round.Parties[id]
-		buffer = append(buffer, id.Bytes()...)
-		buffer = append(buffer, otherParty.Di.Bytes()...)
-		buffer = append(buffer, otherParty.Ei.Bytes()...)
+		t.AppendMessage("id", id.Bytes())
+		t.AppendMessage("D", otherParty.Di.Bytes())
+		t.AppendMessage("E", otherParty.Ei.Bytes())
 	}
+	prefix := t.ChallengeScalar("binding").Bytes()
 
+	buffer := make([]byte, 0, len(prefix)+party.IDByteSize)
+	buffer = append(buffer, prefix...)
+	buffer = append(buffer, round.SelfID().Bytes()...)
 	for _, id := range round.PartyIDs() {
-		// Update the four bytes with the ID
-		copy(buffer[offsetID:], id.Bytes())
+		// Update the last bytes with the ID
+		copy(buffer[len(prefix):], id.Bytes())
 
-		// Pi = ρ = H ("FROST-SHA512" ∥ Message ∥ B ∥ ID )
-		digest := sha512.Sum512(buffer)
-		_, _ = round.Parties[id].Pi.SetUniformBytes(digest[:])
+		// Pi = ρ = H("FROST-SHA512" ∥ P ∥ ID)
+		h.HashToScalar(&round.Parties[id].Pi, hashDomainSeparation, buffer)
 	}
 }
 
 func (round *round1) GenerateMessages() ([]*messages.Message, *state.Error) {
-	round.computeRhos()
-
-	round.R.Set(ristretto.NewIdentityElement())
-	for _, p := range round.Parties {
-		// TODO Find a way to do this faster since we don't need constant time
-		// Ri = D + [ρ] E
-		p.Ri.ScalarMult(&p.Pi, &p.Ei)
-		p.Ri.Add(&p.Ri, &p.Di)
-
-		// R += Ri
-		round.R.Add(&round.R, &p.Ri)
+	if err := round.computeChallenge(); err != nil {
+		return nil, err
 	}
 
-	// c = H(R, GroupKey, M)
-	round.C.Set(eddsa.ComputeChallenge(&round.R, &round.GroupKey, round.Message))
-
 	selfParty := round.Parties[round.SelfID()]
 
 	// Compute z = d + (e • ρ) + 𝛌 • s • c
@@ -106,6 +101,42 @@ func (round *round1) GenerateMessages() ([]*messages.Message, *state.Error) {
 	return []*messages.Message{msg}, nil
 }
 
+// computeChallenge derives the binding factors ρᵢ and commitments Rᵢ of all signers from their commitments (Dᵢ, Eᵢ),
+// and sets the group commitment R and the challenge c of the session.
+// The commitments are always encoded by increasing party.ID, which is part of the wire contract with other implementations,
+// so that the result does not depend on the order in which they were received.
+func (round *round1) computeChallenge() *state.Error {
+	switch round.Ciphersuite {
+	case CiphersuiteRFC9591:
+		round.rfc9591BindingFactors()
+	case CiphersuiteLegacy:
+		round.legacyBindingFactors()
+	default:
+		round.computeRhos()
+	}
+
+	round.R.Set(ristretto.NewIdentityElement())
+	for _, p := range round.Parties {
+		// TODO Find a way to do this faster since we don't need constant time
+		// Ri = D + [ρ] E
+		p.Ri.ScalarMult(&p.Pi, &p.Ei)
+		p.Ri.Add(&p.Ri, &p.Di)
+
+		// R += Ri
+		round.R.Add(&round.R, &p.Ri)
+	}
+	round.Output.setGroupCommitment(&round.R)
+	round.Output.setBindingFactors(round.Parties)
+
+	// c = H(R, GroupKey, M)
+	c, err := eddsa.ComputeChallengeWithOptions(&round.R, &round.GroupKey, round.Message, &round.Options)
+	if err != nil {
+		return state.NewError(0, err)
+	}
+	round.C.Set(c)
+	return nil
+}
+
 func (round *round1) NextRound() state.Round {
-	return &round2{round}
+	return &round2{round1: round}
 }
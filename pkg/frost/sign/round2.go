@@ -14,23 +14,28 @@ var (
 	ErrValidateSignature = errors.New("full signature is invalid")
 )
 
+// IdentifiableAbortError is the state.AbortError reported when the signature shares of some parties are invalid.
+// It wraps ErrValidateSigShare, and its Culprits are the parties who sent an invalid signature share.
+type IdentifiableAbortError = state.AbortError
+
 func (round *round2) ProcessMessage(msg *messages.Message) *state.Error {
 	id := msg.From
 	otherParty := round.Parties[id]
 
-	var publicNeg, RPrime ristretto.Element
-	publicNeg.Negate(&otherParty.Public)
-
-	// RPrime = [c](-A) + [s]B
-	RPrime.VarTimeDoubleScalarBaseMult(&round.C, &publicNeg, &msg.Sign2.Zi)
-	if RPrime.Equal(&otherParty.Ri) != 1 {
-		return state.NewError(id, ErrValidateSigShare)
+	if !eddsa.VerifyShare(&msg.Sign2.Zi, &otherParty.Ri, &otherParty.Public, &round.C) {
+		// We continue verifying the other shares, so that all culprits can be reported.
+		round.culprits = append(round.culprits, id)
+		return nil
 	}
 	otherParty.Zi.Set(&msg.Sign2.Zi)
 	return nil
 }
 
 func (round *round2) GenerateMessages() ([]*messages.Message, *state.Error) {
+	if len(round.culprits) > 0 {
+		return nil, state.NewAbortError(ErrValidateSigShare, round.culprits...)
+	}
+
 	// S = ∑ sᵢ
 	S := ristretto.NewScalar()
 	for _, otherParty := range round.Parties {
@@ -43,8 +48,8 @@ func (round *round2) GenerateMessages() ([]*messages.Message, *state.Error) {
 		S: *S,
 	}
 
-	if !round.GroupKey.Verify(round.Message, sig) {
-		return nil, state.NewError(0, ErrValidateSignature)
+	if round.GroupKey.VerifyWithOptions(round.Message, sig, &round.Options) != nil {
+		return nil, state.NewAbortError(ErrValidateSignature)
 	}
 
 	round.Output.Signature = sig
@@ -0,0 +1,441 @@
+package sign
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/helpers"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+// runSign generates a t-of-n sharing and signs message with the first t+1 parties.
+// It returns the group's public key material and the signature of the first signer.
+func runSign(t *testing.T, n, threshold party.Size, message []byte, opts ...Option) (*eddsa.Public, *eddsa.Signature) {
+	public, states, outputs := runSignTampered(t, n, threshold, message, nil, opts...)
+	for _, s := range states {
+		require.NoError(t, s.WaitForError())
+	}
+	require.NotNil(t, outputs[0].Signature)
+	return public, outputs[0].Signature
+}
+
+// runSignTampered is like runSign, but lets tamper modify every message before it is delivered.
+// It returns the states of all signers, which may have aborted.
+func runSignTampered(t *testing.T, n, threshold party.Size, message []byte, tamper func(msg *messages.Message), opts ...Option) (*eddsa.Public, []*state.State, []*Output) {
+	partyIDs := helpers.GenerateSet(n)
+	_, secretShares := helpers.GenerateSecrets(partyIDs, threshold)
+	public := helpers.GeneratePublic(threshold, secretShares)
+	states, outputs := runSession(t, partyIDs[:threshold+1], secretShares, public, message, tamper, opts...)
+	return public, states, outputs
+}
+
+// runSession runs a signing session between signIDs, with the given shares, and lets tamper modify every message
+// before it is delivered if it is not nil. It returns the states and outputs of all signers, which may have aborted.
+func runSession(t *testing.T, signIDs party.IDSlice, secretShares map[party.ID]*eddsa.SecretShare, public *eddsa.Public,
+	message []byte, tamper func(msg *messages.Message), opts ...Option) ([]*state.State, []*Output) {
+	states := make([]*state.State, 0, len(signIDs))
+	outputs := make([]*Output, 0, len(signIDs))
+	for _, id := range signIDs {
+		r, output, err := NewRound(signIDs, secretShares[id], public, message, opts...)
+		require.NoError(t, err)
+		s, err := state.NewBaseState(r, 0)
+		require.NoError(t, err)
+		states = append(states, s)
+		outputs = append(outputs, output)
+	}
+
+	var msgs []*messages.Message
+	for round := 0; round < 3; round++ {
+		var next []*messages.Message
+		for _, s := range states {
+			// A state which aborted does not accept any more messages
+			if s.IsFinished() {
+				continue
+			}
+			for _, msg := range msgs {
+				require.NoError(t, s.HandleMessage(msg))
+			}
+			next = append(next, s.ProcessAll()...)
+		}
+		if tamper != nil {
+			for _, msg := range next {
+				tamper(msg)
+			}
+		}
+		msgs = next
+	}
+	return states, outputs
+}
+
+func TestSign_Prehash(t *testing.T) {
+	message := []byte("a large file that should not be sent to every signer")
+	digest := sha512.Sum512(message)
+
+	public, sig := runSign(t, 5, 2, digest[:], WithPrehash())
+	pk := public.GroupKey.ToEd25519()
+
+	assert.NoError(t, ed25519.VerifyWithOptions(pk, digest[:], sig.ToEd25519(), &ed25519.Options{Hash: crypto.SHA512}))
+	assert.NoError(t, public.GroupKey.VerifyWithOptions(digest[:], sig, &eddsa.Options{Hash: crypto.SHA512}))
+
+	// The signature must not be valid for plain Ed25519, neither over the message nor over the digest.
+	assert.False(t, ed25519.Verify(pk, message, sig.ToEd25519()))
+	assert.False(t, ed25519.Verify(pk, digest[:], sig.ToEd25519()))
+
+	// Ed25519ph with the RFC 9591 binding factors
+	public, sig = runSign(t, 3, 1, digest[:], WithPrehash(), WithCiphersuite(CiphersuiteRFC9591))
+	assert.NoError(t, ed25519.VerifyWithOptions(public.GroupKey.ToEd25519(), digest[:], sig.ToEd25519(), &ed25519.Options{Hash: crypto.SHA512}))
+}
+
+func TestNewRound_PrehashLength(t *testing.T) {
+	partyIDs := helpers.GenerateSet(3)
+	_, secretShares := helpers.GenerateSecrets(partyIDs, 1)
+	public := helpers.GeneratePublic(1, secretShares)
+
+	_, _, err := NewRound(partyIDs, secretShares[1], public, []byte("not a digest"), WithPrehash())
+	assert.ErrorIs(t, err, eddsa.ErrOptionsPrehashedLen)
+}
+
+func TestSign_Context(t *testing.T) {
+	message := []byte("transfer 10 coins")
+
+	public, sig := runSign(t, 5, 2, message, WithContext("payments"))
+	pk := public.GroupKey.ToEd25519()
+
+	assert.NoError(t, ed25519.VerifyWithOptions(pk, message, sig.ToEd25519(), &ed25519.Options{Context: "payments"}))
+	assert.Error(t, ed25519.VerifyWithOptions(pk, message, sig.ToEd25519(), &ed25519.Options{Context: "governance"}))
+	assert.False(t, ed25519.Verify(pk, message, sig.ToEd25519()))
+
+	// The empty context matches the no-context path
+	public, sig = runSign(t, 5, 2, message, WithContext(""))
+	assert.True(t, ed25519.Verify(public.GroupKey.ToEd25519(), message, sig.ToEd25519()))
+	assert.Error(t, public.GroupKey.VerifyWithOptions(message, sig, &eddsa.Options{Context: "payments"}))
+
+	partyIDs := helpers.GenerateSet(3)
+	_, secretShares := helpers.GenerateSecrets(partyIDs, 1)
+	_, _, err := NewRound(partyIDs, secretShares[1], helpers.GeneratePublic(1, secretShares), message,
+		WithContext(string(make([]byte, eddsa.MaxContextLength+1))))
+	assert.ErrorIs(t, err, eddsa.ErrOptionsContextLen)
+}
+
+func TestSign_AssociatedData(t *testing.T) {
+	message := []byte("transfer 10 coins")
+	ad := []byte("policy 7, 2024-01-01T00:00:00Z")
+
+	for _, c := range []Ciphersuite{CiphersuiteLegacy, CiphersuiteRFC9591, CiphersuiteTranscript} {
+		public, sig := runSign(t, 3, 1, message, WithAssociatedData(ad), WithCiphersuite(c))
+		pk := public.GroupKey.ToEd25519()
+
+		assert.NoError(t, public.GroupKey.VerifyWithOptions(message, sig, &eddsa.Options{AssociatedData: ad}), c)
+		assert.True(t, ed25519.Verify(pk, eddsa.MessageWithAssociatedData(message, ad), sig.ToEd25519()), c)
+		assert.False(t, ed25519.Verify(pk, message, sig.ToEd25519()), c)
+		assert.Error(t, public.GroupKey.VerifyWithOptions(message, sig, &eddsa.Options{AssociatedData: []byte("policy 8")}), c)
+	}
+
+	// The empty associated data matches the path without associated data
+	public, sig := runSign(t, 3, 1, message, WithAssociatedData(nil))
+	assert.True(t, ed25519.Verify(public.GroupKey.ToEd25519(), message, sig.ToEd25519()))
+}
+
+// TestSign_CommitmentOrder checks that the binding factors and the challenge only depend on the set of commitments,
+// and not on the order in which the signers are given or their commitments are received.
+func TestSign_CommitmentOrder(t *testing.T) {
+	partyIDs := helpers.GenerateSet(5)
+	_, secretShares := helpers.GenerateSecrets(partyIDs, 3)
+	public := helpers.GeneratePublic(3, secretShares)
+	message := []byte("canonical order")
+	shuffler := rand.New(rand.NewSource(1))
+
+	for _, c := range []Ciphersuite{CiphersuiteLegacy, CiphersuiteRFC9591, CiphersuiteTranscript} {
+		var expected *eddsa.Signature
+		for run := 0; run < 10; run++ {
+			signIDs := party.IDSlice{5, 2, 4, 1}
+			shuffler.Shuffle(len(signIDs), func(i, j int) { signIDs[i], signIDs[j] = signIDs[j], signIDs[i] })
+
+			// The nonces are the same in every run, so that the signatures can be compared
+			rounds := make(map[party.ID]*round1, len(signIDs))
+			var msgs1 []*messages.Message
+			for _, id := range signIDs {
+				r, _, err := NewRound(signIDs, secretShares[id], public, message, WithCiphersuite(c), WithRandom(rand.New(rand.NewSource(int64(id)))))
+				require.NoError(t, err)
+				rounds[id] = &round1{r.(*round0)}
+				out, stateErr := rounds[id].round0.GenerateMessages()
+				require.Nil(t, stateErr)
+				msgs1 = append(msgs1, out...)
+			}
+
+			var msgs2 []*messages.Message
+			for _, id := range signIDs {
+				shuffler.Shuffle(len(msgs1), func(i, j int) { msgs1[i], msgs1[j] = msgs1[j], msgs1[i] })
+				for _, msg := range msgs1 {
+					if msg.From != id {
+						require.Nil(t, rounds[id].ProcessMessage(msg))
+					}
+				}
+				out, stateErr := rounds[id].GenerateMessages()
+				require.Nil(t, stateErr)
+				msgs2 = append(msgs2, out...)
+			}
+
+			for _, id := range signIDs {
+				r2 := &round2{round1: rounds[id]}
+				shuffler.Shuffle(len(msgs2), func(i, j int) { msgs2[i], msgs2[j] = msgs2[j], msgs2[i] })
+				for _, msg := range msgs2 {
+					if msg.From != id {
+						require.Nil(t, r2.ProcessMessage(msg))
+					}
+				}
+				_, stateErr := r2.GenerateMessages()
+				require.Nil(t, stateErr)
+
+				sig := rounds[id].Output.Signature
+				require.True(t, ed25519.Verify(public.GroupKey.ToEd25519(), message, sig.ToEd25519()))
+				if expected == nil {
+					expected = sig
+				}
+				assert.Equal(t, 1, sig.Equal(expected), "%v: signers %v, party %d", c, signIDs, id)
+			}
+		}
+	}
+}
+
+func TestSign_IdentifiableAbort(t *testing.T) {
+	for _, culprits := range [][]party.ID{{3}, {2, 4}} {
+		tamper := func(msg *messages.Message) {
+			if msg.Type != messages.MessageTypeSign2 {
+				return
+			}
+			for _, id := range culprits {
+				if msg.From == id {
+					msg.Sign2.Zi.Add(&msg.Sign2.Zi, ristretto.NewScalar().SetUint64(1))
+				}
+			}
+		}
+		_, states, _ := runSignTampered(t, 5, 3, []byte("message"), tamper)
+
+		for i, s := range states {
+			id := party.ID(i + 1)
+			err := s.WaitForError()
+
+			// A party does not verify its own share, so a single culprit does not detect itself.
+			expected := make([]party.ID, 0, len(culprits))
+			for _, culprit := range culprits {
+				if culprit != id {
+					expected = append(expected, culprit)
+				}
+			}
+			if len(expected) == 0 {
+				continue
+			}
+
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrValidateSigShare)
+			var abortErr *IdentifiableAbortError
+			require.ErrorAs(t, err, &abortErr)
+			assert.Equal(t, party.IDSlice(expected), abortErr.Culprits, "party %d", id)
+
+			var stateErr *state.Error
+			require.ErrorAs(t, err, &stateErr)
+			if len(expected) == 1 {
+				assert.Equal(t, expected[0], stateErr.PartyID)
+			} else {
+				assert.Equal(t, party.ID(0), stateErr.PartyID)
+			}
+		}
+	}
+}
+
+func TestSign_IdentityCommitment(t *testing.T) {
+	culprit := party.ID(2)
+	tamper := func(msg *messages.Message) {
+		if msg.Type == messages.MessageTypeSign1 && msg.From == culprit {
+			msg.Sign1.Ei.Set(ristretto.NewIdentityElement())
+		}
+	}
+	_, states, _ := runSignTampered(t, 4, 2, []byte("message"), tamper)
+
+	for i, s := range states {
+		if party.ID(i+1) == culprit {
+			continue
+		}
+		err := s.WaitForError()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Ei was the identity")
+		var stateErr *state.Error
+		require.ErrorAs(t, err, &stateErr)
+		assert.Equal(t, culprit, stateErr.PartyID)
+	}
+}
+
+func TestNewRound_Quorum(t *testing.T) {
+	partyIDs := helpers.GenerateSet(5)
+	_, secretShares := helpers.GenerateSecrets(partyIDs, 2)
+	public := helpers.GeneratePublic(2, secretShares)
+	secret := secretShares[1]
+
+	tests := []struct {
+		name    string
+		quorum  party.IDSlice
+		wantErr string
+	}{
+		{"threshold+1", party.IDSlice{1, 2, 3}, ""},
+		{"all", party.IDSlice{1, 2, 3, 4, 5}, ""},
+		{"unsorted", party.IDSlice{4, 1, 3}, ""},
+		{"undersized", party.IDSlice{1, 2}, "at least 3 parties"},
+		{"undersized self", party.IDSlice{1}, "at least 3 parties"},
+		{"duplicates", party.IDSlice{1, 2, 2}, "duplicates"},
+		{"duplicates of self", party.IDSlice{1, 1, 1, 2}, "duplicates"},
+		{"unknown", party.IDSlice{1, 2, 6}, "[6]"},
+		{"zero", party.IDSlice{0, 1, 2}, "contain 0"},
+		{"self missing", party.IDSlice{2, 3, 4}, "owner of SecretShare"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _, err := NewRound(tt.quorum, secret, public, []byte("message"))
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				assert.True(t, r.PartyIDs().Equal(party.NewIDSlice(tt.quorum)))
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+
+			_, _, err = NewBatchRound(tt.quorum, secret, public, [][]byte{[]byte("message")})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestState_Zeroize(t *testing.T) {
+	partyIDs := helpers.GenerateSet(3)
+	_, secretShares := helpers.GenerateSecrets(partyIDs, 1)
+	public := helpers.GeneratePublic(1, secretShares)
+
+	r, _, err := NewRound(partyIDs, secretShares[1], public, []byte("message"))
+	require.NoError(t, err)
+	round := r.(*round0)
+	s, err := state.NewBaseState(r, 0)
+	require.NoError(t, err)
+	require.Len(t, s.ProcessAll(), 1)
+
+	zero := make([]byte, 32)
+	secrets := map[string]*ristretto.Scalar{
+		"d":              &round.d,
+		"e":              &round.e,
+		"SecretKeyShare": &round.SecretKeyShare,
+		"secret":         &round.secret,
+	}
+	for name, secret := range secrets {
+		require.NotEqual(t, zero, secret.Bytes(), name)
+	}
+
+	s.Zeroize()
+	for name, secret := range secrets {
+		assert.Equal(t, zero, secret.Bytes(), name)
+	}
+	assert.ErrorIs(t, s.WaitForError(), state.ErrZeroized)
+
+	// Zeroizing a finished state is harmless, and keeps its result
+	public, states, outputs := runSignTampered(t, 3, 1, []byte("message"), nil)
+	for _, s := range states {
+		require.NoError(t, s.WaitForError())
+		s.Zeroize()
+		assert.NoError(t, s.WaitForError())
+	}
+	assert.True(t, public.GroupKey.Verify([]byte("message"), outputs[0].Signature))
+}
+
+func TestWithSessionID(t *testing.T) {
+	partyIDs := helpers.GenerateSet(3)
+	_, secretShares := helpers.GenerateSecrets(partyIDs, 2)
+	public := helpers.GeneratePublic(2, secretShares)
+	message := []byte("message")
+
+	// session runs a signing session in which all parties use the same randomness, and therefore the same commitments,
+	// and replaces the signature shares by replay if it is not nil.
+	// It returns the binding factors, the signature shares and the states of all parties.
+	session := func(sessionID string, replay [][]byte) (map[party.ID]ristretto.Scalar, [][]byte, map[party.ID]*state.State) {
+		rounds := make(map[party.ID]*round0, len(partyIDs))
+		states := make(map[party.ID]*state.State, len(partyIDs))
+		for _, id := range partyIDs {
+			r, _, err := NewRound(partyIDs, secretShares[id], public, message,
+				WithCiphersuite(CiphersuiteRFC9591), WithSessionID([]byte(sessionID)),
+				WithRandom(bytes.NewReader(bytes.Repeat([]byte{byte(id)}, 64))))
+			require.NoError(t, err)
+			rounds[id] = r.(*round0)
+			states[id], err = state.NewBaseState(r, 0)
+			require.NoError(t, err)
+		}
+
+		bindingFactors := make(map[party.ID]ristretto.Scalar, len(partyIDs))
+		var msgs, shares [][]byte
+		for round := 0; round < 3; round++ {
+			var next [][]byte
+			for _, id := range partyIDs {
+				for _, data := range msgs {
+					var msg messages.Message
+					require.NoError(t, msg.UnmarshalBinary(data))
+					if msg.From != id {
+						require.NoError(t, states[id].HandleMessage(&msg))
+					}
+				}
+				for _, msg := range states[id].ProcessAll() {
+					data, err := msg.MarshalBinary()
+					require.NoError(t, err)
+					next = append(next, data)
+				}
+			}
+			if round == 1 {
+				for _, id := range partyIDs {
+					bindingFactors[id] = rounds[1].Parties[id].Pi
+				}
+				shares = next
+				if replay != nil {
+					next = replay
+				}
+			}
+			msgs = next
+		}
+		return bindingFactors, shares, states
+	}
+
+	bindingA, sharesA, states := session("session A", nil)
+	for _, s := range states {
+		require.NoError(t, s.WaitForError())
+	}
+
+	// The shares can be replayed in a session with the same identifier, since it is the same session
+	_, _, states = session("session A", sharesA)
+	for _, s := range states {
+		require.NoError(t, s.WaitForError())
+	}
+
+	// With a different identifier, the same commitments give different binding factors, and the shares are rejected
+	bindingB, _, states := session("session B", sharesA)
+	for _, id := range partyIDs {
+		a, b := bindingA[id], bindingB[id]
+		assert.Equal(t, 0, a.Equal(&b), "binding factor of party %d", id)
+
+		err := states[id].WaitForError()
+		require.Error(t, err, "party %d", id)
+		var stateErr *state.Error
+		require.ErrorAs(t, err, &stateErr)
+		assert.NotEqual(t, id, stateErr.PartyID)
+	}
+
+	// The binding factors without an identifier are those of RFC 9591, which also differ
+	bindingNone, _, _ := session("", nil)
+	for _, id := range partyIDs {
+		a, none := bindingA[id], bindingNone[id]
+		assert.Equal(t, 0, a.Equal(&none))
+	}
+}
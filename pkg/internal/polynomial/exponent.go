@@ -77,7 +77,8 @@ func (p *Exponent) evaluateVar(index *ristretto.Scalar, result *ristretto.Elemen
 			powersPointers[i] = powers[i].Multiply(&powers[i-1], index)
 		}
 	}
-	result.VarTimeMultiScalarMult(powersPointers, p.coefficients)
+	// Both slices have the same length by construction
+	_, _ = result.VarTimeMultiScalarMult(powersPointers, p.coefficients)
 	return result
 }
 
@@ -160,6 +161,11 @@ func (p *Exponent) Constant() *ristretto.Element {
 	return result.Set(p.coefficients[0])
 }
 
+// Coefficients returns a copy of the coefficients of p, starting with the constant one.
+func (p *Exponent) Coefficients() []*ristretto.Element {
+	return p.Copy().coefficients
+}
+
 // Copy returns a deep copy of p
 func (p *Exponent) Copy() *Exponent {
 	var q Exponent
@@ -187,14 +193,15 @@ func (p *Exponent) UnmarshalBinary(data []byte) error {
 	if err != nil {
 		return err
 	}
-	coefficientCount := degree + 1
+	// The count is computed as an int, since degree + 1 overflows for the maximum party.Size
+	coefficientCount := int(degree) + 1
 	remaining := data[party.IDByteSize:]
 
 	count := len(remaining)
 	if count%32 != 0 {
 		return errors.New("length of data is wrong")
 	}
-	if count != int(coefficientCount)*32 {
+	if count != coefficientCount*32 {
 		return errors.New("wrong number of coefficients embedded")
 	}
 
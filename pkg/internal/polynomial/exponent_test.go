@@ -92,3 +92,13 @@ func TestSum(t *testing.T) {
 	assert.Equal(t, 1, evaluationSum.Equal(evaluationFromScalar))
 	assert.Equal(t, 1, evaluationSum.Equal(evaluationPartial))
 }
+
+func TestExponent_UnmarshalBinary(t *testing.T) {
+	var p Exponent
+	// The maximum degree used to overflow the coefficient count to 0
+	assert.Error(t, p.UnmarshalBinary([]byte{0xff, 0xff, 0xff, 0xff}))
+	assert.Error(t, p.UnmarshalBinary([]byte{0, 0, 0}))
+	assert.Error(t, p.UnmarshalBinary(append([]byte{0, 0, 0, 1}, make([]byte, 32)...)))
+	assert.NoError(t, p.UnmarshalBinary(append([]byte{0, 0, 0, 0}, ristretto.NewIdentityElement().Bytes()...)))
+	assert.Equal(t, party.Size(0), p.Degree())
+}
@@ -2,7 +2,9 @@ package polynomial
 
 import (
 	"crypto/rand"
+	"errors"
 	"fmt"
+	"io"
 
 	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
 	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
@@ -15,23 +17,31 @@ type Polynomial struct {
 // NewPolynomial generates a Polynomial f(X) = secret + a1*X + ... + at*X^t,
 // with coefficients in Z_q, and degree t.
 func NewPolynomial(degree party.Size, constant *ristretto.Scalar) *Polynomial {
+	polynomial, err := NewPolynomialFromReader(degree, constant, rand.Reader)
+	if err != nil {
+		panic(fmt.Errorf("edwards25519: failed to generate random Scalar: %w", err))
+	}
+	return polynomial
+}
+
+// NewPolynomialFromReader is like NewPolynomial, but samples the coefficients a1, ..., at from r.
+// The output is deterministic if r is, and r should therefore be a cryptographically secure source of randomness.
+func NewPolynomialFromReader(degree party.Size, constant *ristretto.Scalar, r io.Reader) (*Polynomial, error) {
 	var polynomial Polynomial
 	polynomial.coefficients = make([]ristretto.Scalar, degree+1)
 
 	// SetWithoutSelf the constant term to the secret
 	polynomial.coefficients[0].Set(constant)
 
-	var err error
-	randomBytes := make([]byte, 64)
 	for i := party.Size(1); i <= degree; i++ {
-		_, err = rand.Read(randomBytes)
+		c, err := ristretto.RandomScalar(r)
 		if err != nil {
-			panic(fmt.Errorf("edwards25519: failed to generate random Scalar: %w", err))
+			return nil, err
 		}
-		_, _ = polynomial.coefficients[i].SetUniformBytes(randomBytes)
+		polynomial.coefficients[i].Set(c)
 	}
 
-	return &polynomial
+	return &polynomial, nil
 }
 
 // Evaluate evaluates a polynomial in a given variable index
@@ -74,3 +84,36 @@ func (p *Polynomial) Reset() {
 		p.coefficients[i].Set(zero)
 	}
 }
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The output contains the secret coefficients, and should be stored encrypted.
+func (p *Polynomial) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, party.IDByteSize+32*len(p.coefficients))
+	data = append(data, p.Degree().Bytes()...)
+	for i := range p.coefficients {
+		data = append(data, p.coefficients[i].Bytes()...)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (p *Polynomial) UnmarshalBinary(data []byte) error {
+	degree, err := party.FromBytes(data)
+	if err != nil {
+		return err
+	}
+	remaining := data[party.IDByteSize:]
+	if len(remaining) != (int(degree)+1)*32 {
+		return errors.New("wrong number of coefficients embedded")
+	}
+
+	coefficients := make([]ristretto.Scalar, int(degree)+1)
+	for i := range coefficients {
+		if _, err = coefficients[i].SetCanonicalBytesConstantTime(remaining[:32]); err != nil {
+			return err
+		}
+		remaining = remaining[32:]
+	}
+	p.coefficients = coefficients
+	return nil
+}
@@ -27,3 +27,17 @@ func TestPolynomial_Evaluate(t *testing.T) {
 		}
 	}
 }
+
+func TestPolynomial_MarshalBinary(t *testing.T) {
+	p := NewPolynomial(5, scalar.NewScalarRandom())
+	data, err := p.MarshalBinary()
+	assert.NoError(t, err)
+
+	var p2 Polynomial
+	assert.NoError(t, p2.UnmarshalBinary(data))
+	assert.Equal(t, p.Degree(), p2.Degree())
+	x := scalar.NewScalarUInt32(42)
+	assert.Equal(t, 1, p.Evaluate(x).Equal(p2.Evaluate(x)))
+
+	assert.Error(t, p2.UnmarshalBinary(data[:len(data)-1]))
+}
@@ -9,15 +9,11 @@ import (
 
 // SetScalarRandom sets s to a random ristretto.Scalar using the default randomness source from crypto/rand
 func SetScalarRandom(s *ristretto.Scalar) *ristretto.Scalar {
-	bytes := make([]byte, 64)
-
-	_, err := rand.Reader.Read(bytes)
+	r, err := ristretto.RandomScalar(rand.Reader)
 	if err != nil {
 		panic(fmt.Errorf("edwards25519: failed to generate random Scalar: %w", err))
 	}
-
-	_, _ = s.SetUniformBytes(bytes)
-	return s
+	return s.Set(r)
 }
 
 // NewScalarRandom generates a new ristretto.Scalar using the default randomness source from crypto/rand
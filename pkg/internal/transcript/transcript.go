@@ -0,0 +1,74 @@
+package transcript
+
+import (
+	"encoding/binary"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+// Hasher hashes a transcript to a scalar. It is implemented by eddsa.Hasher.
+type Hasher interface {
+	HashToScalar(s *ristretto.Scalar, label string, m ...[]byte) *ristretto.Scalar
+}
+
+const (
+	kindMessage   byte = 'm'
+	kindChallenge byte = 'c'
+)
+
+// Transcript accumulates labeled data, from which challenges are derived.
+//
+// Every entry is encoded as
+//
+//	kind ∥ uint32(len(label)) ∥ label ∥ uint64(len(data)) ∥ data
+//
+// where the lengths are big-endian, and kind distinguishes messages from challenges.
+// The encoding of a sequence of entries is therefore unambiguous, even when the data has a variable length.
+// A challenge is the hash of the domain followed by the encoding of all entries, including that of the challenge itself.
+type Transcript struct {
+	domain string
+	hasher Hasher
+	data   []byte
+}
+
+// New returns an empty Transcript whose challenges are computed by h, with the label domain.
+func New(domain string, h Hasher) *Transcript {
+	return &Transcript{
+		domain: domain,
+		hasher: h,
+	}
+}
+
+func (t *Transcript) append(kind byte, label string, data []byte) {
+	var lengths [12]byte
+	binary.BigEndian.PutUint32(lengths[:4], uint32(len(label)))
+	binary.BigEndian.PutUint64(lengths[4:], uint64(len(data)))
+
+	t.data = append(t.data, kind)
+	t.data = append(t.data, lengths[:4]...)
+	t.data = append(t.data, label...)
+	t.data = append(t.data, lengths[4:]...)
+	t.data = append(t.data, data...)
+}
+
+// AppendMessage adds data to the transcript, under label.
+func (t *Transcript) AppendMessage(label string, data []byte) {
+	t.append(kindMessage, label, data)
+}
+
+// ChallengeScalar adds label to the transcript, and returns the hash of the transcript as a scalar.
+// Since the label is recorded, two successive challenges are different even if no message was added in between.
+func (t *Transcript) ChallengeScalar(label string) *ristretto.Scalar {
+	t.append(kindChallenge, label, nil)
+	var s ristretto.Scalar
+	return t.hasher.HashToScalar(&s, t.domain, t.data)
+}
+
+// Clone returns a copy of t, which can be extended independently.
+func (t *Transcript) Clone() *Transcript {
+	return &Transcript{
+		domain: t.domain,
+		hasher: t.hasher,
+		data:   append([]byte(nil), t.data...),
+	}
+}
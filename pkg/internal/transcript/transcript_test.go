@@ -0,0 +1,92 @@
+package transcript
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+// sha512Hasher is the same as eddsa.SHA512, which cannot be imported here.
+type sha512Hasher struct{}
+
+func (sha512Hasher) HashToScalar(s *ristretto.Scalar, label string, m ...[]byte) *ristretto.Scalar {
+	h := sha512.New()
+	_, _ = h.Write([]byte(label))
+	for _, b := range m {
+		_, _ = h.Write(b)
+	}
+	_, _ = s.SetUniformBytes(h.Sum(nil))
+	return s
+}
+
+func TestTranscript_ChallengeScalar(t *testing.T) {
+	tr := New("domain", sha512Hasher{})
+	tr.AppendMessage("first", []byte("message"))
+	tr.AppendMessage("empty", nil)
+	c1 := tr.ChallengeScalar("c1")
+	c2 := tr.ChallengeScalar("c2")
+
+	// The encoding of the entries, as documented
+	encoding := "6d" + "00000005" + hex.EncodeToString([]byte("first")) + "0000000000000007" + hex.EncodeToString([]byte("message")) +
+		"6d" + "00000005" + hex.EncodeToString([]byte("empty")) + "0000000000000000" +
+		"63" + "00000002" + hex.EncodeToString([]byte("c1")) + "0000000000000000"
+	data, err := hex.DecodeString(encoding)
+	require.NoError(t, err)
+	var expected ristretto.Scalar
+	sha512Hasher{}.HashToScalar(&expected, "domain", data)
+	assert.Equal(t, 1, expected.Equal(c1))
+
+	// The encoding is fixed, so the challenges must never change
+	assert.Equal(t, "83cf9208c93a7d69af69ba38784914309ce0927c8b03a292cf40d187da22b209", hex.EncodeToString(c1.Bytes()))
+	assert.Equal(t, "57acc174da5d365319a329c4adae9c222c63b44e9c40417b8573949cd91a750f", hex.EncodeToString(c2.Bytes()))
+	assert.Equal(t, 0, c1.Equal(c2), "successive challenges must differ")
+}
+
+func TestTranscript_Unambiguous(t *testing.T) {
+	challenge := func(domain string, entries ...string) *ristretto.Scalar {
+		tr := New(domain, sha512Hasher{})
+		for i := 0; i < len(entries); i += 2 {
+			tr.AppendMessage(entries[i], []byte(entries[i+1]))
+		}
+		return tr.ChallengeScalar("challenge")
+	}
+
+	reference := challenge("domain", "a", "bc")
+	for _, other := range []*ristretto.Scalar{
+		challenge("domain", "ab", "c"),
+		challenge("domain", "a", "b", "", "c"),
+		challenge("domain", "a", "bc", "", ""),
+		challenge("other", "a", "bc"),
+	} {
+		assert.Equal(t, 0, reference.Equal(other))
+	}
+	assert.Equal(t, 1, reference.Equal(challenge("domain", "a", "bc")))
+
+	// A message is not the same as a challenge with the same label
+	withMessage := New("domain", sha512Hasher{})
+	withMessage.AppendMessage("c", nil)
+	withChallenge := New("domain", sha512Hasher{})
+	_ = withChallenge.ChallengeScalar("c")
+	assert.Equal(t, 0, withMessage.ChallengeScalar("x").Equal(withChallenge.ChallengeScalar("x")))
+}
+
+func TestTranscript_Clone(t *testing.T) {
+	tr := New("domain", sha512Hasher{})
+	tr.AppendMessage("common", []byte("data"))
+
+	clone1, clone2 := tr.Clone(), tr.Clone()
+	clone1.AppendMessage("party", []byte{1})
+	clone2.AppendMessage("party", []byte{2})
+	c1 := clone1.ChallengeScalar("rho")
+	c2 := clone2.ChallengeScalar("rho")
+	assert.Equal(t, 0, c1.Equal(c2))
+
+	// The original is unchanged by its clones
+	again := tr.Clone()
+	again.AppendMessage("party", []byte{1})
+	assert.Equal(t, 1, c1.Equal(again.ChallengeScalar("rho")))
+}
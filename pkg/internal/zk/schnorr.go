@@ -1,15 +1,39 @@
 package zk
 
 import (
+	"crypto/rand"
+	"crypto/sha512"
 	"errors"
+	"fmt"
+	"io"
 
 	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
-	"github.com/taurusgroup/frost-ed25519/pkg/internal/scalar"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/transcript"
 	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
-
-	"crypto/sha512"
 )
 
+// Hasher computes the challenge of a proof. It is implemented by eddsa.Hasher,
+// which cannot be imported here since the messages package depends on this one.
+type Hasher interface {
+	HashToScalar(s *ristretto.Scalar, label string, m ...[]byte) *ristretto.Scalar
+}
+
+// defaultHasher is the same as eddsa.SHA512.
+var defaultHasher Hasher = sha512Hasher{}
+
+type sha512Hasher struct{}
+
+func (sha512Hasher) HashToScalar(s *ristretto.Scalar, label string, m ...[]byte) *ristretto.Scalar {
+	h := sha512.New()
+	_, _ = h.Write([]byte(label))
+	for _, b := range m {
+		_, _ = h.Write(b)
+	}
+	// SetUniformBytes only returns an error when the length is wrong, and the digest is 64 bytes long
+	_, _ = s.SetUniformBytes(h.Sum(nil))
+	return s
+}
+
 // Schnorr is a Non-Interactive Zero-Knowledge proof of knowledge of
 // the discrete logarithm of public = [secret] B
 //
@@ -36,20 +60,33 @@ type Schnorr struct {
 //
 // The proof returned is the tuple (S,R)
 func NewSchnorrProof(partyID party.ID, public *ristretto.Element, context []byte, private *ristretto.Scalar) *Schnorr {
+	proof, err := NewSchnorrProofFromReader(partyID, public, context, private, rand.Reader, defaultHasher)
+	if err != nil {
+		panic(fmt.Errorf("edwards25519: failed to generate random Scalar: %w", err))
+	}
+	return proof
+}
+
+// NewSchnorrProofFromReader is like NewSchnorrProof, but samples the nonce of the proof from r,
+// and computes the challenge with h.
+func NewSchnorrProofFromReader(partyID party.ID, public *ristretto.Element, context []byte, private *ristretto.Scalar, r io.Reader, h Hasher) (*Schnorr, error) {
 	var proof Schnorr
 
 	// Compute commitment for random nonce
-	k := scalar.NewScalarRandom()
+	k, err := ristretto.RandomScalar(r)
+	if err != nil {
+		return nil, err
+	}
 
 	// M = [k] B
 	var M ristretto.Element
 	M.ScalarBaseMult(k)
 
-	S := challenge(partyID, context, public, &M)
+	S := challenge(h, partyID, context, public, &M)
 	proof.S.Set(S)
 	proof.R.MultiplyAdd(private, S, k)
 
-	return &proof
+	return &proof, nil
 }
 
 // Verify verifies that the zero knowledge proof is valid.
@@ -57,36 +94,38 @@ func NewSchnorrProof(partyID party.ID, public *ristretto.Element, context []byte
 //    public is the point [private]•B
 //    context is a 32 byte context (if it is set to [0 ... 0] then we may be susceptible to replay attacks)
 func (proof *Schnorr) Verify(partyID party.ID, public *ristretto.Element, context []byte) bool {
+	return proof.VerifyWithHasher(partyID, public, context, defaultHasher)
+}
+
+// VerifyWithHasher is like Verify, but computes the challenge with h.
+func (proof *Schnorr) VerifyWithHasher(partyID party.ID, public *ristretto.Element, context []byte, h Hasher) bool {
 	var MPrime, publicNeg ristretto.Element
 
 	publicNeg.Negate(public)
 
 	MPrime.VarTimeDoubleScalarBaseMult(&proof.S, &publicNeg, &proof.R)
 
-	SPrime := challenge(partyID, context, public, &MPrime)
+	SPrime := challenge(h, partyID, context, public, &MPrime)
 
 	return proof.S.Equal(SPrime) == 1
 }
 
-// challenge computes the hash H(partyID, context, public, M), where
+// schnorrDomainSeparation is the domain of the transcript of a proof.
+const schnorrDomainSeparation = "FROST-SCHNORR"
+
+// challenge computes the hash H(partyID, context, public, M) as a transcript.Transcript, where
 //   partyID: prover's uint32 ID
 //   context: 32 byte context string,
 //   public:  [secret] B
 //   M:       [k] B
-func challenge(partyID party.ID, context []byte, public, M *ristretto.Element) *ristretto.Scalar {
+func challenge(h Hasher, partyID party.ID, context []byte, public, M *ristretto.Element) *ristretto.Scalar {
 	// S = H( ID || CTX || Public || M )
-	var S ristretto.Scalar
-
-	h := sha512.New()
-	_, _ = h.Write(partyID.Bytes())
-	_, _ = h.Write(context[:32])
-	_, _ = h.Write(public.Bytes())
-	_, _ = h.Write(M.Bytes())
-
-	buffer := make([]byte, 64)
-	// SetUniformBytes only returns an error when the length is wrong so we're okay here
-	_, _ = S.SetUniformBytes(h.Sum(buffer))
-	return &S
+	t := transcript.New(schnorrDomainSeparation, h)
+	t.AppendMessage("id", partyID.Bytes())
+	t.AppendMessage("context", context[:32])
+	t.AppendMessage("public", public.Bytes())
+	t.AppendMessage("commitment", M.Bytes())
+	return t.ChallengeScalar("challenge")
 }
 
 //
@@ -101,7 +140,7 @@ func (proof *Schnorr) MarshalBinary() (data []byte, err error) {
 
 // UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
 func (proof *Schnorr) UnmarshalBinary(data []byte) error {
-	if len(data) < 64 {
+	if len(data) != 64 {
 		return errors.New("length is wrong")
 	}
 	var err error
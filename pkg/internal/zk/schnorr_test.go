@@ -19,3 +19,19 @@ func TestSchnorrProof(t *testing.T) {
 	require.True(t, publicComputed.Equal(public) == 1)
 	require.True(t, proof.Verify(partyID, public, ctx[:]))
 }
+
+func TestSchnorrProof_Forgery(t *testing.T) {
+	var ctx [32]byte
+	partyID := party.ID(42)
+	private := scalar.NewScalarRandom()
+	public := new(ristretto.Element).ScalarBaseMult(private)
+	proof := NewSchnorrProof(partyID, public, ctx[:], private)
+	require.Equal(t, 0, proof.S.Equal(ristretto.NewScalar()), "challenge should not be zero")
+
+	// With a zero challenge, any R would be a valid proof without knowledge of private
+	var forged Schnorr
+	forged.R.Set(scalar.NewScalarRandom())
+	require.False(t, forged.Verify(partyID, public, ctx[:]))
+
+	require.False(t, proof.Verify(partyID+1, public, ctx[:]))
+}
@@ -0,0 +1,35 @@
+package messages
+
+import (
+	"bytes"
+	"testing"
+)
+
+func FuzzMessageUnmarshal(f *testing.F) {
+	for _, msg := range testMessages() {
+		data, err := msg.MarshalBinary()
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+	}
+	f.Add([]byte{})
+	f.Add([]byte{WireVersion, byte(PhaseSign), 1})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var msg Message
+		if err := msg.UnmarshalBinary(data); err != nil {
+			return
+		}
+		encoded, err := msg.MarshalBinary()
+		if err != nil {
+			t.Fatalf("failed to encode decoded message: %v", err)
+		}
+		if !bytes.Equal(data, encoded) {
+			t.Fatalf("re-encoding differs:\n%x\n%x", data, encoded)
+		}
+		if msg.Size() != len(data) {
+			t.Fatalf("Size() = %d, expected %d", msg.Size(), len(data))
+		}
+	})
+}
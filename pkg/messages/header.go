@@ -42,17 +42,18 @@ func (h *Header) UnmarshalBinary(data []byte) error {
 		return fmt.Errorf("Header.UnmarshalBinary: from: %w", err)
 	}
 	if to, err = party.FromBytes(data[1+party.IDByteSize:]); err != nil {
-		return fmt.Errorf("Header.UnmarshalBinary: from: %w", err)
+		return fmt.Errorf("Header.UnmarshalBinary: to: %w", err)
 	}
 
 	switch msgType {
-	case MessageTypeKeyGen1, MessageTypeSign1, MessageTypeSign2:
+	case MessageTypeKeyGen1, MessageTypeKeyGen3, MessageTypeSign1, MessageTypeSign2, MessageTypeRefresh1, MessageTypeReshare1,
+		MessageTypeSignBatch1, MessageTypeSignBatch2:
 		if to != 0 {
 			return errors.New("Header.UnmarshalBinary: .To field must be 0 to indicate broadcast")
 		}
-	case MessageTypeKeyGen2:
+	case MessageTypeKeyGen2, MessageTypeRefresh2, MessageTypeReshare2:
 		if to == 0 {
-			return errors.New("Header.UnmarshalBinary: MessageTypeKeyGen2 requires a sender (.To field)")
+			return errors.New("Header.UnmarshalBinary: point-to-point message requires a receiver (.To field)")
 		}
 	default:
 		return errors.New("Header.UnmarshalBinary: invalid message type")
@@ -69,13 +70,14 @@ func (h *Header) UnmarshalBinary(data []byte) error {
 
 func (h *Header) BytesAppend(existing []byte) (data []byte, err error) {
 	switch h.Type {
-	case MessageTypeKeyGen1, MessageTypeSign1, MessageTypeSign2:
+	case MessageTypeKeyGen1, MessageTypeKeyGen3, MessageTypeSign1, MessageTypeSign2, MessageTypeRefresh1, MessageTypeReshare1,
+		MessageTypeSignBatch1, MessageTypeSignBatch2:
 		if h.To != 0 {
 			return nil, errors.New("Header.BytesAppend: .To field must be 0 to indicate broadcast")
 		}
-	case MessageTypeKeyGen2:
+	case MessageTypeKeyGen2, MessageTypeRefresh2, MessageTypeReshare2:
 		if h.To == 0 {
-			return nil, errors.New("Header.BytesAppend: MessageTypeKeyGen2 requires a sender (.To field)")
+			return nil, errors.New("Header.BytesAppend: point-to-point message requires a receiver (.To field)")
 		}
 	default:
 		return nil, errors.New("Header.BytesAppend: invalid message type")
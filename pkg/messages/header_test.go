@@ -28,7 +28,17 @@ func TestHeader_UnmarshalBinary(t *testing.T) {
 				From: 1,
 				To:   0,
 			},
-			args{data: []byte{1, 0, 1, 0, 0}},
+			args{data: []byte{1, 0, 0, 0, 1, 0, 0, 0, 0}},
+			false,
+		},
+		{
+			"ok keygen2 above 16 bits",
+			fields{
+				Type: MessageTypeKeyGen2,
+				From: 70000,
+				To:   4294967295,
+			},
+			args{data: []byte{2, 0, 1, 0x11, 0x70, 255, 255, 255, 255}},
 			false,
 		},
 		{
@@ -38,7 +48,7 @@ func TestHeader_UnmarshalBinary(t *testing.T) {
 				From: 1,
 				To:   2,
 			},
-			args{data: []byte{1, 0, 1, 0, 2}},
+			args{data: []byte{1, 0, 0, 0, 1, 0, 0, 0, 2}},
 			true,
 		},
 		{
@@ -48,7 +58,7 @@ func TestHeader_UnmarshalBinary(t *testing.T) {
 				From: 2,
 				To:   1,
 			},
-			args{data: []byte{2, 0, 2, 0, 1}},
+			args{data: []byte{2, 0, 0, 0, 2, 0, 0, 0, 1}},
 			false,
 		},
 		{
@@ -58,7 +68,7 @@ func TestHeader_UnmarshalBinary(t *testing.T) {
 				From: 2,
 				To:   0,
 			},
-			args{data: []byte{2, 0, 2, 0, 0}},
+			args{data: []byte{2, 0, 0, 0, 2, 0, 0, 0, 0}},
 			true,
 		},
 		{
@@ -68,7 +78,7 @@ func TestHeader_UnmarshalBinary(t *testing.T) {
 				From: 2,
 				To:   0,
 			},
-			args{data: []byte{3, 0, 2, 0, 0}},
+			args{data: []byte{3, 0, 0, 0, 2, 0, 0, 0, 0}},
 			false,
 		},
 		{
@@ -78,7 +88,7 @@ func TestHeader_UnmarshalBinary(t *testing.T) {
 				From: 2,
 				To:   1,
 			},
-			args{data: []byte{3, 0, 2, 0, 1}},
+			args{data: []byte{3, 0, 0, 0, 2, 0, 0, 0, 1}},
 			true,
 		},
 		{
@@ -88,7 +98,7 @@ func TestHeader_UnmarshalBinary(t *testing.T) {
 				From: 2,
 				To:   0,
 			},
-			args{data: []byte{4, 0, 2, 0, 0}},
+			args{data: []byte{4, 0, 0, 0, 2, 0, 0, 0, 0}},
 			false,
 		},
 		{
@@ -98,7 +108,7 @@ func TestHeader_UnmarshalBinary(t *testing.T) {
 				From: 2,
 				To:   1,
 			},
-			args{data: []byte{4, 0, 2, 0, 1}},
+			args{data: []byte{4, 0, 0, 0, 2, 0, 0, 0, 1}},
 			true,
 		},
 		{
@@ -108,7 +118,7 @@ func TestHeader_UnmarshalBinary(t *testing.T) {
 				From: 2,
 				To:   1,
 			},
-			args{data: []byte{4, 0, 2, 0, 1}},
+			args{data: []byte{4, 0, 0, 0, 2, 0, 0, 0, 1}},
 			true,
 		},
 	}
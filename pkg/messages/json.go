@@ -0,0 +1,308 @@
+package messages
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/polynomial"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/zk"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+// jsonMessage is the JSON representation of a Message.
+// Exactly one of the payload fields is set, and its name is the Type of the message.
+// All group elements and scalars are encoded with unpadded base64url.
+type jsonMessage struct {
+	Version byte     `json:"version"`
+	Type    string   `json:"type"`
+	Round   uint8    `json:"round"`
+	From    party.ID `json:"from"`
+	To      party.ID `json:"to,omitempty"`
+
+	KeyGen1    *jsonKeyGen1     `json:"keygen1,omitempty"`
+	KeyGen2    *jsonShare       `json:"keygen2,omitempty"`
+	KeyGen3    *jsonDigest      `json:"keygen3,omitempty"`
+	Sign1      *jsonCommitment  `json:"sign1,omitempty"`
+	Sign2      *jsonSignShare   `json:"sign2,omitempty"`
+	Refresh1   *jsonPolynomial  `json:"refresh1,omitempty"`
+	Refresh2   *jsonShare       `json:"refresh2,omitempty"`
+	Reshare1   *jsonPolynomial  `json:"reshare1,omitempty"`
+	Reshare2   *jsonShare       `json:"reshare2,omitempty"`
+	SignBatch1 *jsonBatchCommit `json:"signbatch1,omitempty"`
+	SignBatch2 *jsonBatchShares `json:"signbatch2,omitempty"`
+}
+
+type (
+	jsonKeyGen1 struct {
+		Proof       jsonProof `json:"proof"`
+		Commitments []string  `json:"commitments"`
+	}
+	jsonProof struct {
+		S string `json:"s"`
+		R string `json:"r"`
+	}
+	jsonShare struct {
+		Share string `json:"share"`
+	}
+	jsonDigest struct {
+		Digest string `json:"digest"`
+	}
+	jsonCommitment struct {
+		D string `json:"d"`
+		E string `json:"e"`
+	}
+	jsonSignShare struct {
+		Z string `json:"z"`
+	}
+	jsonPolynomial struct {
+		Commitments []string `json:"commitments"`
+	}
+	jsonBatchCommit struct {
+		Commitments []jsonCommitment `json:"commitments"`
+	}
+	jsonBatchShares struct {
+		Z []string `json:"z"`
+	}
+)
+
+var messageTypeNames = map[MessageType]string{
+	MessageTypeKeyGen1:    "keygen1",
+	MessageTypeKeyGen2:    "keygen2",
+	MessageTypeKeyGen3:    "keygen3",
+	MessageTypeSign1:      "sign1",
+	MessageTypeSign2:      "sign2",
+	MessageTypeRefresh1:   "refresh1",
+	MessageTypeRefresh2:   "refresh2",
+	MessageTypeReshare1:   "reshare1",
+	MessageTypeReshare2:   "reshare2",
+	MessageTypeSignBatch1: "signbatch1",
+	MessageTypeSignBatch2: "signbatch2",
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The JSON encoding contains the same information as the binary one, and decoding it with
+// UnmarshalJSON gives a Message with the same binary encoding.
+func (m *Message) MarshalJSON() ([]byte, error) {
+	// Invalid messages are rejected in the same way as by MarshalBinary
+	if _, err := m.MarshalBinary(); err != nil {
+		return nil, err
+	}
+
+	out := jsonMessage{
+		Version: WireVersion,
+		Type:    messageTypeNames[m.Type],
+		Round:   m.Type.Round(),
+		From:    m.From,
+		To:      m.To,
+	}
+	switch m.Type {
+	case MessageTypeKeyGen1:
+		out.KeyGen1 = &jsonKeyGen1{
+			Proof:       encodeProof(m.KeyGen1.Proof),
+			Commitments: encodePolynomial(m.KeyGen1.Commitments),
+		}
+	case MessageTypeKeyGen2:
+		out.KeyGen2 = &jsonShare{Share: encodeScalar(&m.KeyGen2.Share)}
+	case MessageTypeKeyGen3:
+		out.KeyGen3 = &jsonDigest{Digest: encodeBytes(m.KeyGen3.Digest[:])}
+	case MessageTypeSign1:
+		out.Sign1 = encodeCommitment(m.Sign1)
+	case MessageTypeSign2:
+		out.Sign2 = &jsonSignShare{Z: encodeScalar(&m.Sign2.Zi)}
+	case MessageTypeRefresh1:
+		out.Refresh1 = &jsonPolynomial{Commitments: encodePolynomial(m.Refresh1.Commitments)}
+	case MessageTypeRefresh2:
+		out.Refresh2 = &jsonShare{Share: encodeScalar(&m.Refresh2.Share)}
+	case MessageTypeReshare1:
+		out.Reshare1 = &jsonPolynomial{Commitments: encodePolynomial(m.Reshare1.Commitments)}
+	case MessageTypeReshare2:
+		out.Reshare2 = &jsonShare{Share: encodeScalar(&m.Reshare2.Share)}
+	case MessageTypeSignBatch1:
+		out.SignBatch1 = &jsonBatchCommit{Commitments: make([]jsonCommitment, len(m.SignBatch1.Commitments))}
+		for i := range m.SignBatch1.Commitments {
+			out.SignBatch1.Commitments[i] = *encodeCommitment(&m.SignBatch1.Commitments[i])
+		}
+	case MessageTypeSignBatch2:
+		out.SignBatch2 = &jsonBatchShares{Z: make([]string, len(m.SignBatch2.Zi))}
+		for i := range m.SignBatch2.Zi {
+			out.SignBatch2.Z[i] = encodeScalar(&m.SignBatch2.Zi[i])
+		}
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It performs the same validation as UnmarshalBinary.
+func (m *Message) UnmarshalJSON(data []byte) error {
+	var in jsonMessage
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	if in.Version != WireVersion {
+		return fmt.Errorf("messages.UnmarshalJSON: %w %d (expected %d)", ErrUnknownVersion, in.Version, WireVersion)
+	}
+	var msgType MessageType
+	for t, name := range messageTypeNames {
+		if name == in.Type {
+			msgType = t
+		}
+	}
+	if msgType == MessageTypeNone {
+		return errors.New("messages.UnmarshalJSON: invalid message type")
+	}
+	if in.Round != msgType.Round() {
+		return fmt.Errorf("messages.UnmarshalJSON: round %d does not match the message type: %w", in.Round, ErrInvalidMessage)
+	}
+
+	// Build the binary encoding of the payload, and decode it with UnmarshalBinary
+	var (
+		body []byte
+		err  error
+	)
+	switch msgType {
+	case MessageTypeKeyGen1:
+		if in.KeyGen1 == nil {
+			return missingPayload(in.Type)
+		}
+		if body, err = decodeProof(body, &in.KeyGen1.Proof); err != nil {
+			return err
+		}
+		body, err = decodePolynomial(body, in.KeyGen1.Commitments)
+	case MessageTypeKeyGen3:
+		if in.KeyGen3 == nil {
+			return missingPayload(in.Type)
+		}
+		body, err = decodeBytes(body, in.KeyGen3.Digest)
+	case MessageTypeSign1:
+		if in.Sign1 == nil {
+			return missingPayload(in.Type)
+		}
+		body, err = decodeCommitment(body, in.Sign1)
+	case MessageTypeSign2:
+		if in.Sign2 == nil {
+			return missingPayload(in.Type)
+		}
+		body, err = decodeBytes(body, in.Sign2.Z)
+	case MessageTypeKeyGen2, MessageTypeRefresh2, MessageTypeReshare2:
+		share := map[MessageType]*jsonShare{
+			MessageTypeKeyGen2:  in.KeyGen2,
+			MessageTypeRefresh2: in.Refresh2,
+			MessageTypeReshare2: in.Reshare2,
+		}[msgType]
+		if share == nil {
+			return missingPayload(in.Type)
+		}
+		body, err = decodeBytes(body, share.Share)
+	case MessageTypeRefresh1, MessageTypeReshare1:
+		commitments := in.Refresh1
+		if msgType == MessageTypeReshare1 {
+			commitments = in.Reshare1
+		}
+		if commitments == nil {
+			return missingPayload(in.Type)
+		}
+		body, err = decodePolynomial(body, commitments.Commitments)
+	case MessageTypeSignBatch1:
+		if in.SignBatch1 == nil {
+			return missingPayload(in.Type)
+		}
+		for i := range in.SignBatch1.Commitments {
+			if body, err = decodeCommitment(body, &in.SignBatch1.Commitments[i]); err != nil {
+				break
+			}
+		}
+	case MessageTypeSignBatch2:
+		if in.SignBatch2 == nil {
+			return missingPayload(in.Type)
+		}
+		for _, z := range in.SignBatch2.Z {
+			if body, err = decodeBytes(body, z); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("messages.UnmarshalJSON: %s: %w", in.Type, err)
+	}
+
+	encoded := msgType.frameAppend(make([]byte, 0, frameSize+headerSize+len(body)))
+	encoded = append(encoded, byte(msgType))
+	encoded = append(encoded, in.From.Bytes()...)
+	encoded = append(encoded, in.To.Bytes()...)
+	encoded = append(encoded, body...)
+	return m.UnmarshalBinary(encoded)
+}
+
+func missingPayload(name string) error {
+	return fmt.Errorf("messages.UnmarshalJSON: missing %s payload: %w", name, ErrInvalidMessage)
+}
+
+func encodeBytes(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func encodeScalar(s *ristretto.Scalar) string {
+	return encodeBytes(s.Bytes())
+}
+
+func encodeCommitment(m *Sign1) *jsonCommitment {
+	return &jsonCommitment{D: encodeBytes(m.Di.Bytes()), E: encodeBytes(m.Ei.Bytes())}
+}
+
+func encodeProof(proof *zk.Schnorr) jsonProof {
+	return jsonProof{S: encodeScalar(&proof.S), R: encodeScalar(&proof.R)}
+}
+
+// encodePolynomial returns the encodings of the coefficients of p.
+func encodePolynomial(p *polynomial.Exponent) []string {
+	data, _ := p.MarshalBinary()
+	data = data[party.IDByteSize:]
+	coefficients := make([]string, 0, len(data)/32)
+	for ; len(data) > 0; data = data[32:] {
+		coefficients = append(coefficients, encodeBytes(data[:32]))
+	}
+	return coefficients
+}
+
+// decodeBytes appends the 32 bytes encoded in s to existing.
+func decodeBytes(existing []byte, s string) ([]byte, error) {
+	b, err := base64.RawURLEncoding.Strict().DecodeString(s)
+	if err != nil || len(b) != 32 {
+		return nil, ErrInvalidMessage
+	}
+	return append(existing, b...), nil
+}
+
+func decodeCommitment(existing []byte, c *jsonCommitment) ([]byte, error) {
+	existing, err := decodeBytes(existing, c.D)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBytes(existing, c.E)
+}
+
+func decodeProof(existing []byte, proof *jsonProof) ([]byte, error) {
+	existing, err := decodeBytes(existing, proof.S)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBytes(existing, proof.R)
+}
+
+// decodePolynomial appends the binary encoding of a polynomial.Exponent with the given coefficients to existing.
+func decodePolynomial(existing []byte, coefficients []string) ([]byte, error) {
+	if len(coefficients) == 0 {
+		return nil, ErrInvalidMessage
+	}
+	existing = append(existing, party.Size(len(coefficients)-1).Bytes()...)
+	var err error
+	for _, c := range coefficients {
+		if existing, err = decodeBytes(existing, c); err != nil {
+			return nil, err
+		}
+	}
+	return existing, nil
+}
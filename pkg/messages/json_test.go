@@ -0,0 +1,88 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/polynomial"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/scalar"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/zk"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+func testMessages() map[string]*Message {
+	from, to := party.ID(3), party.ID(7)
+	poly := polynomial.NewPolynomial(4, scalar.NewScalarRandom())
+	comm := polynomial.NewPolynomialExponent(poly)
+	proof := zk.NewSchnorrProof(from, comm.Constant(), make([]byte, 32), poly.Constant())
+	D := new(ristretto.Element).ScalarBaseMult(scalar.NewScalarRandom())
+	E := new(ristretto.Element).ScalarBaseMult(scalar.NewScalarRandom())
+
+	return map[string]*Message{
+		"keygen1":    NewKeyGen1(from, proof, comm),
+		"keygen2":    NewKeyGen2(from, to, scalar.NewScalarRandom()),
+		"keygen3":    NewKeyGen3(from, scalar.NewScalarRandom().Bytes()),
+		"sign1":      NewSign1(from, D, E),
+		"sign2":      NewSign2(from, scalar.NewScalarRandom()),
+		"refresh1":   NewRefresh1(from, comm),
+		"refresh2":   NewRefresh2(from, to, scalar.NewScalarRandom()),
+		"reshare1":   NewReshare1(from, comm),
+		"reshare2":   NewReshare2(from, to, scalar.NewScalarRandom()),
+		"signbatch1": NewSignBatch1(from, []Sign1{{Di: *D, Ei: *E}, {Di: *E, Ei: *D}}),
+		"signbatch2": NewSignBatch2(from, []ristretto.Scalar{*scalar.NewScalarRandom(), *scalar.NewScalarRandom()}),
+	}
+}
+
+func TestMessage_MarshalJSON(t *testing.T) {
+	for name, msg := range testMessages() {
+		t.Run(name, func(t *testing.T) {
+			data, err := json.Marshal(msg)
+			require.NoError(t, err)
+
+			var fields map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal(data, &fields))
+			assert.Equal(t, `"`+name+`"`, string(fields["type"]))
+			assert.Contains(t, fields, name)
+			assert.Equal(t, `"3"`, string(fields["from"]))
+			assert.NotContains(t, string(data), "=", "base64url should not be padded")
+
+			var decoded Message
+			require.NoError(t, json.Unmarshal(data, &decoded))
+			assert.True(t, msg.Equal(&decoded))
+
+			expected, err := msg.MarshalBinary()
+			require.NoError(t, err)
+			actual, err := decoded.MarshalBinary()
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestMessage_UnmarshalJSON_Invalid(t *testing.T) {
+	data, err := json.Marshal(NewSign2(3, scalar.NewScalarRandom()))
+	require.NoError(t, err)
+	valid := string(data)
+
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &fields))
+	z := fields["sign2"].(map[string]interface{})["z"].(string)
+
+	for name, invalid := range map[string]string{
+		"version":       strings.Replace(valid, fmt.Sprintf(`"version":%d`, WireVersion), fmt.Sprintf(`"version":%d`, WireVersion+1), 1),
+		"type":          strings.Replace(valid, `"type":"sign2"`, `"type":"sign3"`, 1),
+		"round":         strings.Replace(valid, `"round":2`, `"round":1`, 1),
+		"payload":       strings.Replace(valid, `"sign2"`, `"sign1"`, 2),
+		"short":         strings.Replace(valid, z, z[:10], 1),
+		"non-canonical": strings.Replace(valid, z, strings.Repeat("_", 42)+"w", 1),
+		"broadcast":     strings.Replace(valid, `"from":"3"`, `"from":"3","to":"4"`, 1),
+	} {
+		var msg Message
+		assert.Error(t, json.Unmarshal([]byte(invalid), &msg), name)
+	}
+}
@@ -51,6 +51,10 @@ func (m *KeyGen1) UnmarshalBinary(data []byte) error {
 		return fmt.Errorf("msg1: %w", ErrInvalidMessage)
 	}
 
+	if err := checkPolynomialDegree(MessageTypeKeyGen1, data[64:]); err != nil {
+		return err
+	}
+
 	m.Proof = &zk.Schnorr{}
 	m.Commitments = &polynomial.Exponent{}
 
@@ -41,7 +41,7 @@ func (m *KeyGen2) UnmarshalBinary(data []byte) error {
 		return fmt.Errorf("msg2: %w", ErrInvalidMessage)
 	}
 
-	_, err := m.Share.SetCanonicalBytes(data)
+	_, err := m.Share.SetCanonicalBytesConstantTime(data)
 	return err
 }
 
@@ -0,0 +1,59 @@
+package messages
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+)
+
+const sizeKeygen3 = 32
+
+type KeyGen3 struct {
+	// Digest is a hash of the sum of the commitments obtained by the sender,
+	// which determines the group key and the public shares.
+	Digest [sizeKeygen3]byte
+}
+
+func NewKeyGen3(from party.ID, digest []byte) *Message {
+	var m KeyGen3
+	copy(m.Digest[:], digest)
+	return &Message{
+		Header: Header{
+			Type: MessageTypeKeyGen3,
+			From: from,
+		},
+		KeyGen3: &m,
+	}
+}
+
+func (m *KeyGen3) BytesAppend(existing []byte) ([]byte, error) {
+	return append(existing, m.Digest[:]...), nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (m *KeyGen3) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, sizeKeygen3)
+	return m.BytesAppend(buf)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (m *KeyGen3) UnmarshalBinary(data []byte) error {
+	if len(data) != sizeKeygen3 {
+		return fmt.Errorf("msg3: %w", ErrInvalidMessage)
+	}
+	copy(m.Digest[:], data)
+	return nil
+}
+
+func (m *KeyGen3) Size() int {
+	return sizeKeygen3
+}
+
+func (m *KeyGen3) Equal(other interface{}) bool {
+	otherMsg, ok := other.(*KeyGen3)
+	if !ok {
+		return false
+	}
+	return bytes.Equal(otherMsg.Digest[:], m.Digest[:])
+}
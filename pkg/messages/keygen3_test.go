@@ -0,0 +1,22 @@
+package messages
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+)
+
+func TestKeyGen3_MarshalBinary(t *testing.T) {
+	digest := make([]byte, 32)
+	_, err := rand.Read(digest)
+	require.NoError(t, err)
+
+	msg := NewKeyGen3(party.RandID(), digest)
+
+	var msg2 Message
+	require.NoError(t, CheckFROSTMarshaler(msg, &msg2))
+	assert.Equal(t, *msg, msg2, "messages are not equal")
+}
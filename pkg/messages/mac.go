@@ -0,0 +1,69 @@
+package messages
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+)
+
+// MACSize is the size of the tags returned by Message.Sign.
+const MACSize = sha256.Size
+
+// macDomainSeparation is prepended to the encoded message when computing its tag.
+var macDomainSeparation = []byte("FROST-MESSAGE-MAC")
+
+// ErrEmptyMACKey is returned when authenticating a message with an empty key.
+var ErrEmptyMACKey = errors.New("messages: MAC key is empty")
+
+// AuthenticationError is returned by Message.Verify when the tag of a message is invalid.
+type AuthenticationError struct {
+	// From is the claimed sender of the message.
+	From party.ID
+}
+
+// Error implements error.
+func (e *AuthenticationError) Error() string {
+	return fmt.Sprintf("messages: invalid MAC for message from party %d", e.From)
+}
+
+// Sign returns an HMAC-SHA-256 tag over the encoding of the message, using key.
+// The tag covers the version and phase prefix, the header (including the sender and receiver), and the payload.
+//
+// key should be a secret shared between the sender and the receiver of the message, for example derived from
+// a pairwise session key. A broadcast message must then be signed once for every receiver.
+func (m *Message) Sign(key []byte) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, ErrEmptyMACKey
+	}
+	data, err := m.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return computeMAC(key, data), nil
+}
+
+// Verify checks that tag was produced by Sign on this message with the same key.
+// It returns an *AuthenticationError if the tag is invalid.
+func (m *Message) Verify(key, tag []byte) error {
+	if len(key) == 0 {
+		return ErrEmptyMACKey
+	}
+	data, err := m.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal(computeMAC(key, data), tag) {
+		return &AuthenticationError{From: m.From}
+	}
+	return nil
+}
+
+func computeMAC(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(macDomainSeparation)
+	_, _ = mac.Write(data)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,43 @@
+package messages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/scalar"
+)
+
+func TestMessage_Sign(t *testing.T) {
+	key := []byte("pairwise session key between 1 and 2")
+	msg := NewSign2(1, scalar.NewScalarRandom())
+
+	tag, err := msg.Sign(key)
+	require.NoError(t, err)
+	assert.Len(t, tag, MACSize)
+
+	// The receiver verifies the decoded message
+	data, err := msg.MarshalBinary()
+	require.NoError(t, err)
+	var received Message
+	require.NoError(t, received.UnmarshalBinary(data))
+	assert.NoError(t, received.Verify(key, tag))
+
+	// Tampered payload
+	received.Sign2.Zi.Add(&received.Sign2.Zi, scalar.NewScalarRandom())
+	err = received.Verify(key, tag)
+	var authErr *AuthenticationError
+	require.ErrorAs(t, err, &authErr)
+	assert.Equal(t, party.ID(1), authErr.From)
+
+	// Different sender, different key, truncated tag
+	spoofed := NewSign2(3, &msg.Sign2.Zi)
+	assert.ErrorAs(t, spoofed.Verify(key, tag), &authErr)
+	assert.Equal(t, party.ID(3), authErr.From)
+	assert.ErrorAs(t, msg.Verify([]byte("another key"), tag), &authErr)
+	assert.ErrorAs(t, msg.Verify(key, tag[:MACSize-1]), &authErr)
+
+	_, err = msg.Sign(nil)
+	assert.ErrorIs(t, err, ErrEmptyMACKey)
+}
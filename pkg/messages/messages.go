@@ -9,8 +9,18 @@ type Message struct {
 	Header
 	KeyGen1 *KeyGen1
 	KeyGen2 *KeyGen2
+	KeyGen3 *KeyGen3
 	Sign1   *Sign1
 	Sign2   *Sign2
+
+	Refresh1 *Refresh1
+	Refresh2 *Refresh2
+
+	Reshare1 *Reshare1
+	Reshare2 *Reshare2
+
+	SignBatch1 *SignBatch1
+	SignBatch2 *SignBatch2
 }
 
 var ErrInvalidMessage = errors.New("invalid message")
@@ -24,9 +34,17 @@ const (
 	MessageTypeKeyGen2
 	MessageTypeSign1
 	MessageTypeSign2
+	MessageTypeRefresh1
+	MessageTypeRefresh2
+	MessageTypeReshare1
+	MessageTypeReshare2
+	MessageTypeSignBatch1
+	MessageTypeSignBatch2
+	MessageTypeKeyGen3
 )
 
 func (m *Message) BytesAppend(existing []byte) (data []byte, err error) {
+	existing = m.Type.frameAppend(existing)
 	existing, err = m.Header.BytesAppend(existing)
 	if err != nil {
 		return nil, fmt.Errorf("message.BytesAppend: %w", err)
@@ -41,6 +59,10 @@ func (m *Message) BytesAppend(existing []byte) (data []byte, err error) {
 		if m.KeyGen2 != nil {
 			return m.KeyGen2.BytesAppend(existing)
 		}
+	case MessageTypeKeyGen3:
+		if m.KeyGen3 != nil {
+			return m.KeyGen3.BytesAppend(existing)
+		}
 	case MessageTypeSign1:
 		if m.Sign1 != nil {
 			return m.Sign1.BytesAppend(existing)
@@ -49,6 +71,30 @@ func (m *Message) BytesAppend(existing []byte) (data []byte, err error) {
 		if m.Sign2 != nil {
 			return m.Sign2.BytesAppend(existing)
 		}
+	case MessageTypeRefresh1:
+		if m.Refresh1 != nil {
+			return m.Refresh1.BytesAppend(existing)
+		}
+	case MessageTypeRefresh2:
+		if m.Refresh2 != nil {
+			return m.Refresh2.BytesAppend(existing)
+		}
+	case MessageTypeReshare1:
+		if m.Reshare1 != nil {
+			return m.Reshare1.BytesAppend(existing)
+		}
+	case MessageTypeReshare2:
+		if m.Reshare2 != nil {
+			return m.Reshare2.BytesAppend(existing)
+		}
+	case MessageTypeSignBatch1:
+		if m.SignBatch1 != nil {
+			return m.SignBatch1.BytesAppend(existing)
+		}
+	case MessageTypeSignBatch2:
+		if m.SignBatch2 != nil {
+			return m.SignBatch2.BytesAppend(existing)
+		}
 	}
 
 	return nil, errors.New("message does not contain any data")
@@ -65,6 +111,10 @@ func (m *Message) Size() int {
 		if m.KeyGen2 != nil {
 			size = m.KeyGen2.Size()
 		}
+	case MessageTypeKeyGen3:
+		if m.KeyGen3 != nil {
+			size = m.KeyGen3.Size()
+		}
 	case MessageTypeSign1:
 		if m.Sign1 != nil {
 			size = m.Sign1.Size()
@@ -73,8 +123,32 @@ func (m *Message) Size() int {
 		if m.Sign2 != nil {
 			size = m.Sign2.Size()
 		}
+	case MessageTypeRefresh1:
+		if m.Refresh1 != nil {
+			size = m.Refresh1.Size()
+		}
+	case MessageTypeRefresh2:
+		if m.Refresh2 != nil {
+			size = m.Refresh2.Size()
+		}
+	case MessageTypeReshare1:
+		if m.Reshare1 != nil {
+			size = m.Reshare1.Size()
+		}
+	case MessageTypeReshare2:
+		if m.Reshare2 != nil {
+			size = m.Reshare2.Size()
+		}
+	case MessageTypeSignBatch1:
+		if m.SignBatch1 != nil {
+			size = m.SignBatch1.Size()
+		}
+	case MessageTypeSignBatch2:
+		if m.SignBatch2 != nil {
+			size = m.SignBatch2.Size()
+		}
 	}
-	return m.Header.Size() + size
+	return frameSize + m.Header.Size() + size
 }
 
 // MarshalBinary implements the encoding.BinaryMarshaler interface.
@@ -87,10 +161,21 @@ func (m *Message) MarshalBinary() ([]byte, error) {
 func (m *Message) UnmarshalBinary(data []byte) error {
 	var err error
 
+	if len(data) > MaxMessageSize {
+		return &SizeError{Size: len(data), Max: MaxMessageSize}
+	}
+	if err = checkFrame(data); err != nil {
+		return err
+	}
+	data = data[frameSize:]
+
 	if err = m.Header.UnmarshalBinary(data); err != nil {
 		return err
 	}
 	data = data[m.Header.Size():]
+	if max := m.Type.maxPayloadSize(); len(data) > max {
+		return &SizeError{Type: m.Type, Size: len(data), Max: max}
+	}
 
 	switch m.Type {
 	case MessageTypeKeyGen1:
@@ -105,6 +190,12 @@ func (m *Message) UnmarshalBinary(data []byte) error {
 			m.KeyGen2 = &keygen2
 		}
 
+	case MessageTypeKeyGen3:
+		var keygen3 KeyGen3
+		if err = keygen3.UnmarshalBinary(data); err == nil {
+			m.KeyGen3 = &keygen3
+		}
+
 	case MessageTypeSign1:
 		var sign1 Sign1
 		if err = sign1.UnmarshalBinary(data); err == nil {
@@ -115,10 +206,44 @@ func (m *Message) UnmarshalBinary(data []byte) error {
 		if err = sign2.UnmarshalBinary(data); err == nil {
 			m.Sign2 = &sign2
 		}
+	case MessageTypeRefresh1:
+		var refresh1 Refresh1
+		if err = refresh1.UnmarshalBinary(data); err == nil {
+			m.Refresh1 = &refresh1
+		}
+	case MessageTypeRefresh2:
+		var refresh2 Refresh2
+		if err = refresh2.UnmarshalBinary(data); err == nil {
+			m.Refresh2 = &refresh2
+		}
+	case MessageTypeReshare1:
+		var reshare1 Reshare1
+		if err = reshare1.UnmarshalBinary(data); err == nil {
+			m.Reshare1 = &reshare1
+		}
+	case MessageTypeReshare2:
+		var reshare2 Reshare2
+		if err = reshare2.UnmarshalBinary(data); err == nil {
+			m.Reshare2 = &reshare2
+		}
+	case MessageTypeSignBatch1:
+		var signBatch1 SignBatch1
+		if err = signBatch1.UnmarshalBinary(data); err == nil {
+			m.SignBatch1 = &signBatch1
+		}
+	case MessageTypeSignBatch2:
+		var signBatch2 SignBatch2
+		if err = signBatch2.UnmarshalBinary(data); err == nil {
+			m.SignBatch2 = &signBatch2
+		}
 	default:
 		return errors.New("messages.UnmarshalBinary: invalid message type")
 	}
 
+	if err != nil {
+		// the header was decoded successfully, so the sender of the invalid payload is known
+		return fmt.Errorf("message from party %d: %w", m.From, err)
+	}
 	return nil
 }
 
@@ -141,6 +266,10 @@ func (m *Message) Equal(other interface{}) bool {
 		if m.KeyGen2 != nil && otherMsg.KeyGen2 != nil {
 			return m.KeyGen2.Equal(otherMsg.KeyGen2)
 		}
+	case MessageTypeKeyGen3:
+		if m.KeyGen3 != nil && otherMsg.KeyGen3 != nil {
+			return m.KeyGen3.Equal(otherMsg.KeyGen3)
+		}
 	case MessageTypeSign1:
 		if m.Sign1 != nil && otherMsg.Sign1 != nil {
 			return m.Sign1.Equal(otherMsg.Sign1)
@@ -149,6 +278,30 @@ func (m *Message) Equal(other interface{}) bool {
 		if m.Sign2 != nil && otherMsg.Sign2 != nil {
 			return m.Sign2.Equal(otherMsg.Sign2)
 		}
+	case MessageTypeRefresh1:
+		if m.Refresh1 != nil && otherMsg.Refresh1 != nil {
+			return m.Refresh1.Equal(otherMsg.Refresh1)
+		}
+	case MessageTypeRefresh2:
+		if m.Refresh2 != nil && otherMsg.Refresh2 != nil {
+			return m.Refresh2.Equal(otherMsg.Refresh2)
+		}
+	case MessageTypeReshare1:
+		if m.Reshare1 != nil && otherMsg.Reshare1 != nil {
+			return m.Reshare1.Equal(otherMsg.Reshare1)
+		}
+	case MessageTypeReshare2:
+		if m.Reshare2 != nil && otherMsg.Reshare2 != nil {
+			return m.Reshare2.Equal(otherMsg.Reshare2)
+		}
+	case MessageTypeSignBatch1:
+		if m.SignBatch1 != nil && otherMsg.SignBatch1 != nil {
+			return m.SignBatch1.Equal(otherMsg.SignBatch1)
+		}
+	case MessageTypeSignBatch2:
+		if m.SignBatch2 != nil && otherMsg.SignBatch2 != nil {
+			return m.SignBatch2.Equal(otherMsg.SignBatch2)
+		}
 	}
 	return false
 }
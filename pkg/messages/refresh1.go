@@ -0,0 +1,54 @@
+package messages
+
+import (
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/polynomial"
+)
+
+type Refresh1 struct {
+	// Commitments to a polynomial whose constant coefficient is 0
+	Commitments *polynomial.Exponent
+}
+
+func NewRefresh1(from party.ID, commitments *polynomial.Exponent) *Message {
+	return &Message{
+		Header: Header{
+			Type: MessageTypeRefresh1,
+			From: from,
+		},
+		Refresh1: &Refresh1{
+			Commitments: commitments,
+		},
+	}
+}
+
+func (m *Refresh1) BytesAppend(existing []byte) ([]byte, error) {
+	return m.Commitments.BytesAppend(existing)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (m *Refresh1) MarshalBinary() (data []byte, err error) {
+	buf := make([]byte, 0, m.Size())
+	return m.BytesAppend(buf)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (m *Refresh1) UnmarshalBinary(data []byte) error {
+	if err := checkPolynomialDegree(MessageTypeRefresh1, data); err != nil {
+		return err
+	}
+	m.Commitments = &polynomial.Exponent{}
+	return m.Commitments.UnmarshalBinary(data)
+}
+
+func (m *Refresh1) Size() int {
+	return m.Commitments.Size()
+}
+
+func (m *Refresh1) Equal(other interface{}) bool {
+	otherMsg, ok := other.(*Refresh1)
+	if !ok {
+		return false
+	}
+	return otherMsg.Commitments.Equal(m.Commitments)
+}
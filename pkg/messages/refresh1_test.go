@@ -0,0 +1,21 @@
+package messages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/polynomial"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+func TestRefresh1_MarshalBinary(t *testing.T) {
+	poly := polynomial.NewPolynomial(5, ristretto.NewScalar())
+	commitments := polynomial.NewPolynomialExponent(poly)
+
+	msg := NewRefresh1(party.ID(42), commitments)
+
+	var msgDec Message
+	require.NoError(t, CheckFROSTMarshaler(msg, &msgDec))
+	require.True(t, msg.Equal(&msgDec), "messages are not equal")
+}
@@ -0,0 +1,54 @@
+package messages
+
+import (
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/polynomial"
+)
+
+type Reshare1 struct {
+	// Commitments to a polynomial whose constant coefficient is the sender's additive share of the group key
+	Commitments *polynomial.Exponent
+}
+
+func NewReshare1(from party.ID, commitments *polynomial.Exponent) *Message {
+	return &Message{
+		Header: Header{
+			Type: MessageTypeReshare1,
+			From: from,
+		},
+		Reshare1: &Reshare1{
+			Commitments: commitments,
+		},
+	}
+}
+
+func (m *Reshare1) BytesAppend(existing []byte) ([]byte, error) {
+	return m.Commitments.BytesAppend(existing)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (m *Reshare1) MarshalBinary() (data []byte, err error) {
+	buf := make([]byte, 0, m.Size())
+	return m.BytesAppend(buf)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (m *Reshare1) UnmarshalBinary(data []byte) error {
+	if err := checkPolynomialDegree(MessageTypeReshare1, data); err != nil {
+		return err
+	}
+	m.Commitments = &polynomial.Exponent{}
+	return m.Commitments.UnmarshalBinary(data)
+}
+
+func (m *Reshare1) Size() int {
+	return m.Commitments.Size()
+}
+
+func (m *Reshare1) Equal(other interface{}) bool {
+	otherMsg, ok := other.(*Reshare1)
+	if !ok {
+		return false
+	}
+	return otherMsg.Commitments.Equal(m.Commitments)
+}
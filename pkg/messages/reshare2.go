@@ -0,0 +1,58 @@
+package messages
+
+import (
+	"fmt"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+const sizeReshare2 = 32
+
+type Reshare2 struct {
+	// Share is the evaluation of the sender's resharing polynomial for the destination party
+	Share ristretto.Scalar
+}
+
+func NewReshare2(from, to party.ID, share *ristretto.Scalar) *Message {
+	return &Message{
+		Header: Header{
+			Type: MessageTypeReshare2,
+			From: from,
+			To:   to,
+		},
+		Reshare2: &Reshare2{Share: *share},
+	}
+}
+
+func (m *Reshare2) BytesAppend(existing []byte) ([]byte, error) {
+	return append(existing, m.Share.Bytes()...), nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (m *Reshare2) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, sizeReshare2)
+	return m.BytesAppend(buf)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (m *Reshare2) UnmarshalBinary(data []byte) error {
+	if len(data) != sizeReshare2 {
+		return fmt.Errorf("reshare2: %w", ErrInvalidMessage)
+	}
+
+	_, err := m.Share.SetCanonicalBytesConstantTime(data)
+	return err
+}
+
+func (m *Reshare2) Size() int {
+	return sizeReshare2
+}
+
+func (m *Reshare2) Equal(other interface{}) bool {
+	otherMsg, ok := other.(*Reshare2)
+	if !ok {
+		return false
+	}
+	return otherMsg.Share.Equal(&m.Share) == 1
+}
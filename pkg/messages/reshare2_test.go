@@ -0,0 +1,17 @@
+package messages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/scalar"
+)
+
+func TestReshare2_MarshalBinary(t *testing.T) {
+	msg := NewReshare2(party.ID(42), party.ID(7), scalar.NewScalarRandom())
+
+	var msgDec Message
+	require.NoError(t, CheckFROSTMarshaler(msg, &msgDec))
+	require.True(t, msg.Equal(&msgDec), "messages are not equal")
+}
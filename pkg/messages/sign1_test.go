@@ -1,8 +1,10 @@
 package messages
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
 	"github.com/taurusgroup/frost-ed25519/pkg/internal/scalar"
@@ -23,3 +25,27 @@ func TestSign1_MarshalBinary(t *testing.T) {
 	require.NoError(t, CheckFROSTMarshaler(msg, &msgDec))
 	require.True(t, msg.Equal(&msgDec), "messages are not equal")
 }
+
+func TestSign1_UnmarshalBinary_NonCanonical(t *testing.T) {
+	D := new(ristretto.Element).ScalarBaseMult(scalar.NewScalarRandom())
+	E := new(ristretto.Element).ScalarBaseMult(scalar.NewScalarRandom())
+	data, err := NewSign1(42, D, E).MarshalBinary()
+	require.NoError(t, err)
+
+	nonCanonical := map[string][]byte{
+		// s >= p
+		"unreduced": bytes.Repeat([]byte{0xff}, 32),
+		// s = 1 is negative
+		"negative": append([]byte{1}, make([]byte, 31)...),
+	}
+	for name, encoding := range nonCanonical {
+		tampered := append([]byte{}, data...)
+		copy(tampered[len(tampered)-sizeSign1:], encoding)
+
+		var msg Message
+		err = msg.UnmarshalBinary(tampered)
+		require.Error(t, err, name)
+		assert.Contains(t, err.Error(), "party 42", name)
+		assert.Nil(t, msg.Sign1, name)
+	}
+}
@@ -0,0 +1,79 @@
+package messages
+
+import (
+	"fmt"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+)
+
+type SignBatch1 struct {
+	// Commitments contains the commitments (Di, Ei) of the sender for each message of the batch, in order.
+	Commitments []Sign1
+}
+
+func NewSignBatch1(from party.ID, commitments []Sign1) *Message {
+	return &Message{
+		Header: Header{
+			Type: MessageTypeSignBatch1,
+			From: from,
+		},
+		SignBatch1: &SignBatch1{
+			Commitments: commitments,
+		},
+	}
+}
+
+func (m *SignBatch1) BytesAppend(existing []byte) ([]byte, error) {
+	var err error
+	for i := range m.Commitments {
+		if existing, err = m.Commitments[i].BytesAppend(existing); err != nil {
+			return nil, err
+		}
+	}
+	return existing, nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (m *SignBatch1) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, m.Size())
+	return m.BytesAppend(buf)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (m *SignBatch1) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || len(data)%sizeSign1 != 0 {
+		return fmt.Errorf("msgBatch1: %w", ErrInvalidMessage)
+	}
+	if n := len(data) / sizeSign1; n > MaxBatchSize {
+		return &SizeError{Type: MessageTypeSignBatch1, Size: n, Max: MaxBatchSize}
+	}
+
+	m.Commitments = make([]Sign1, len(data)/sizeSign1)
+	for i := range m.Commitments {
+		if err := m.Commitments[i].UnmarshalBinary(data[:sizeSign1]); err != nil {
+			return fmt.Errorf("msgBatch1[%d]: %w", i, err)
+		}
+		data = data[sizeSign1:]
+	}
+	return nil
+}
+
+func (m *SignBatch1) Size() int {
+	return len(m.Commitments) * sizeSign1
+}
+
+func (m *SignBatch1) Equal(other interface{}) bool {
+	otherMsg, ok := other.(*SignBatch1)
+	if !ok {
+		return false
+	}
+	if len(otherMsg.Commitments) != len(m.Commitments) {
+		return false
+	}
+	for i := range m.Commitments {
+		if !m.Commitments[i].Equal(&otherMsg.Commitments[i]) {
+			return false
+		}
+	}
+	return true
+}
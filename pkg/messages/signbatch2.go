@@ -0,0 +1,75 @@
+package messages
+
+import (
+	"fmt"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+type SignBatch2 struct {
+	// Zi contains the sender's signature share for each message of the batch, in order.
+	Zi []ristretto.Scalar
+}
+
+func NewSignBatch2(from party.ID, signatureShares []ristretto.Scalar) *Message {
+	return &Message{
+		Header: Header{
+			Type: MessageTypeSignBatch2,
+			From: from,
+		},
+		SignBatch2: &SignBatch2{Zi: signatureShares},
+	}
+}
+
+func (m *SignBatch2) BytesAppend(existing []byte) ([]byte, error) {
+	for i := range m.Zi {
+		existing = append(existing, m.Zi[i].Bytes()...)
+	}
+	return existing, nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (m *SignBatch2) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, m.Size())
+	return m.BytesAppend(buf)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (m *SignBatch2) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || len(data)%sizeSign2 != 0 {
+		return fmt.Errorf("msgBatch2: %w", ErrInvalidMessage)
+	}
+	if n := len(data) / sizeSign2; n > MaxBatchSize {
+		return &SizeError{Type: MessageTypeSignBatch2, Size: n, Max: MaxBatchSize}
+	}
+
+	m.Zi = make([]ristretto.Scalar, len(data)/sizeSign2)
+	for i := range m.Zi {
+		if _, err := m.Zi[i].SetCanonicalBytes(data[:sizeSign2]); err != nil {
+			return fmt.Errorf("msgBatch2.Zi[%d]: %w", i, err)
+		}
+		data = data[sizeSign2:]
+	}
+	return nil
+}
+
+func (m *SignBatch2) Size() int {
+	return len(m.Zi) * sizeSign2
+}
+
+func (m *SignBatch2) Equal(other interface{}) bool {
+	otherMsg, ok := other.(*SignBatch2)
+	if !ok {
+		return false
+	}
+	if len(otherMsg.Zi) != len(m.Zi) {
+		return false
+	}
+	for i := range m.Zi {
+		if otherMsg.Zi[i].Equal(&m.Zi[i]) != 1 {
+			return false
+		}
+	}
+	return true
+}
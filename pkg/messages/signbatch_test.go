@@ -0,0 +1,41 @@
+package messages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/scalar"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+func TestSignBatch1_MarshalBinary(t *testing.T) {
+	commitments := make([]Sign1, 3)
+	for i := range commitments {
+		commitments[i].Di.ScalarBaseMult(scalar.NewScalarRandom())
+		commitments[i].Ei.ScalarBaseMult(scalar.NewScalarRandom())
+	}
+
+	msg := NewSignBatch1(party.ID(42), commitments)
+
+	var msgDec Message
+	require.NoError(t, CheckFROSTMarshaler(msg, &msgDec))
+	require.True(t, msg.Equal(&msgDec), "messages are not equal")
+}
+
+func TestSignBatch2_MarshalBinary(t *testing.T) {
+	shares := make([]ristretto.Scalar, 3)
+	for i := range shares {
+		shares[i].Set(scalar.NewScalarRandom())
+	}
+
+	msg := NewSignBatch2(party.ID(42), shares)
+
+	var msgDec Message
+	require.NoError(t, CheckFROSTMarshaler(msg, &msgDec))
+	require.True(t, msg.Equal(&msgDec), "messages are not equal")
+
+	var batch SignBatch2
+	require.Error(t, batch.UnmarshalBinary(make([]byte, sizeSign2+1)))
+	require.Error(t, batch.UnmarshalBinary(nil))
+}
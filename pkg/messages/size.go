@@ -0,0 +1,78 @@
+package messages
+
+import (
+	"fmt"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+)
+
+const (
+	// MaxParties is the maximum number of parties for which messages can be decoded.
+	// It bounds the number of coefficients of the polynomials in KeyGen1, Refresh1 and Reshare1.
+	MaxParties = 4096
+
+	// MaxBatchSize is the maximum number of messages that can be signed in a single batch.
+	MaxBatchSize = 1024
+
+	// maxPolynomialSize is the size of the encoding of a polynomial.Exponent of degree MaxParties-1.
+	maxPolynomialSize = party.IDByteSize + MaxParties*32
+
+	// MaxMessageSize is an upper bound on the size of any encoded Message.
+	// Transports can reject larger frames before attempting to decode them.
+	MaxMessageSize = frameSize + headerSize + 64 + maxPolynomialSize
+)
+
+// SizeError is returned when decoding a message whose payload exceeds the maximum size for its type.
+// It wraps ErrInvalidMessage.
+type SizeError struct {
+	Type MessageType
+	// Size is the size of the payload, or the number of items it declares.
+	Size int
+	// Max is the maximum allowed Size.
+	Max int
+}
+
+// Error implements error.
+func (e *SizeError) Error() string {
+	return fmt.Sprintf("message of type %d has size %d, exceeding the maximum %d", e.Type, e.Size, e.Max)
+}
+
+// Unwrap returns ErrInvalidMessage.
+func (e *SizeError) Unwrap() error {
+	return ErrInvalidMessage
+}
+
+// maxPayloadSize returns the maximum size of the payload of a message of type t.
+func (t MessageType) maxPayloadSize() int {
+	switch t {
+	case MessageTypeKeyGen1:
+		return 64 + maxPolynomialSize
+	case MessageTypeRefresh1, MessageTypeReshare1:
+		return maxPolynomialSize
+	case MessageTypeSign1:
+		return sizeSign1
+	case MessageTypeSign2, MessageTypeKeyGen2, MessageTypeRefresh2, MessageTypeReshare2:
+		return 32
+	case MessageTypeKeyGen3:
+		return sizeKeygen3
+	case MessageTypeSignBatch1:
+		return MaxBatchSize * sizeSign1
+	case MessageTypeSignBatch2:
+		return MaxBatchSize * sizeSign2
+	default:
+		return 0
+	}
+}
+
+// checkPolynomialDegree verifies that the degree prefix of an encoded polynomial.Exponent is less than MaxParties,
+// before the coefficients are allocated.
+func checkPolynomialDegree(t MessageType, data []byte) error {
+	degree, err := party.FromBytes(data)
+	if err != nil {
+		return fmt.Errorf("polynomial: %w", ErrInvalidMessage)
+	}
+	if int(degree) >= MaxParties {
+		return &SizeError{Type: t, Size: int(degree) + 1, Max: MaxParties}
+	}
+	return nil
+}
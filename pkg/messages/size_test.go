@@ -0,0 +1,60 @@
+package messages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/polynomial"
+	"github.com/taurusgroup/frost-ed25519/pkg/internal/scalar"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+// frame returns the encoding of a message of type t from party 1 to party to, with the given payload.
+func frame(t MessageType, to party.ID, payload []byte) []byte {
+	data := t.frameAppend(nil)
+	data = append(data, byte(t))
+	data = append(data, party.ID(1).Bytes()...)
+	data = append(data, to.Bytes()...)
+	return append(data, payload...)
+}
+
+func TestMessage_UnmarshalBinary_Size(t *testing.T) {
+	var sizeErr *SizeError
+
+	// Polynomials declaring more coefficients than MaxParties are rejected, even with a small payload
+	for _, degree := range []party.Size{MaxParties, 0xffff} {
+		for _, msgType := range []MessageType{MessageTypeRefresh1, MessageTypeReshare1} {
+			err := new(Message).UnmarshalBinary(frame(msgType, 0, append(degree.Bytes(), make([]byte, 64)...)))
+			require.ErrorAs(t, err, &sizeErr, "type %d degree %d", msgType, degree)
+			assert.Equal(t, MaxParties, sizeErr.Max)
+			assert.ErrorIs(t, err, ErrInvalidMessage)
+		}
+		err := new(Message).UnmarshalBinary(frame(MessageTypeKeyGen1, 0, append(make([]byte, 64), degree.Bytes()...)))
+		assert.ErrorAs(t, err, &sizeErr)
+	}
+
+	// Frames larger than MaxMessageSize are rejected before being parsed
+	err := new(Message).UnmarshalBinary(make([]byte, MaxMessageSize+1))
+	require.ErrorAs(t, err, &sizeErr)
+	assert.Equal(t, MaxMessageSize, sizeErr.Max)
+
+	// Payloads larger than the maximum for their type
+	err = new(Message).UnmarshalBinary(frame(MessageTypeSign2, 0, make([]byte, 64)))
+	require.ErrorAs(t, err, &sizeErr)
+	assert.Equal(t, MessageTypeSign2, sizeErr.Type)
+	err = new(Message).UnmarshalBinary(frame(MessageTypeSignBatch2, 0, make([]byte, (MaxBatchSize+1)*32)))
+	assert.ErrorAs(t, err, &sizeErr)
+	var batch SignBatch2
+	assert.ErrorAs(t, batch.UnmarshalBinary(make([]byte, (MaxBatchSize+1)*32)), &sizeErr)
+
+	// The largest valid messages are accepted
+	poly := polynomial.NewPolynomial(MaxParties-1, scalar.NewScalarRandom())
+	msg := NewRefresh1(1, polynomial.NewPolynomialExponent(poly))
+	require.LessOrEqual(t, msg.Size(), MaxMessageSize)
+	assert.NoError(t, CheckFROSTMarshaler(msg, new(Message)))
+
+	shares := make([]ristretto.Scalar, MaxBatchSize)
+	assert.NoError(t, CheckFROSTMarshaler(NewSignBatch2(1, shares), new(Message)))
+}
@@ -0,0 +1,97 @@
+package messages
+
+import (
+	"errors"
+	"fmt"
+)
+
+// WireVersion is the version of the encoding produced by Message.MarshalBinary.
+// It must be incremented whenever the layout of a message changes.
+const WireVersion byte = 2
+
+// frameSize is the size of the prefix version ∥ phase ∥ round of an encoded Message.
+const frameSize = 3
+
+// ErrUnknownVersion is returned when decoding a Message encoded with a different WireVersion.
+var ErrUnknownVersion = errors.New("unknown message version")
+
+// Phase identifies the protocol a message belongs to.
+type Phase uint8
+
+const (
+	PhaseNone Phase = iota
+	PhaseKeyGen
+	PhaseSign
+	PhaseRefresh
+	PhaseReshare
+)
+
+// String implements fmt.Stringer.
+func (p Phase) String() string {
+	switch p {
+	case PhaseKeyGen:
+		return "keygen"
+	case PhaseSign:
+		return "sign"
+	case PhaseRefresh:
+		return "refresh"
+	case PhaseReshare:
+		return "reshare"
+	default:
+		return fmt.Sprintf("Phase(%d)", uint8(p))
+	}
+}
+
+// Phase returns the protocol in which messages of this type are sent.
+func (t MessageType) Phase() Phase {
+	switch t {
+	case MessageTypeKeyGen1, MessageTypeKeyGen2, MessageTypeKeyGen3:
+		return PhaseKeyGen
+	case MessageTypeSign1, MessageTypeSign2, MessageTypeSignBatch1, MessageTypeSignBatch2:
+		return PhaseSign
+	case MessageTypeRefresh1, MessageTypeRefresh2:
+		return PhaseRefresh
+	case MessageTypeReshare1, MessageTypeReshare2:
+		return PhaseReshare
+	default:
+		return PhaseNone
+	}
+}
+
+// Round returns the round of the protocol in which messages of this type are sent, starting at 1.
+// It returns 0 for an invalid type.
+func (t MessageType) Round() uint8 {
+	switch t {
+	case MessageTypeKeyGen1, MessageTypeSign1, MessageTypeSignBatch1, MessageTypeRefresh1, MessageTypeReshare1:
+		return 1
+	case MessageTypeKeyGen2, MessageTypeSign2, MessageTypeSignBatch2, MessageTypeRefresh2, MessageTypeReshare2:
+		return 2
+	case MessageTypeKeyGen3:
+		return 3
+	default:
+		return 0
+	}
+}
+
+func (t MessageType) frameAppend(existing []byte) []byte {
+	return append(existing, WireVersion, byte(t.Phase()), t.Round())
+}
+
+// checkFrame verifies that the version ∥ phase ∥ round prefix of data is consistent with the type
+// of the message that follows it.
+func checkFrame(data []byte) error {
+	if len(data) < frameSize {
+		return fmt.Errorf("messages.UnmarshalBinary: %w", ErrInvalidMessage)
+	}
+	if data[0] != WireVersion {
+		return fmt.Errorf("messages.UnmarshalBinary: %w %d (expected %d)", ErrUnknownVersion, data[0], WireVersion)
+	}
+	if len(data) == frameSize {
+		return fmt.Errorf("messages.UnmarshalBinary: %w", ErrInvalidMessage)
+	}
+	msgType := MessageType(data[frameSize])
+	if phase, round := Phase(data[1]), data[2]; phase != msgType.Phase() || round != msgType.Round() {
+		return fmt.Errorf("messages.UnmarshalBinary: %s round %d does not match the message type: %w", phase, round, ErrInvalidMessage)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package messages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+func TestMessage_WireVersion(t *testing.T) {
+	// version 2 ∥ sign ∥ round 2 ∥ Sign2 header from party 2 ∥ Zi = 1
+	encoded := append([]byte{2, 2, 2, 4, 0, 0, 0, 2, 0, 0, 0, 0}, ristretto.NewScalar().SetUint64(1).Bytes()...)
+
+	var msg Message
+	require.NoError(t, msg.UnmarshalBinary(encoded))
+	assert.Equal(t, MessageTypeSign2, msg.Type)
+	assert.Equal(t, party.ID(2), msg.From)
+	require.NotNil(t, msg.Sign2)
+	assert.Equal(t, 1, msg.Sign2.Zi.Equal(ristretto.NewScalar().SetUint64(1)))
+
+	data, err := NewSign2(2, ristretto.NewScalar().SetUint64(1)).MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, encoded, data)
+
+	bumped := append([]byte{}, encoded...)
+	bumped[0] = WireVersion + 1
+	assert.ErrorIs(t, new(Message).UnmarshalBinary(bumped), ErrUnknownVersion)
+
+	// A sign message framed as keygen, or as the first round, is rejected
+	for _, frame := range [][]byte{{WireVersion, byte(PhaseKeyGen), 2}, {WireVersion, byte(PhaseSign), 1}} {
+		misrouted := append(append([]byte{}, frame...), encoded[frameSize:]...)
+		assert.ErrorIs(t, new(Message).UnmarshalBinary(misrouted), ErrInvalidMessage)
+	}
+
+	for _, truncated := range [][]byte{nil, encoded[:2], encoded[:frameSize]} {
+		assert.Error(t, new(Message).UnmarshalBinary(truncated))
+	}
+}
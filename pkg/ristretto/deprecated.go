@@ -0,0 +1,76 @@
+// Copyright 2019 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !ristretto_nodeprecated
+// +build !ristretto_nodeprecated
+
+package ristretto
+
+// This file contains the APIs which will be removed before v1.0.0.
+// Building with the ristretto_nodeprecated tag excludes them, along with the ristrettocompat package,
+// so that the compiler reports every remaining call site.
+
+// FromUniformBytes sets s to a uniformly distributed value given 64 uniformly
+// distributed random bytes.
+//
+// Deprecated: use SetUniformBytes. This API will be removed before v1.0.0.
+func (s *Scalar) FromUniformBytes(x []byte) *Scalar {
+	if _, err := s.SetUniformBytes(x); err != nil {
+		panic(err.Error())
+	}
+	return s
+}
+
+// Decode sets s = x, where x is a 32 bytes little-endian encoding of s. If x is
+// not a canonical encoding of s, Decode returns an error and the receiver is
+// unchanged.
+//
+// Deprecated: use SetCanonicalBytes. This API will be removed before v1.0.0.
+func (s *Scalar) Decode(x []byte) error {
+	_, err := s.SetCanonicalBytes(x)
+	return err
+}
+
+// Encode appends a 32 bytes little-endian encoding of s to b.
+//
+// Deprecated: use Bytes. This API will be removed before v1.0.0.
+func (s *Scalar) Encode(b []byte) []byte {
+	ret, out := sliceForAppend(b, 32)
+	copy(out, s.s.Bytes())
+	return ret
+}
+
+// Decode sets e to the decoded value of in. If in is not a 32 byte canonical
+// encoding, Decode returns an error, and the receiver is unchanged.
+//
+// Deprecated: use SetCanonicalBytes. This API will be removed before v1.0.0.
+func (e *Element) Decode(in []byte) error {
+	_, err := e.SetCanonicalBytes(in)
+	return err
+}
+
+// Encode appends the 32 bytes canonical encoding of e to b
+// and returns the result.
+//
+// Deprecated: use Bytes. This API will be removed before v1.0.0.
+func (e *Element) Encode(b []byte) []byte {
+	ret, out := sliceForAppend(b, 32)
+	e.bytes(out)
+	return ret
+}
+
+// sliceForAppend takes a slice and a requested number of bytes. It returns a
+// slice with the contents of the given slice followed by that many bytes and a
+// second slice that aliases into it and contains only the extra bytes. If the
+// original slice has sufficient capacity then no allocation is performed.
+func sliceForAppend(in []byte, n int) (head, tail []byte) {
+	if total := len(in) + n; cap(in) >= total {
+		head = in[:total]
+	} else {
+		head = make([]byte, total)
+		copy(head, in)
+	}
+	tail = head[len(in):]
+	return
+}
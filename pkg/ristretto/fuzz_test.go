@@ -0,0 +1,61 @@
+package ristretto
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"testing"
+)
+
+func FuzzSetCanonicalBytes(f *testing.F) {
+	f.Add(compressedRistrettoBasepoint)
+	f.Add(make([]byte, 32))
+	f.Add([]byte{1, 2, 3})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var e Element
+		if _, err := e.SetCanonicalBytes(data); err == nil {
+			if !bytes.Equal(e.Bytes(), data) {
+				t.Fatalf("element re-encoding differs: %x", data)
+			}
+		}
+
+		var s, ct Scalar
+		_, err := s.SetCanonicalBytes(data)
+		if err == nil {
+			if !bytes.Equal(s.Bytes(), data) {
+				t.Fatalf("scalar re-encoding differs: %x", data)
+			}
+		}
+		if _, ctErr := ct.SetCanonicalBytesConstantTime(data); (ctErr == nil) != (err == nil) || ct.Equal(&s) != 1 {
+			t.Fatalf("SetCanonicalBytesConstantTime differs from SetCanonicalBytes: %x", data)
+		}
+
+		var edwards Element
+		if _, err := edwards.SetBytesEd25519(data); err == nil {
+			if !bytes.Equal(edwards.BytesEd25519(), data) {
+				t.Fatalf("Ed25519 re-encoding differs: %x", data)
+			}
+		}
+
+		_ = new(Element).UnmarshalText(data)
+		_ = new(Scalar).UnmarshalText(data)
+		_, _ = new(Scalar).SetUniformBytes(data)
+		_, _ = new(Element).SetUniformBytes(data)
+	})
+}
+
+// FuzzScalarIdentities checks the field axioms for scalars derived from the fuzzer input.
+func FuzzScalarIdentities(f *testing.F) {
+	f.Add([]byte{}, []byte{}, []byte{})
+	f.Add([]byte{1}, []byte{2}, []byte{3})
+
+	f.Fuzz(func(t *testing.T, x, y, z []byte) {
+		scalar := func(data []byte) *Scalar {
+			digest := sha512.Sum512(data)
+			return scalarFromUniform(digest)
+		}
+		if err := checkScalarIdentities(scalar(x), scalar(y), scalar(z)); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
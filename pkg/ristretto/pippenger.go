@@ -0,0 +1,101 @@
+package ristretto
+
+import (
+	"filippo.io/edwards25519"
+)
+
+// pippengerThreshold is the number of terms from which VarTimeMultiScalarMult uses pippengerMultiScalarMult
+// instead of the Straus method of edwards25519. It was chosen with BenchmarkVarTimeMultiScalarMultPippenger,
+// where the bucket method starts being faster, and is about 25% faster for 1024 terms.
+const pippengerThreshold = 400
+
+// pippengerWindow returns the width in bits of the digits used by pippengerMultiScalarMult for n terms.
+func pippengerWindow(n int) uint {
+	switch {
+	case n < 400:
+		return 6
+	case n < 800:
+		return 7
+	default:
+		return 8
+	}
+}
+
+// pippengerMultiScalarMult sets out = sum(scalars[i] * points[i]) using the bucket method of Pippenger,
+// and returns out. The slices must have the same length.
+// The sum is accumulated in a temporary, so out may alias any of the points.
+//
+// The scalars are decomposed into signed digits d in [-2ᶜ⁻¹, 2ᶜ⁻¹), and for each digit position,
+// starting from the most significant, the points are added to one of the 2ᶜ⁻¹ buckets according to their digit.
+// The sum of ∑ [j]•bucketⱼ is then computed with 2ᶜ additions, as a sum of partial sums.
+//
+// Execution time depends on the inputs.
+func pippengerMultiScalarMult(out *edwards25519.Point, scalars []*edwards25519.Scalar, points []*edwards25519.Point) *edwards25519.Point {
+	c := pippengerWindow(len(scalars))
+	digits := make([][]int16, len(scalars))
+	for i, s := range scalars {
+		digits[i] = signedDigits(s, c)
+	}
+	windows := len(digits[0])
+
+	negated := make([]edwards25519.Point, len(points))
+	for i, p := range points {
+		negated[i].Negate(p)
+	}
+
+	buckets := make([]edwards25519.Point, 1<<(c-1))
+	var acc, sum, partial edwards25519.Point
+	acc.Set(edwards25519.NewIdentityPoint())
+	for w := windows - 1; w >= 0; w-- {
+		for k := uint(0); k < c; k++ {
+			acc.Add(&acc, &acc)
+		}
+
+		for j := range buckets {
+			buckets[j].Set(edwards25519.NewIdentityPoint())
+		}
+		for i := range points {
+			switch d := digits[i][w]; {
+			case d > 0:
+				buckets[d-1].Add(&buckets[d-1], points[i])
+			case d < 0:
+				buckets[-d-1].Add(&buckets[-d-1], &negated[i])
+			}
+		}
+
+		// ∑ [j]•bucketⱼ = ∑ₖ ∑_{j ≥ k} bucketⱼ
+		sum.Set(edwards25519.NewIdentityPoint())
+		partial.Set(edwards25519.NewIdentityPoint())
+		for j := len(buckets) - 1; j >= 0; j-- {
+			partial.Add(&partial, &buckets[j])
+			sum.Add(&sum, &partial)
+		}
+		acc.Add(&acc, &sum)
+	}
+	return out.Set(&acc)
+}
+
+// signedDigits returns the decomposition of s in base 2ᶜ, with digits in [-2ᶜ⁻¹, 2ᶜ⁻¹], least significant first.
+func signedDigits(s *edwards25519.Scalar, c uint) []int16 {
+	b := s.Bytes()
+	// s < 2²⁵³, so the last carry fits in an additional digit
+	n := (253+int(c)-1)/int(c) + 1
+	digits := make([]int16, n)
+
+	radix := int16(1) << c
+	carry := int16(0)
+	for i := 0; i < n; i++ {
+		// Read the c bits starting at bit i•c
+		var window uint32
+		bit := uint(i) * c
+		for k := uint(0); k < 3 && int(bit/8+k) < len(b); k++ {
+			window |= uint32(b[bit/8+k]) << (8 * k)
+		}
+		d := int16((window>>(bit%8))&uint32(radix-1)) + carry
+
+		// Move the digit into the signed range, carrying into the next one
+		carry = (d + radix/2) >> c
+		digits[i] = d - carry*radix
+	}
+	return digits
+}
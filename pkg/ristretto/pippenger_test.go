@@ -0,0 +1,97 @@
+package ristretto
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"filippo.io/edwards25519"
+)
+
+// unwrapTerms returns the edwards25519 representation of scalars and points.
+func unwrapTerms(scalars []*Scalar, points []*Element) ([]*edwards25519.Scalar, []*edwards25519.Point) {
+	s := make([]*edwards25519.Scalar, len(scalars))
+	p := make([]*edwards25519.Point, len(points))
+	for i := range scalars {
+		s[i] = &scalars[i].s
+		p[i] = &points[i].r
+	}
+	return s, p
+}
+
+func TestPippengerMultiScalarMult(t *testing.T) {
+	for _, n := range []int{1, 2, 7, 64, 200, 600, 1024} {
+		scalars, points := newTestTerms(n)
+
+		// Include edge case scalars
+		scalars[0] = NewScalar()
+		if n > 1 {
+			scalars[1] = NewScalar().Negate(NewScalar().SetUint64(1))
+		}
+		if n > 2 {
+			scalars[2] = NewScalar().SetUint64(1)
+		}
+
+		var expected, tmp Element
+		expected.Set(NewIdentityElement())
+		for i := range scalars {
+			expected.Add(&expected, tmp.ScalarMult(scalars[i], points[i]))
+		}
+
+		s, p := unwrapTerms(scalars, points)
+		var got Element
+		pippengerMultiScalarMult(&got.r, s, p)
+		if !bytes.Equal(got.Bytes(), expected.Bytes()) || !bytes.Equal(got.BytesEd25519(), expected.BytesEd25519()) {
+			t.Errorf("n=%d: pippengerMultiScalarMult() differs from naive accumulation", n)
+		}
+		if _, err := got.VarTimeMultiScalarMult(scalars, points); err != nil || got.Equal(&expected) != 1 {
+			t.Errorf("n=%d: VarTimeMultiScalarMult() differs from naive accumulation", n)
+		}
+	}
+}
+
+func TestSignedDigits(t *testing.T) {
+	scalars, _ := newTestTerms(32)
+	scalars = append(scalars, NewScalar(), NewScalar().SetUint64(1), NewScalar().Negate(NewScalar().SetUint64(1)))
+	for _, c := range []uint{6, 7, 8} {
+		for _, s := range scalars {
+			digits := signedDigits(&s.s, c)
+
+			var got, d Scalar
+			radix := NewScalar().SetUint64(1 << c)
+			for i := len(digits) - 1; i >= 0; i-- {
+				if digits[i] < -(1<<(c-1)) || digits[i] >= 1<<(c-1) {
+					t.Errorf("c=%d: digit %d out of range", c, digits[i])
+				}
+				if digits[i] >= 0 {
+					d.SetUint64(uint64(digits[i]))
+				} else {
+					d.Negate(d.SetUint64(uint64(-digits[i])))
+				}
+				got.MultiplyAdd(&got, radix, &d)
+			}
+			if got.Equal(s) != 1 {
+				t.Errorf("c=%d: signedDigits() does not decompose %v", c, s)
+			}
+		}
+	}
+}
+
+func BenchmarkVarTimeMultiScalarMultPippenger(b *testing.B) {
+	for _, n := range []int{64, 256, 400, 1024} {
+		scalars, points := newTestTerms(n)
+		s, p := unwrapTerms(scalars, points)
+		b.Run(fmt.Sprintf("straus/n=%d", n), func(b *testing.B) {
+			var out edwards25519.Point
+			for i := 0; i < b.N; i++ {
+				out.VarTimeMultiScalarMult(s, p)
+			}
+		})
+		b.Run(fmt.Sprintf("pippenger/n=%d", n), func(b *testing.B) {
+			var out edwards25519.Point
+			for i := 0; i < b.N; i++ {
+				pippengerMultiScalarMult(&out, s, p)
+			}
+		})
+	}
+}
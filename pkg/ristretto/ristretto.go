@@ -10,11 +10,15 @@
 // as specified in draft-hdevalence-cfrg-ristretto-01.
 //
 // All operations are constant time unless otherwise specified.
+//
+// The receiver of an Element operation may alias any of its arguments, as in e.Add(e, e):
+// the arguments are always fully read before the receiver is written.
 package ristretto
 
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 
 	"filippo.io/edwards25519"
@@ -114,10 +118,26 @@ func (e *Element) Equal(ee *Element) int {
 	return out
 }
 
+// IsIdentity returns 1 if e is equivalent to the identity element, and 0 otherwise.
+//
+// It is equivalent to e.Equal(NewIdentityElement()), and runs in constant time.
+func (e *Element) IsIdentity() int {
+	// The identity is (0, 1), so by Equal, e is equivalent to it if and only if x1 = 0 or y1 = 0.
+	X, Y, _, _ := e.r.ExtendedCoordinates()
+	var zero field.Element
+	return X.Equal(zero.Zero()) | Y.Equal(&zero)
+}
+
 // SetUniformBytes deterministically sets e to a uniformly distributed value
 // given 64 uniformly distributed random bytes.
 //
 // This can be used for hash-to-group operations or to obtain a random element.
+// The map is one-way: it is not possible to recover b from e, and the discrete
+// logarithm of e with regard to the generator is unknown. For any input, the result
+// is a valid element of the prime-order group.
+//
+// If b is not of the right length, SetUniformBytes returns nil and an error,
+// and the receiver is unchanged.
 //
 // SetUniformBytes implements the Element Derivation operation from RFC 9496,
 // Section 4.3.4.
@@ -204,31 +224,6 @@ func mapToPoint(out *edwards25519.Point, t *field.Element) {
 	}
 }
 
-// Encode appends the 32 bytes canonical encoding of e to b
-// and returns the result.
-//
-// Deprecated: use Bytes. This API will be removed before v1.0.0.
-func (e *Element) Encode(b []byte) []byte {
-	ret, out := sliceForAppend(b, 32)
-	e.bytes(out)
-	return ret
-}
-
-// sliceForAppend takes a slice and a requested number of bytes. It returns a
-// slice with the contents of the given slice followed by that many bytes and a
-// second slice that aliases into it and contains only the extra bytes. If the
-// original slice has sufficient capacity then no allocation is performed.
-func sliceForAppend(in []byte, n int) (head, tail []byte) {
-	if total := len(in) + n; cap(in) >= total {
-		head = in[:total]
-	} else {
-		head = make([]byte, total)
-		copy(head, in)
-	}
-	tail = head[len(in):]
-	return
-}
-
 // Bytes returns the 32 bytes canonical encoding of e.
 //
 // Bytes implements the Encode operation from RFC 9496, Section 4.3.2.
@@ -301,15 +296,6 @@ func (e *Element) bytes(b []byte) []byte {
 
 var errInvalidEncoding = errors.New("ristretto: invalid element encoding")
 
-// Decode sets e to the decoded value of in. If in is not a 32 byte canonical
-// encoding, Decode returns an error, and the receiver is unchanged.
-//
-// Deprecated: use SetCanonicalBytes. This API will be removed before v1.0.0.
-func (e *Element) Decode(in []byte) error {
-	_, err := e.SetCanonicalBytes(in)
-	return err
-}
-
 // SetCanonicalBytes sets e to the decoded value of in. If in is not a canonical
 // encoding of s, SetCanonicalBytes returns nil and an error and the receiver is
 // unchanged.
@@ -386,12 +372,18 @@ func (e *Element) SetCanonicalBytes(in []byte) (*Element, error) {
 }
 
 // ScalarBaseMult sets e = s * B, where B is the canonical generator, and returns e.
+//
+// It uses the precomputed table of multiples of B from edwards25519, and is several times faster than
+// ScalarMult(s, NewGeneratorElement()), which should therefore never be used instead.
+// Execution time does not depend on s.
 func (e *Element) ScalarBaseMult(s *Scalar) *Element {
 	e.r.ScalarBaseMult(&s.s)
 	return e
 }
 
 // ScalarMult sets e = s * p, and returns e.
+//
+// Execution time does not depend on s. When p is the generator, ScalarBaseMult should be used instead.
 func (e *Element) ScalarMult(s *Scalar, p *Element) *Element {
 	e.r.ScalarMult(&s.s, &p.r)
 	return e
@@ -416,10 +408,16 @@ func (e *Element) MultiScalarMult(s []*Scalar, p []*Element) *Element {
 
 // VarTimeMultiScalarMult sets e = sum(s[i] * p[i]), and returns e.
 //
-// Execution time depends on the inputs.
-func (e *Element) VarTimeMultiScalarMult(s []*Scalar, p []*Element) *Element {
+// If the lengths of s and p differ, VarTimeMultiScalarMult returns nil and an error,
+// and the receiver is unchanged.
+//
+// Execution time depends on the inputs. It must therefore only be used with public scalars,
+// such as during verification, and never with secret values such as nonces or shares.
+//
+// For large inputs, the bucket method of Pippenger is used instead of the Straus method.
+func (e *Element) VarTimeMultiScalarMult(s []*Scalar, p []*Element) (*Element, error) {
 	if len(p) != len(s) {
-		panic("ristretto: VarTimeMultiScalarMult invoked with mismatched slice lengths")
+		return nil, errors.New("ristretto: VarTimeMultiScalarMult invoked with mismatched slice lengths")
 	}
 	points := make([]*edwards25519.Point, len(p))
 	scalars := make([]*edwards25519.Scalar, len(s))
@@ -427,8 +425,12 @@ func (e *Element) VarTimeMultiScalarMult(s []*Scalar, p []*Element) *Element {
 		points[i] = &p[i].r
 		scalars[i] = &s[i].s
 	}
-	e.r.VarTimeMultiScalarMult(scalars, points)
-	return e
+	if len(s) >= pippengerThreshold {
+		pippengerMultiScalarMult(&e.r, scalars, points)
+	} else {
+		e.r.VarTimeMultiScalarMult(scalars, points)
+	}
+	return e, nil
 }
 
 // VarTimeDoubleScalarBaseMult sets e = a * A + b * B, where B is the canonical
@@ -446,7 +448,8 @@ func (e *Element) Add(p, q *Element) *Element {
 	return e
 }
 
-// Subtract sets e = p - q, and returns e.
+// Subtract sets e = p - q, and returns e. It is equivalent to adding Negate(q),
+// and lets a verification equation be checked as a single comparison with the identity.
 func (e *Element) Subtract(p, q *Element) *Element {
 	e.r.Subtract(&p.r, &q.r)
 	return e
@@ -458,18 +461,75 @@ func (e *Element) Negate(p *Element) *Element {
 	return e
 }
 
-// MarshalText implements encoding/TextMarshaler interface
+// Double sets e = x + x, and returns e.
+func (e *Element) Double(x *Element) *Element {
+	e.r.Add(&x.r, &x.r)
+	return e
+}
+
+// MultByCofactor sets e = [8]x, and returns e.
+//
+// Unlike on edwards25519, where it clears the small-order component of a point, multiplication by the cofactor
+// has nothing to clear on ristretto255, since all elements are in the prime-order group. It is the same as
+// multiplication by the scalar 8, which is invertible modulo l: e is the identity if and only if x is.
+func (e *Element) MultByCofactor(x *Element) *Element {
+	e.r.MultByCofactor(&x.r)
+	return e
+}
+
+// MarshalText implements encoding/TextMarshaler interface.
+// It returns the standard base64 encoding of the 32 bytes canonical encoding of e.
 func (e *Element) MarshalText() (text []byte, err error) {
-	b := e.Encode([]byte{})
-	return []byte(base64.StdEncoding.EncodeToString(b)), nil
+	return []byte(base64.StdEncoding.EncodeToString(e.Bytes())), nil
 }
 
-// UnmarshalText implements encoding/TextMarshaler interface
+// UnmarshalText implements encoding/TextMarshaler interface.
+// If text is not the base64 encoding of a canonical encoding, UnmarshalText returns an error
+// and the receiver is unchanged.
 func (e *Element) UnmarshalText(text []byte) error {
 	eb, err := base64.StdEncoding.DecodeString(string(text))
-	if err == nil {
-		return e.Decode(eb)
+	if err != nil {
+		return err
 	}
+	_, err = e.SetCanonicalBytes(eb)
+	return err
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It returns the output of MarshalText as a JSON string.
+func (e *Element) MarshalJSON() ([]byte, error) {
+	text, err := e.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It accepts a JSON string in the format of MarshalText, and leaves the receiver unchanged when an error is returned.
+// As is the convention, null is a no-op.
+func (e *Element) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	return e.UnmarshalText([]byte(text))
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// It returns the 32 bytes canonical encoding of e.
+func (e *Element) MarshalBinary() ([]byte, error) {
+	return e.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// If data is not a 32 bytes canonical encoding, UnmarshalBinary returns an error
+// and the receiver is unchanged.
+func (e *Element) UnmarshalBinary(data []byte) error {
+	_, err := e.SetCanonicalBytes(data)
 	return err
 }
 
@@ -498,3 +558,44 @@ func (e *Element) BytesEd25519() []byte {
 
 	return p.Bytes()
 }
+
+var errNotPrimeOrder = errors.New("ristretto: Ed25519 point is not in the prime-order subgroup")
+
+// SetBytesEd25519 sets e to the decoded value of the canonical encoding of an edwards25519.Point,
+// as returned by BytesEd25519. If in is not a canonical encoding of a point of the prime-order subgroup,
+// SetBytesEd25519 returns nil and an error and the receiver is unchanged.
+func (e *Element) SetBytesEd25519(in []byte) (*Element, error) {
+	var tmp Element
+	if _, err := tmp.r.SetBytes(in); err != nil {
+		return nil, errInvalidEncoding
+	}
+	// BytesEd25519 clears the torsion component, so it only returns in if the point has none.
+	// This also rejects the non-canonical encodings accepted by edwards25519.Point.SetBytes.
+	if !bytes.Equal(tmp.BytesEd25519(), in) {
+		return nil, errNotPrimeOrder
+	}
+	e.r.Set(&tmp.r)
+	return e, nil
+}
+
+// SetBytesWithCofactorClearing sets e to [8]P, where P is the edwards25519.Point whose canonical encoding is in,
+// and returns e. The multiplication by the cofactor removes the small-order component of P, so e is an element
+// of the prime-order group even when P is not, and the identity if P has a small order.
+// If in is not the canonical encoding of a point, SetBytesWithCofactorClearing returns nil and an error,
+// and the receiver is unchanged.
+//
+// It is only meant for interoperability with sources which use non-ristretto encodings and may produce points with a
+// small-order component. Data encoded with Bytes needs no cofactor clearing, and should be decoded with SetCanonicalBytes.
+// Note that e corresponds to [8]P and not to P: SetBytesEd25519 decodes a point of the prime-order subgroup unchanged.
+func (e *Element) SetBytesWithCofactorClearing(in []byte) (*Element, error) {
+	var p edwards25519.Point
+	if _, err := p.SetBytes(in); err != nil {
+		return nil, errInvalidEncoding
+	}
+	// edwards25519.Point.SetBytes accepts some non-canonical encodings
+	if !bytes.Equal(p.Bytes(), in) {
+		return nil, errInvalidEncoding
+	}
+	e.r.MultByCofactor(&p)
+	return e, nil
+}
@@ -4,11 +4,14 @@ package ristretto
 import (
 	"bytes"
 	"crypto/sha512"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"testing"
 
+	"filippo.io/edwards25519"
 	"filippo.io/edwards25519/field"
 )
 
@@ -185,6 +188,38 @@ func TestRistrettoFromUniformBytesTestVectors(t *testing.T) {
 	}
 }
 
+func TestRistrettoSetUniformBytesValid(t *testing.T) {
+	var element, decoded Element
+	var input [64]byte
+	for i := 0; i < 64; i++ {
+		// Use both structured and hashed inputs
+		input[i] = 0xff
+		digest := sha512.Sum512(input[:])
+		for _, in := range [][]byte{input[:], digest[:]} {
+			if _, err := element.SetUniformBytes(in); err != nil {
+				t.Fatalf("#%d: SetUniformBytes() unexpected error: %v", i, err)
+			}
+			// The result must be a valid element, which can be decoded from its encoding
+			if _, err := decoded.SetCanonicalBytes(element.Bytes()); err != nil {
+				t.Fatalf("#%d: SetUniformBytes() produced an element with an invalid encoding: %v", i, err)
+			}
+			if decoded.Equal(&element) != 1 {
+				t.Errorf("#%d: decode<>encode roundtrip failed", i)
+			}
+		}
+	}
+
+	for _, l := range []int{0, 32, 63, 65} {
+		e := NewGeneratorElement()
+		if _, err := e.SetUniformBytes(make([]byte, l)); err == nil {
+			t.Errorf("SetUniformBytes() should fail on input of length %d", l)
+		}
+		if e.Equal(NewGeneratorElement()) != 1 {
+			t.Errorf("SetUniformBytes() modified the receiver on input of length %d", l)
+		}
+	}
+}
+
 func TestEquivalentFromUniformBytes(t *testing.T) {
 	inputs := []string{
 		"edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff" +
@@ -229,6 +264,115 @@ func TestMarshalElement(t *testing.T) {
 	}
 }
 
+func TestElementSetBytesEd25519(t *testing.T) {
+	xbytes := sha512.Sum512([]byte("Hello World"))
+	x, _ := new(Element).SetUniformBytes(xbytes[:])
+
+	y := new(Element)
+	if _, err := y.SetBytesEd25519(x.BytesEd25519()); err != nil {
+		t.Fatal(err)
+	}
+	if y.Equal(x) != 1 {
+		t.Error("decode succeeded, but got wrong point")
+	}
+	if !bytes.Equal(x.BytesEd25519(), y.BytesEd25519()) {
+		t.Error("decode<>encode roundtrip produced different results")
+	}
+
+	// The Ed25519 base point maps to the ristretto generator
+	if _, err := y.SetBytesEd25519(edwards25519.NewGeneratorPoint().Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if y.Equal(NewGeneratorElement()) != 1 {
+		t.Error("Ed25519 base point should decode to the generator")
+	}
+
+	// (0, -1) has order 2
+	lowOrder, _ := hex.DecodeString("ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")
+	var p edwards25519.Point
+	if _, err := p.SetBytes(lowOrder); err != nil {
+		t.Fatal(err)
+	}
+	p.Add(&p, edwards25519.NewGeneratorPoint())
+
+	// y = 1 with the sign bit set is a non-canonical encoding of the identity
+	nonCanonical := make([]byte, 32)
+	nonCanonical[0], nonCanonical[31] = 1, 0x80
+
+	for _, in := range [][]byte{lowOrder, p.Bytes(), nonCanonical, make([]byte, 31)} {
+		var z Element
+		z.Set(x)
+		if _, err := z.SetBytesEd25519(in); err == nil {
+			t.Errorf("expected %x to be rejected", in)
+		}
+		if z.Equal(x) != 1 {
+			t.Error("receiver should be unchanged on error")
+		}
+	}
+}
+
+func TestElementSetBytesWithCofactorClearing(t *testing.T) {
+	xbytes := sha512.Sum512([]byte("Hello World"))
+	x, _ := new(Element).SetUniformBytes(xbytes[:])
+	eightX := new(Element).MultByCofactor(x)
+
+	// A point of the prime-order subgroup
+	y := new(Element)
+	if _, err := y.SetBytesWithCofactorClearing(x.BytesEd25519()); err != nil {
+		t.Fatal(err)
+	}
+	if y.Equal(eightX) != 1 {
+		t.Error("expected [8]x")
+	}
+
+	// (0, -1) has order 2, and is cleared to the identity
+	lowOrder, _ := hex.DecodeString("ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")
+	if _, err := y.SetBytesWithCofactorClearing(lowOrder); err != nil {
+		t.Fatal(err)
+	}
+	if y.IsIdentity() != 1 {
+		t.Error("a small-order point should be cleared to the identity")
+	}
+
+	// x with a small-order component, which SetBytesEd25519 rejects, is cleared to [8]x
+	var p, torsion edwards25519.Point
+	if _, err := p.SetBytes(x.BytesEd25519()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := torsion.SetBytes(lowOrder); err != nil {
+		t.Fatal(err)
+	}
+	p.Add(&p, &torsion)
+	if _, err := new(Element).SetBytesEd25519(p.Bytes()); err == nil {
+		t.Fatal("SetBytesEd25519 should reject a point with a small-order component")
+	}
+	if _, err := y.SetBytesWithCofactorClearing(p.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if y.Equal(eightX) != 1 {
+		t.Error("expected the small-order component to be cleared")
+	}
+
+	// y = 1 with the sign bit set is a non-canonical encoding of the identity
+	nonCanonical := make([]byte, 32)
+	nonCanonical[0], nonCanonical[31] = 1, 0x80
+
+	// y = 2 is not on the curve
+	notOnCurve := make([]byte, 32)
+	notOnCurve[0] = 2
+
+	for _, in := range [][]byte{nonCanonical, notOnCurve, make([]byte, 31), nil} {
+		var z Element
+		z.Set(x)
+		if _, err := z.SetBytesWithCofactorClearing(in); err == nil {
+			t.Errorf("expected %x to be rejected", in)
+		}
+		if z.Equal(x) != 1 {
+			t.Error("receiver should be unchanged on error")
+		}
+	}
+}
+
 func TestElementSet(t *testing.T) {
 	// Test this, because the internal point type being hard-copyable isn't part of the spec.
 
@@ -329,3 +473,415 @@ func testConstant(t *testing.T, f *field.Element, decimal string) {
 		t.Errorf("expected %x", buf)
 	}
 }
+
+// newTestTerms returns n scalars and elements deterministically derived from their index.
+func newTestTerms(n int) ([]*Scalar, []*Element) {
+	scalars := make([]*Scalar, n)
+	points := make([]*Element, n)
+	for i := 0; i < n; i++ {
+		digest := sha512.Sum512([]byte(fmt.Sprint("point", i)))
+		points[i], _ = new(Element).SetUniformBytes(digest[:])
+		scalars[i] = newTestScalar(fmt.Sprint("scalar", i))
+	}
+	return scalars, points
+}
+
+func TestVarTimeMultiScalarMult(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 16} {
+		scalars, points := newTestTerms(n)
+
+		expected := NewIdentityElement()
+		var tmp Element
+		for i := range scalars {
+			expected.Add(expected, tmp.ScalarMult(scalars[i], points[i]))
+		}
+
+		got, err := new(Element).VarTimeMultiScalarMult(scalars, points)
+		if err != nil {
+			t.Fatalf("n=%d: VarTimeMultiScalarMult() unexpected error: %v", n, err)
+		}
+		if got.Equal(expected) != 1 {
+			t.Errorf("n=%d: VarTimeMultiScalarMult() differs from naive accumulation", n)
+		}
+		if new(Element).MultiScalarMult(scalars, points).Equal(expected) != 1 {
+			t.Errorf("n=%d: MultiScalarMult() differs from naive accumulation", n)
+		}
+	}
+
+	scalars, points := newTestTerms(3)
+	e := NewGeneratorElement()
+	if _, err := e.VarTimeMultiScalarMult(scalars[:2], points); err == nil {
+		t.Error("VarTimeMultiScalarMult() should fail with mismatched lengths")
+	}
+	if e.Equal(NewGeneratorElement()) != 1 {
+		t.Error("VarTimeMultiScalarMult() modified the receiver on error")
+	}
+}
+
+func BenchmarkVarTimeMultiScalarMult(b *testing.B) {
+	for _, n := range []int{16, 64} {
+		scalars, points := newTestTerms(n)
+		b.Run(fmt.Sprintf("multi/n=%d", n), func(b *testing.B) {
+			var e Element
+			for i := 0; i < b.N; i++ {
+				_, _ = e.VarTimeMultiScalarMult(scalars, points)
+			}
+		})
+		b.Run(fmt.Sprintf("naive/n=%d", n), func(b *testing.B) {
+			var e, tmp Element
+			for i := 0; i < b.N; i++ {
+				e.Set(NewIdentityElement())
+				for j := range scalars {
+					e.Add(&e, tmp.ScalarMult(scalars[j], points[j]))
+				}
+			}
+		})
+	}
+}
+
+func TestScalarBaseMult(t *testing.T) {
+	scalars, _ := newTestTerms(16)
+	var base, variable Element
+	for _, s := range scalars {
+		base.ScalarBaseMult(s)
+		variable.ScalarMult(s, NewGeneratorElement())
+		if base.Equal(&variable) != 1 {
+			t.Errorf("ScalarBaseMult() differs from ScalarMult() with the generator")
+		}
+	}
+	if base.ScalarBaseMult(NewScalar()).Equal(NewIdentityElement()) != 1 {
+		t.Error("ScalarBaseMult(0) should be the identity")
+	}
+	if base.ScalarBaseMult(NewScalar().SetUint64(1)).Equal(NewGeneratorElement()) != 1 {
+		t.Error("ScalarBaseMult(1) should be the generator")
+	}
+}
+
+func BenchmarkScalarBaseMult(b *testing.B) {
+	scalars, _ := newTestTerms(1)
+	s := scalars[0]
+	b.Run("base", func(b *testing.B) {
+		var e Element
+		for i := 0; i < b.N; i++ {
+			e.ScalarBaseMult(s)
+		}
+	})
+	b.Run("variable", func(b *testing.B) {
+		var e Element
+		generator := NewGeneratorElement()
+		for i := 0; i < b.N; i++ {
+			e.ScalarMult(s, generator)
+		}
+	})
+}
+
+func TestElementIsIdentity(t *testing.T) {
+	scalars, points := newTestTerms(2)
+
+	if NewIdentityElement().IsIdentity() != 1 {
+		t.Error("IsIdentity() should be 1 for the identity")
+	}
+	if new(Element).ScalarBaseMult(NewScalar()).IsIdentity() != 1 {
+		t.Error("IsIdentity() should be 1 for [0]•B")
+	}
+	var e Element
+	if e.Subtract(points[0], points[0]).IsIdentity() != 1 {
+		t.Error("IsIdentity() should be 1 for P - P")
+	}
+	if NewGeneratorElement().IsIdentity() != 0 || points[1].IsIdentity() != 0 {
+		t.Error("IsIdentity() should be 0 for other elements")
+	}
+	if e.ScalarBaseMult(scalars[0]).IsIdentity() != 0 {
+		t.Error("IsIdentity() should be 0 for a random multiple of B")
+	}
+
+	// The 2-torsion point (0, -1) is in the same ristretto255 equivalence class as the identity.
+	minusOne := make([]byte, 32)
+	minusOne[0] = 0xec
+	for i := 1; i < 31; i++ {
+		minusOne[i] = 0xff
+	}
+	minusOne[31] = 0x7f
+	if _, err := e.r.SetBytes(minusOne); err != nil {
+		t.Fatal(err)
+	}
+	if e.IsIdentity() != 1 || e.Equal(NewIdentityElement()) != 1 {
+		t.Error("IsIdentity() should be 1 for a representative of the identity")
+	}
+	if !bytes.Equal(e.Bytes(), NewIdentityElement().Bytes()) {
+		t.Error("a representative of the identity should encode as the identity")
+	}
+}
+
+func TestElementEqual(t *testing.T) {
+	scalars, points := newTestTerms(2)
+
+	if points[0].Equal(points[0]) != 1 {
+		t.Error("Equal() should be 1 for the same element")
+	}
+	if points[0].Equal(new(Element).Set(points[0])) != 1 {
+		t.Error("Equal() should be 1 for a copy")
+	}
+	if points[0].Equal(points[1]) != 0 || points[1].Equal(points[0]) != 0 {
+		t.Error("Equal() should be 0 for different elements")
+	}
+	if points[0].Equal(NewIdentityElement()) != 0 || NewIdentityElement().Equal(points[0]) != 0 {
+		t.Error("Equal() should be 0 between an element and the identity")
+	}
+	if NewIdentityElement().Equal(NewIdentityElement()) != 1 {
+		t.Error("Equal() should be 1 for the identity")
+	}
+
+	// Different computations of the same element
+	var a, b Element
+	a.ScalarBaseMult(new(Scalar).Add(scalars[0], scalars[1]))
+	b.Add(new(Element).ScalarBaseMult(scalars[0]), new(Element).ScalarBaseMult(scalars[1]))
+	if a.Equal(&b) != 1 {
+		t.Error("Equal() should be 1 for [a+b]•B and [a]•B + [b]•B")
+	}
+}
+
+func TestElementDouble(t *testing.T) {
+	_, points := newTestTerms(2)
+
+	var double, sum, e Element
+	for _, p := range append(points, NewGeneratorElement(), NewIdentityElement()) {
+		if double.Double(p).Equal(sum.Add(p, p)) != 1 {
+			t.Error("Double(P) should be equal to P + P")
+		}
+		if double.ScalarMult(NewScalar().SetUint64(2), p).Equal(&sum) != 1 {
+			t.Error("Double(P) should be equal to [2]P")
+		}
+	}
+
+	// The receiver may alias the argument
+	e.Set(points[0])
+	if e.Double(&e).Equal(sum.Add(points[0], points[0])) != 1 {
+		t.Error("Double(P) should be equal to P + P when the receiver is P")
+	}
+}
+
+func TestElementNegateSubtract(t *testing.T) {
+	_, points := newTestTerms(3)
+	identity := NewIdentityElement()
+
+	var diff, sum, neg Element
+	all := append(points, NewGeneratorElement(), NewIdentityElement())
+	for _, x := range all {
+		if sum.Add(x, neg.Negate(x)).IsIdentity() != 1 {
+			t.Error("P + (-P) should be the identity")
+		}
+		if neg.Negate(neg.Negate(x)).Equal(x) != 1 {
+			t.Error("-(-P) should be equal to P")
+		}
+		for _, y := range all {
+			if diff.Subtract(x, y).Equal(sum.Add(x, neg.Negate(y))) != 1 {
+				t.Error("Subtract(P, Q) should be equal to Add(P, Negate(Q))")
+			}
+			if diff.Add(&diff, y).Equal(x) != 1 {
+				t.Error("(P - Q) + Q should be equal to P")
+			}
+		}
+	}
+	if neg.Negate(identity).IsIdentity() != 1 {
+		t.Error("-0 should be the identity")
+	}
+	if diff.Subtract(points[0], points[0]).IsIdentity() != 1 {
+		t.Error("P - P should be the identity")
+	}
+
+	// The receiver may alias the arguments
+	expected := new(Element).Add(points[0], new(Element).Negate(points[1]))
+	e := new(Element).Set(points[0])
+	if e.Subtract(e, points[1]).Equal(expected) != 1 {
+		t.Error("Subtract(P, Q) is wrong when the receiver is P")
+	}
+	e.Set(points[1])
+	if e.Subtract(points[0], e).Equal(expected) != 1 {
+		t.Error("Subtract(P, Q) is wrong when the receiver is Q")
+	}
+	e.Set(points[0])
+	if e.Negate(e).Equal(new(Element).Negate(points[0])) != 1 {
+		t.Error("Negate(P) is wrong when the receiver is P")
+	}
+
+	// A verification equation R = [s]B - [c]A can be checked as [s]B - [c]A - R = 0
+	s, c := newTestScalar("s"), newTestScalar("c")
+	A := points[2]
+	R := new(Element).Subtract(new(Element).ScalarBaseMult(s), new(Element).ScalarMult(c, A))
+	for _, tt := range []struct {
+		R     *Element
+		valid bool
+	}{{R, true}, {points[0], false}} {
+		check := new(Element).ScalarBaseMult(s)
+		check.Subtract(check, new(Element).ScalarMult(c, A))
+		if (check.Subtract(check, tt.R).IsIdentity() == 1) != tt.valid {
+			t.Errorf("[s]B - [c]A - R should be the identity if and only if R = [s]B - [c]A")
+		}
+	}
+}
+
+func TestElementMultByCofactor(t *testing.T) {
+	_, points := newTestTerms(2)
+
+	var e, expected Element
+	for _, p := range append(points, NewGeneratorElement()) {
+		if e.MultByCofactor(p).Equal(expected.ScalarMult(NewScalar().SetUint64(8), p)) != 1 {
+			t.Error("MultByCofactor(P) should be equal to [8]P")
+		}
+		if e.IsIdentity() != 0 {
+			t.Error("MultByCofactor(P) should not be the identity for P ≠ 0")
+		}
+	}
+	if e.MultByCofactor(NewIdentityElement()).IsIdentity() != 1 {
+		t.Error("MultByCofactor(0) should be the identity")
+	}
+}
+
+// TestElementAliasing checks that every operation gives the same result when the receiver aliases its arguments.
+func TestElementAliasing(t *testing.T) {
+	_, points := newTestTerms(2)
+	x, y := points[0], points[1]
+	a, b := newTestScalar("a"), newTestScalar("b")
+
+	for _, tt := range []struct {
+		name    string
+		aliased func(e *Element) *Element
+		fresh   func(e *Element) *Element
+	}{
+		{"Add(e, e)", func(e *Element) *Element { return e.Add(e, e) }, func(e *Element) *Element { return e.Add(x, x) }},
+		{"Add(e, y)", func(e *Element) *Element { return e.Add(e, y) }, func(e *Element) *Element { return e.Add(x, y) }},
+		{"Add(y, e)", func(e *Element) *Element { return e.Add(y, e) }, func(e *Element) *Element { return e.Add(y, x) }},
+		{"Subtract(e, e)", func(e *Element) *Element { return e.Subtract(e, e) }, func(e *Element) *Element { return e.Subtract(x, x) }},
+		{"Subtract(e, y)", func(e *Element) *Element { return e.Subtract(e, y) }, func(e *Element) *Element { return e.Subtract(x, y) }},
+		{"Subtract(y, e)", func(e *Element) *Element { return e.Subtract(y, e) }, func(e *Element) *Element { return e.Subtract(y, x) }},
+		{"Negate(e)", func(e *Element) *Element { return e.Negate(e) }, func(e *Element) *Element { return e.Negate(x) }},
+		{"Double(e)", func(e *Element) *Element { return e.Double(e) }, func(e *Element) *Element { return e.Double(x) }},
+		{"MultByCofactor(e)", func(e *Element) *Element { return e.MultByCofactor(e) }, func(e *Element) *Element { return e.MultByCofactor(x) }},
+		{"Set(e)", func(e *Element) *Element { return e.Set(e) }, func(e *Element) *Element { return e.Set(x) }},
+		{"ScalarMult(a, e)", func(e *Element) *Element { return e.ScalarMult(a, e) }, func(e *Element) *Element { return e.ScalarMult(a, x) }},
+		{"VarTimeDoubleScalarBaseMult(a, e, b)",
+			func(e *Element) *Element { return e.VarTimeDoubleScalarBaseMult(a, e, b) },
+			func(e *Element) *Element { return e.VarTimeDoubleScalarBaseMult(a, x, b) }},
+		{"MultiScalarMult(s, [e, y])",
+			func(e *Element) *Element { return e.MultiScalarMult([]*Scalar{a, b}, []*Element{e, y}) },
+			func(e *Element) *Element { return e.MultiScalarMult([]*Scalar{a, b}, []*Element{x, y}) }},
+		{"MultiScalarMult(s, [e, e])",
+			func(e *Element) *Element { return e.MultiScalarMult([]*Scalar{a, b}, []*Element{e, e}) },
+			func(e *Element) *Element { return e.MultiScalarMult([]*Scalar{a, b}, []*Element{x, x}) }},
+	} {
+		expected := tt.fresh(new(Element))
+		e := new(Element).Set(x)
+		if tt.aliased(e).Equal(expected) != 1 {
+			t.Errorf("%s is wrong when e = P", tt.name)
+		}
+	}
+
+	// VarTimeMultiScalarMult, with both the Straus and the Pippenger methods
+	for _, n := range []int{2, pippengerThreshold} {
+		scalars, points := newTestTerms(n)
+		expected, err := new(Element).VarTimeMultiScalarMult(scalars, points)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, i := range []int{0, n - 1} {
+			e := new(Element).Set(points[i])
+			aliased := append([]*Element(nil), points...)
+			aliased[i] = e
+			if _, err = e.VarTimeMultiScalarMult(scalars, aliased); err != nil {
+				t.Fatal(err)
+			}
+			if e.Equal(expected) != 1 {
+				t.Errorf("VarTimeMultiScalarMult of %d terms is wrong when e = p[%d]", n, i)
+			}
+		}
+	}
+}
+
+func TestElementMarshal(t *testing.T) {
+	_, points := newTestTerms(1)
+	x := points[0]
+
+	text, err := x.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(text) != base64.StdEncoding.EncodeToString(x.Bytes()) {
+		t.Errorf("MarshalText() = %s, want the base64 of Bytes()", text)
+	}
+	var y Element
+	if err = y.UnmarshalText(text); err != nil || y.Equal(x) != 1 {
+		t.Errorf("UnmarshalText() failed to round trip: %v", err)
+	}
+
+	data, err := json.Marshal(map[string]*Element{"x": x, "identity": NewIdentityElement()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]*Element
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded["x"].Equal(x) != 1 || decoded["identity"].IsIdentity() != 1 {
+		t.Errorf("JSON failed to round trip: %s", data)
+	}
+	if direct, _ := x.MarshalJSON(); string(direct) != `"`+string(text)+`"` {
+		t.Errorf("MarshalJSON() = %s, want the MarshalText() string", direct)
+	}
+
+	binary, err := x.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	y.Set(NewIdentityElement())
+	if err = y.UnmarshalBinary(binary); err != nil || y.Equal(x) != 1 {
+		t.Errorf("UnmarshalBinary() failed to round trip: %v", err)
+	}
+
+	invalid := []struct {
+		name string
+		data []byte
+	}{
+		{"non-canonical", mustDecodeHex(t, "f3ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")},
+		{"negative", mustDecodeHex(t, "0100000000000000000000000000000000000000000000000000000000000000")},
+		{"short", x.Bytes()[:31]},
+		{"long", append(x.Bytes(), 0)},
+	}
+	for _, tt := range invalid {
+		y.Set(x)
+		encoded := base64.StdEncoding.EncodeToString(tt.data)
+		if err := y.UnmarshalText([]byte(encoded)); err == nil {
+			t.Errorf("%s: UnmarshalText() should fail", tt.name)
+		}
+		if err := json.Unmarshal([]byte(`"`+encoded+`"`), &y); err == nil {
+			t.Errorf("%s: UnmarshalJSON() should fail", tt.name)
+		}
+		if err := y.UnmarshalBinary(tt.data); err == nil {
+			t.Errorf("%s: UnmarshalBinary() should fail", tt.name)
+		}
+		if y.Equal(x) != 1 {
+			t.Errorf("%s: the receiver was modified on error", tt.name)
+		}
+	}
+	for _, data := range []string{`"not base64!"`, `42`, `""`, `{}`} {
+		y.Set(x)
+		if err := json.Unmarshal([]byte(data), &y); err == nil {
+			t.Errorf("UnmarshalJSON(%s) should fail", data)
+		}
+		if y.Equal(x) != 1 {
+			t.Errorf("UnmarshalJSON(%s) modified the receiver", data)
+		}
+	}
+
+	if err := json.Unmarshal([]byte(`null`), &y); err != nil || y.Equal(x) != 1 {
+		t.Errorf("UnmarshalJSON(null) should be a no-op")
+	}
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
@@ -0,0 +1,54 @@
+//go:build !ristretto_nodeprecated
+// +build !ristretto_nodeprecated
+
+// Package ristrettocompat provides the deprecated methods of the ristretto package as functions
+// which delegate to their replacements, as a migration aid before they are removed in v1.0.0.
+//
+// A call such as s.Decode(x) can be rewritten mechanically as ristrettocompat.ScalarDecode(s, x), and later
+// replaced by s.SetCanonicalBytes(x). This package is removed along with the deprecated methods, so it does not
+// keep code compiling after the removal: building with the ristretto_nodeprecated tag excludes both,
+// so that the compiler reports every call site which remains to be migrated, including the calls to this package.
+package ristrettocompat
+
+import "github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+
+// ScalarFromUniformBytes is the deprecated s.FromUniformBytes(x).
+// It panics if x is not 64 bytes long.
+//
+// Deprecated: use s.SetUniformBytes(x).
+func ScalarFromUniformBytes(s *ristretto.Scalar, x []byte) *ristretto.Scalar {
+	if _, err := s.SetUniformBytes(x); err != nil {
+		panic(err.Error())
+	}
+	return s
+}
+
+// ScalarDecode is the deprecated s.Decode(x).
+//
+// Deprecated: use s.SetCanonicalBytes(x).
+func ScalarDecode(s *ristretto.Scalar, x []byte) error {
+	_, err := s.SetCanonicalBytes(x)
+	return err
+}
+
+// ScalarEncode is the deprecated s.Encode(b), which appends the encoding of s to b.
+//
+// Deprecated: use append(b, s.Bytes()...).
+func ScalarEncode(s *ristretto.Scalar, b []byte) []byte {
+	return append(b, s.Bytes()...)
+}
+
+// ElementDecode is the deprecated e.Decode(in).
+//
+// Deprecated: use e.SetCanonicalBytes(in).
+func ElementDecode(e *ristretto.Element, in []byte) error {
+	_, err := e.SetCanonicalBytes(in)
+	return err
+}
+
+// ElementEncode is the deprecated e.Encode(b), which appends the encoding of e to b.
+//
+// Deprecated: use append(b, e.Bytes()...).
+func ElementEncode(e *ristretto.Element, b []byte) []byte {
+	return append(b, e.Bytes()...)
+}
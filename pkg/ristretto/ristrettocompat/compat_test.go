@@ -0,0 +1,95 @@
+//go:build !ristretto_nodeprecated
+// +build !ristretto_nodeprecated
+
+package ristrettocompat
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"testing"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+func TestScalar(t *testing.T) {
+	digest := sha512.Sum512([]byte("scalar"))
+	expected, err := ristretto.NewScalar().SetUniformBytes(digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// FromUniformBytes
+	var s, deprecated ristretto.Scalar
+	if ScalarFromUniformBytes(&s, digest[:]) != &s || s.Equal(expected) != 1 {
+		t.Error("ScalarFromUniformBytes differs from SetUniformBytes")
+	}
+	if deprecated.FromUniformBytes(digest[:]).Equal(&s) != 1 {
+		t.Error("ScalarFromUniformBytes differs from FromUniformBytes")
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("ScalarFromUniformBytes should panic on a short input")
+			}
+		}()
+		ScalarFromUniformBytes(&s, digest[:32])
+	}()
+
+	// Encode
+	prefix := []byte("prefix")
+	encoded := ScalarEncode(expected, append([]byte(nil), prefix...))
+	if !bytes.Equal(encoded, append(append([]byte(nil), prefix...), expected.Bytes()...)) {
+		t.Error("ScalarEncode differs from Bytes")
+	}
+	if !bytes.Equal(encoded, expected.Encode(append([]byte(nil), prefix...))) {
+		t.Error("ScalarEncode differs from Encode")
+	}
+
+	// Decode
+	for _, in := range [][]byte{expected.Bytes(), bytes.Repeat([]byte{0xff}, 32), expected.Bytes()[:31]} {
+		var s, canonical, deprecated ristretto.Scalar
+		err := ScalarDecode(&s, in)
+		_, canonicalErr := canonical.SetCanonicalBytes(in)
+		deprecatedErr := deprecated.Decode(in)
+		if (err == nil) != (canonicalErr == nil) || (err == nil) != (deprecatedErr == nil) {
+			t.Errorf("ScalarDecode(%x) returned %v, but SetCanonicalBytes %v and Decode %v", in, err, canonicalErr, deprecatedErr)
+		}
+		if s.Equal(&canonical) != 1 || s.Equal(&deprecated) != 1 {
+			t.Errorf("ScalarDecode(%x) decoded a different scalar", in)
+		}
+	}
+}
+
+func TestElement(t *testing.T) {
+	digest := sha512.Sum512([]byte("element"))
+	var expected ristretto.Element
+	if _, err := expected.SetUniformBytes(digest[:]); err != nil {
+		t.Fatal(err)
+	}
+
+	// Encode
+	prefix := []byte("prefix")
+	encoded := ElementEncode(&expected, append([]byte(nil), prefix...))
+	if !bytes.Equal(encoded, append(append([]byte(nil), prefix...), expected.Bytes()...)) {
+		t.Error("ElementEncode differs from Bytes")
+	}
+	if !bytes.Equal(encoded, expected.Encode(append([]byte(nil), prefix...))) {
+		t.Error("ElementEncode differs from Encode")
+	}
+
+	// Decode
+	invalid := expected.Bytes()
+	invalid[0] ^= 1
+	for _, in := range [][]byte{expected.Bytes(), invalid, expected.Bytes()[:31]} {
+		var e, canonical, deprecated ristretto.Element
+		err := ElementDecode(&e, in)
+		_, canonicalErr := canonical.SetCanonicalBytes(in)
+		deprecatedErr := deprecated.Decode(in)
+		if (err == nil) != (canonicalErr == nil) || (err == nil) != (deprecatedErr == nil) {
+			t.Errorf("ElementDecode(%x) returned %v, but SetCanonicalBytes %v and Decode %v", in, err, canonicalErr, deprecatedErr)
+		}
+		if err == nil && (e.Equal(&canonical) != 1 || e.Equal(&deprecated) != 1) {
+			t.Errorf("ElementDecode(%x) decoded a different element", in)
+		}
+	}
+}
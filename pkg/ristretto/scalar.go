@@ -4,8 +4,15 @@
 package ristretto
 
 import (
+	"crypto/subtle"
 	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"math/bits"
 
 	"filippo.io/edwards25519"
 )
@@ -20,17 +27,49 @@ type Scalar struct {
 	s edwards25519.Scalar
 }
 
+// scalarOrder is the prime order l of the ristretto255 group.
+var scalarOrder, _ = new(big.Int).SetString("7237005577332262213973186563042994240857116359379907606001950938285454250989", 10)
+
 // NewScalar returns a Scalar set to the value 0.
 func NewScalar() *Scalar {
 	return &Scalar{}
 }
 
+// RandomScalar returns a uniformly distributed Scalar, by reading 64 bytes from r
+// and reducing them with SetUniformBytes.
+//
+// If r fails to provide 64 bytes, the returned error wraps the error from r
+// (io.ErrUnexpectedEOF in the case of a short read).
+func RandomScalar(r io.Reader) (*Scalar, error) {
+	var buf [64]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return nil, fmt.Errorf("ristretto255: RandomScalar: failed to read random bytes: %w", err)
+	}
+	s, err := NewScalar().SetUniformBytes(buf[:])
+	if err != nil {
+		return nil, fmt.Errorf("ristretto255: RandomScalar: failed to reduce random bytes: %w", err)
+	}
+	return s, nil
+}
+
 // Set sets the value of s to x and returns s.
 func (s *Scalar) Set(x *Scalar) *Scalar {
 	*s = *x
 	return s
 }
 
+// SetUint64 sets s = x, and returns s.
+//
+// Since l > 2^64, the value of x is never reduced.
+func (s *Scalar) SetUint64(x uint64) *Scalar {
+	var buf [32]byte
+	binary.LittleEndian.PutUint64(buf[:], x)
+	if _, err := s.s.SetCanonicalBytes(buf[:]); err != nil {
+		panic("ristretto255: internal error: SetUint64 produced a non-canonical encoding")
+	}
+	return s
+}
+
 // Add sets s = x + y mod l and returns s.
 func (s *Scalar) Add(x, y *Scalar) *Scalar {
 	s.s.Add(&x.s, &y.s)
@@ -63,6 +102,23 @@ func (s *Scalar) MultiplyAdd(x, y, z *Scalar) *Scalar {
 	return s.Multiply(x, y).Add(s, zCopy)
 }
 
+// Pow sets s = base^exp mod l, and returns s.
+//
+// Pow uses square-and-multiply, and its execution time depends on exp, which must therefore be public.
+// It does not depend on the value of base, which may be secret.
+func (s *Scalar) Pow(base *Scalar, exp uint64) *Scalar {
+	// Make a copy of base in case it aliases s.
+	b := new(Scalar).Set(base)
+	s.SetUint64(1)
+	for i := bits.Len64(exp) - 1; i >= 0; i-- {
+		s.Multiply(s, s)
+		if (exp>>uint(i))&1 == 1 {
+			s.Multiply(s, b)
+		}
+	}
+	return s
+}
+
 // Invert sets s = 1 / x such that s * x = 1 mod l and returns s.
 //
 // If x is 0, the result is undefined.
@@ -71,15 +127,51 @@ func (s *Scalar) Invert(x *Scalar) *Scalar {
 	return s
 }
 
-// FromUniformBytes sets s to a uniformly distributed value given 64 uniformly
-// distributed random bytes.
+// BatchInvert returns a slice containing 1 / x for each x in inputs.
 //
-// Deprecated: use SetUniformBytes. This API will be removed before v1.0.0.
-func (s *Scalar) FromUniformBytes(x []byte) *Scalar {
-	if _, err := s.SetUniformBytes(x); err != nil {
-		panic(err.Error())
+// It uses Montgomery's trick so that n inversions cost a single call to Invert
+// and 3(n-1) multiplications. Elements of inputs equal to 0 have no inverse, and their
+// corresponding output is set to 0. The inputs are not modified.
+func BatchInvert(inputs []*Scalar) []*Scalar {
+	n := len(inputs)
+	outputs := make([]*Scalar, n)
+	if n == 0 {
+		return outputs
 	}
-	return s
+
+	var one Scalar
+	one.SetUint64(1)
+
+	// Replace 0 by 1 so that it does not affect the product,
+	// and remember where we did it so we can reset these outputs at the end.
+	isZero := make([]int, n)
+	values := make([]Scalar, n)
+	for i, x := range inputs {
+		isZero[i] = x.IsZero()
+		values[i].Select(&one, x, isZero[i])
+	}
+
+	// products[i] = values[0] * ... * values[i]
+	products := make([]Scalar, n)
+	products[0].Set(&values[0])
+	for i := 1; i < n; i++ {
+		products[i].Multiply(&products[i-1], &values[i])
+	}
+
+	var inv Scalar
+	inv.Invert(&products[n-1])
+	for i := n - 1; i > 0; i-- {
+		// 1/values[i] = (values[0] * ... * values[i-1]) / (values[0] * ... * values[i])
+		outputs[i] = new(Scalar).Multiply(&inv, &products[i-1])
+		inv.Multiply(&inv, &values[i])
+	}
+	outputs[0] = new(Scalar).Set(&inv)
+
+	var zero Scalar
+	for i := range outputs {
+		outputs[i].Select(&zero, outputs[i], isZero[i])
+	}
+	return outputs
 }
 
 // SetBytesWithClamping applies the buffer pruning described in RFC 8032,
@@ -125,19 +217,13 @@ func (s *Scalar) SetUniformBytes(x []byte) (*Scalar, error) {
 	return s, nil
 }
 
-// Decode sets s = x, where x is a 32 bytes little-endian encoding of s. If x is
-// not a canonical encoding of s, Decode returns an error and the receiver is
-// unchanged.
-//
-// Deprecated: use SetCanonicalBytes. This API will be removed before v1.0.0.
-func (s *Scalar) Decode(x []byte) error {
-	_, err := s.SetCanonicalBytes(x)
-	return err
-}
-
 // SetCanonicalBytes sets s = x, where x is a 32 bytes little-endian encoding of
 // s. If x is not a canonical encoding of s, SetCanonicalBytes returns nil and
 // an error and the receiver is unchanged.
+//
+// The canonicity check compares x to l byte by byte and returns at the first difference,
+// so its execution time depends on x. It must only be used with public data,
+// and SetCanonicalBytesConstantTime decodes secret scalars.
 func (s *Scalar) SetCanonicalBytes(x []byte) (*Scalar, error) {
 	if _, err := s.s.SetCanonicalBytes(x); err != nil {
 		return nil, errors.New("ristretto255: " + err.Error())
@@ -145,13 +231,39 @@ func (s *Scalar) SetCanonicalBytes(x []byte) (*Scalar, error) {
 	return s, nil
 }
 
-// Encode appends a 32 bytes little-endian encoding of s to b.
+// scalarOrderLimbs is l in little-endian 64 bit limbs.
+var scalarOrderLimbs = [4]uint64{0x5812631a5cf5d3ed, 0x14def9dea2f79cd6, 0, 0x1000000000000000}
+
+// SetCanonicalBytesConstantTime is like SetCanonicalBytes, but its execution time does not depend on
+// the value of x, only on its length, so that it can decode secret scalars such as the shares received by a party.
+// Whether x is canonical is revealed by the returned error, but nothing else about x.
 //
-// Deprecated: use Bytes. This API will be removed before v1.0.0.
-func (s *Scalar) Encode(b []byte) []byte {
-	ret, out := sliceForAppend(b, 32)
-	copy(out, s.s.Bytes())
-	return ret
+// x is canonical if x - l, computed on 64 bit limbs with bits.Sub64, borrows, and it is then decoded
+// by the constant time reduction of SetUniformBytes.
+func (s *Scalar) SetCanonicalBytesConstantTime(x []byte) (*Scalar, error) {
+	if len(x) != 32 {
+		return nil, errors.New("ristretto255: invalid scalar length")
+	}
+
+	var borrow uint64
+	for i := range scalarOrderLimbs {
+		_, borrow = bits.Sub64(binary.LittleEndian.Uint64(x[8*i:]), scalarOrderLimbs[i], borrow)
+	}
+
+	var wide [64]byte
+	copy(wide[:], x)
+	var t edwards25519.Scalar
+	// SetUniformBytes only returns an error when the length is wrong
+	_, _ = t.SetUniformBytes(wide[:])
+	for i := range wide {
+		wide[i] = 0
+	}
+
+	if borrow == 0 {
+		return nil, errors.New("ristretto255: invalid scalar encoding")
+	}
+	s.s = t
+	return s, nil
 }
 
 // Bytes returns the 32 bytes little-endian canonical encoding of s.
@@ -164,29 +276,149 @@ func (s *Scalar) Equal(u *Scalar) int {
 	return s.s.Equal(&u.s)
 }
 
+// IsZero returns 1 if s is equal to 0, and 0 otherwise.
+//
+// It runs in constant time over the canonical encoding of s.
+func (s *Scalar) IsZero() int {
+	var acc byte
+	for _, b := range s.s.Bytes() {
+		acc |= b
+	}
+	return subtle.ConstantTimeByteEq(acc, 0)
+}
+
 // Zero sets s = 0 and returns s.
 func (s *Scalar) Zero() *Scalar {
 	s.s = edwards25519.Scalar{}
 	return s
 }
 
+// Zeroize overwrites s with zeros. It should be called on secret scalars once they are no longer needed.
+//
+// This is only best-effort: the Go runtime may have copied the value elsewhere in memory,
+// for example when growing a stack, and these copies are not erased.
+func (s *Scalar) Zeroize() {
+	s.s = edwards25519.Scalar{}
+}
+
 // MarshalText implements encoding/TextMarshaler interface
 func (s *Scalar) MarshalText() (text []byte, err error) {
-	b := s.Encode([]byte{})
-	return []byte(base64.StdEncoding.EncodeToString(b)), nil
+	return []byte(base64.StdEncoding.EncodeToString(s.Bytes())), nil
 }
 
 // UnmarshalText implements encoding/TextMarshaler interface
 func (s *Scalar) UnmarshalText(text []byte) error {
 	sb, err := base64.StdEncoding.DecodeString(string(text))
-	if err == nil {
-		return s.Decode(sb)
+	if err != nil {
+		return err
 	}
+	_, err = s.SetCanonicalBytes(sb)
+	return err
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// It returns the 32 bytes little-endian canonical encoding of s.
+func (s *Scalar) MarshalBinary() ([]byte, error) {
+	return s.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// If data is not a 32 bytes canonical encoding, UnmarshalBinary returns an error
+// and the receiver is unchanged.
+func (s *Scalar) UnmarshalBinary(data []byte) error {
+	_, err := s.SetCanonicalBytes(data)
 	return err
 }
 
+// Hex returns the lowercase hexadecimal encoding of the 32 bytes little-endian
+// canonical encoding of s.
+func (s *Scalar) Hex() string {
+	return hex.EncodeToString(s.s.Bytes())
+}
+
+// SetHex sets s to the value encoded by x, the hexadecimal encoding of a 32 bytes
+// little-endian canonical encoding, as returned by Hex. Both lower and upper case are accepted.
+//
+// If x has odd length, contains non hexadecimal characters, does not encode 32 bytes,
+// or is not a canonical encoding, SetHex returns nil and an error, and the receiver is unchanged.
+func (s *Scalar) SetHex(x string) (*Scalar, error) {
+	if len(x)%2 != 0 {
+		return nil, errors.New("ristretto255: SetHex input has odd length")
+	}
+	b, err := hex.DecodeString(x)
+	if err != nil {
+		return nil, fmt.Errorf("ristretto255: SetHex input contains an invalid character: %w", err)
+	}
+	if len(b) != 32 {
+		return nil, errors.New("ristretto255: SetHex input does not encode 32 bytes")
+	}
+	if _, err = s.s.SetCanonicalBytes(b); err != nil {
+		return nil, errors.New("ristretto255: SetHex input is not a canonical encoding")
+	}
+	return s, nil
+}
+
 // String implements the Stringer interface
 func (s *Scalar) String() string {
 	result, _ := s.MarshalText()
 	return string(result)
 }
+
+// Select sets s to a if cond == 1, and to b if cond == 0, and returns s.
+//
+// The selection is performed without branching on cond, by masking the
+// canonical encodings of a and b. The result is decoded by the constant time reduction
+// of SetUniformBytes, as in SetCanonicalBytesConstantTime, so that the execution time
+// does not depend on the selected value either. Select panics if cond is not 0 or 1.
+func (s *Scalar) Select(a, b *Scalar, cond int) *Scalar {
+	if cond != 0 && cond != 1 {
+		panic("ristretto255: Select invoked with cond not in {0, 1}")
+	}
+	aBytes, bBytes := a.s.Bytes(), b.s.Bytes()
+	mask := byte(-cond)
+	var wide [64]byte
+	for i := range aBytes {
+		wide[i] = bBytes[i] ^ (mask & (aBytes[i] ^ bBytes[i]))
+	}
+	// SetUniformBytes only returns an error when the length is wrong
+	_, _ = s.s.SetUniformBytes(wide[:])
+	for i := range wide {
+		wide[i] = 0
+	}
+	return s
+}
+
+// SetBigInt sets s = x mod l, and returns s.
+//
+// The input may be negative or larger than l, in which case it is reduced to its
+// canonical representative in [0, l). If x is nil, SetBigInt returns nil and an error,
+// and the receiver is unchanged.
+func (s *Scalar) SetBigInt(x *big.Int) (*Scalar, error) {
+	if x == nil {
+		return nil, errors.New("ristretto255: SetBigInt input is nil")
+	}
+	// big.Int.Mod implements Euclidean modulus, so the result is always non-negative.
+	reduced := new(big.Int).Mod(x, scalarOrder)
+	buf := reduced.FillBytes(make([]byte, 32))
+	reverseBytes(buf)
+	if _, err := s.s.SetCanonicalBytes(buf); err != nil {
+		return nil, errors.New("ristretto255: " + err.Error())
+	}
+	return s, nil
+}
+
+// BigInt returns the canonical representative of s in [0, l).
+//
+// The returned big.Int is a fresh allocation, and may be modified by the caller.
+func (s *Scalar) BigInt() *big.Int {
+	buf := s.s.Bytes()
+	reverseBytes(buf)
+	return new(big.Int).SetBytes(buf)
+}
+
+// reverseBytes reverses b in place, converting between little- and big-endian.
+func reverseBytes(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
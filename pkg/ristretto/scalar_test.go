@@ -0,0 +1,643 @@
+package ristretto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+// newTestScalar returns a Scalar deterministically derived from label.
+func newTestScalar(label string) *Scalar {
+	digest := sha512.Sum512([]byte(label))
+	s, _ := NewScalar().SetUniformBytes(digest[:])
+	return s
+}
+
+func TestScalarSelect(t *testing.T) {
+	a := newTestScalar("a")
+	b := newTestScalar("b")
+
+	s := NewScalar()
+	if s.Select(a, b, 1).Equal(a) != 1 {
+		t.Error("Select(a, b, 1) should return a")
+	}
+	if s.Select(a, b, 0).Equal(b) != 1 {
+		t.Error("Select(a, b, 0) should return b")
+	}
+
+	// Values near l are selected unchanged
+	nearOrder := NewScalar().Subtract(NewScalar(), NewScalar().SetUint64(1))
+	if s.Select(nearOrder, a, 1).Equal(nearOrder) != 1 || s.Select(a, nearOrder, 0).Equal(nearOrder) != 1 {
+		t.Error("Select should return l - 1")
+	}
+
+	// The receiver may alias one of the arguments
+	aCopy := new(Scalar).Set(a)
+	if aCopy.Select(aCopy, b, 0).Equal(b) != 1 {
+		t.Error("Select with an aliased receiver should return b")
+	}
+
+	for _, cond := range []int{-1, 2, 42} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Select should panic for cond = %d", cond)
+				}
+			}()
+			NewScalar().Select(a, b, cond)
+		}()
+	}
+}
+
+func BenchmarkScalarSelect(b *testing.B) {
+	// l - 1 shares all but its lowest byte with l, so a decoding which compares the result
+	// to l byte by byte would take longer when selecting it than when selecting 1.
+	small := NewScalar().SetUint64(1)
+	nearOrder := NewScalar().Subtract(NewScalar(), small)
+	s := NewScalar()
+
+	// Both branches execute the same sequence of operations,
+	// so the timings reported below should match.
+	b.Run("cond=0", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s.Select(nearOrder, small, 0)
+		}
+	})
+	b.Run("cond=1", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s.Select(nearOrder, small, 1)
+		}
+	})
+}
+
+func TestScalarSetCanonicalBytesConstantTime(t *testing.T) {
+	// lBytes returns the 32 byte little-endian encoding of l + offset
+	lBytes := func(offset int64) []byte {
+		be := new(big.Int).Add(scalarOrder, big.NewInt(offset)).Bytes()
+		out := make([]byte, 32)
+		for i := range be {
+			out[i] = be[len(be)-1-i]
+		}
+		return out
+	}
+	fieldOrder := append([]byte{0xed}, bytes.Repeat([]byte{0xff}, 30)...)
+	fieldOrder = append(fieldOrder, 0x7f)
+
+	for _, tt := range []struct {
+		name      string
+		in        []byte
+		canonical bool
+	}{
+		{"zero", make([]byte, 32), true},
+		{"l - 1", lBytes(-1), true},
+		{"l", lBytes(0), false},
+		{"l + 1", lBytes(1), false},
+		{"2^255 - 19", fieldOrder, false},
+		{"all ones", bytes.Repeat([]byte{0xff}, 32), false},
+		{"random", newTestScalar("random").Bytes(), true},
+		{"short", make([]byte, 31), false},
+		{"long", make([]byte, 33), false},
+	} {
+		s := new(Scalar).Set(newTestScalar("receiver"))
+		out, err := s.SetCanonicalBytesConstantTime(tt.in)
+
+		var expected Scalar
+		_, expectedErr := expected.SetCanonicalBytes(tt.in)
+		if (expectedErr == nil) != tt.canonical {
+			t.Fatalf("%s: SetCanonicalBytes returned %v", tt.name, expectedErr)
+		}
+		if !tt.canonical {
+			if err == nil || out != nil {
+				t.Errorf("%s: expected an error", tt.name)
+			}
+			if s.Equal(newTestScalar("receiver")) != 1 {
+				t.Errorf("%s: the receiver was modified", tt.name)
+			}
+			continue
+		}
+		if err != nil || out != s {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		}
+		if s.Equal(&expected) != 1 || !bytes.Equal(s.Bytes(), tt.in) {
+			t.Errorf("%s: decoded %x", tt.name, s.Bytes())
+		}
+	}
+}
+
+func BenchmarkScalarSetCanonicalBytesConstantTime(b *testing.B) {
+	canonical := newTestScalar("x").Bytes()
+	nonCanonical := bytes.Repeat([]byte{0xff}, 32)
+	s := NewScalar()
+
+	// Unlike SetCanonicalBytes, which returns at the first byte differing from l,
+	// both inputs execute the same sequence of operations.
+	b.Run("canonical", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = s.SetCanonicalBytesConstantTime(canonical)
+		}
+	})
+	b.Run("non-canonical", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = s.SetCanonicalBytesConstantTime(nonCanonical)
+		}
+	})
+}
+
+// scalarFromUniform returns the Scalar reduced from b, generated by testing/quick.
+func scalarFromUniform(b [64]byte) *Scalar {
+	s, _ := NewScalar().SetUniformBytes(b[:])
+	return s
+}
+
+// checkScalarIdentities returns an error describing the first field axiom which does not hold for x, y and z.
+func checkScalarIdentities(x, y, z *Scalar) error {
+	zero, one := NewScalar(), NewScalar().SetUint64(1)
+	for _, tt := range []struct {
+		name        string
+		left, right *Scalar
+	}{
+		{"x + y = y + x", new(Scalar).Add(x, y), new(Scalar).Add(y, x)},
+		{"x * y = y * x", new(Scalar).Multiply(x, y), new(Scalar).Multiply(y, x)},
+		{"(x + y) + z = x + (y + z)", new(Scalar).Add(new(Scalar).Add(x, y), z), new(Scalar).Add(x, new(Scalar).Add(y, z))},
+		{"(x * y) * z = x * (y * z)", new(Scalar).Multiply(new(Scalar).Multiply(x, y), z), new(Scalar).Multiply(x, new(Scalar).Multiply(y, z))},
+		{"x * (y + z) = x * y + x * z", new(Scalar).Multiply(x, new(Scalar).Add(y, z)), new(Scalar).Add(new(Scalar).Multiply(x, y), new(Scalar).Multiply(x, z))},
+		{"x + (-x) = 0", new(Scalar).Add(x, new(Scalar).Negate(x)), zero},
+		{"x - y = x + (-y)", new(Scalar).Subtract(x, y), new(Scalar).Add(x, new(Scalar).Negate(y))},
+		{"x + 0 = x", new(Scalar).Add(x, zero), x},
+		{"x * 1 = x", new(Scalar).Multiply(x, one), x},
+		{"MultiplyAdd(x, y, z) = x * y + z", new(Scalar).MultiplyAdd(x, y, z), new(Scalar).Add(new(Scalar).Multiply(x, y), z)},
+	} {
+		if tt.left.Equal(tt.right) != 1 {
+			return fmt.Errorf("%s does not hold for x = %x, y = %x, z = %x", tt.name, x.Bytes(), y.Bytes(), z.Bytes())
+		}
+	}
+	if x.IsZero() == 0 {
+		if new(Scalar).Multiply(x, new(Scalar).Invert(x)).Equal(one) != 1 {
+			return fmt.Errorf("x * x⁻¹ = 1 does not hold for x = %x", x.Bytes())
+		}
+	}
+
+	// The receiver of MultiplyAdd may alias any of its arguments
+	expected := new(Scalar).Add(new(Scalar).Multiply(x, y), z)
+	for i, alias := range []func(s *Scalar) *Scalar{
+		func(s *Scalar) *Scalar { return s.Set(z).MultiplyAdd(x, y, s) },
+		func(s *Scalar) *Scalar { return s.Set(x).MultiplyAdd(s, y, z) },
+		func(s *Scalar) *Scalar { return s.Set(y).MultiplyAdd(x, s, z) },
+	} {
+		if alias(NewScalar()).Equal(expected) != 1 {
+			return fmt.Errorf("MultiplyAdd with receiver aliasing argument %d is wrong for x = %x, y = %x, z = %x", i, x.Bytes(), y.Bytes(), z.Bytes())
+		}
+	}
+	return nil
+}
+
+func TestScalarIdentities(t *testing.T) {
+	f := func(x, y, z [64]byte) bool {
+		if err := checkScalarIdentities(scalarFromUniform(x), scalarFromUniform(y), scalarFromUniform(z)); err != nil {
+			t.Log(err)
+			return false
+		}
+		return true
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Error(err)
+	}
+
+	// Edge cases, which random scalars almost never hit
+	zero, one, minusOne := NewScalar(), NewScalar().SetUint64(1), NewScalar().Negate(NewScalar().SetUint64(1))
+	for _, x := range []*Scalar{zero, one, minusOne} {
+		for _, y := range []*Scalar{zero, one, minusOne, newTestScalar("y")} {
+			if err := checkScalarIdentities(x, y, newTestScalar("z")); err != nil {
+				t.Error(err)
+			}
+		}
+	}
+}
+
+func TestScalarBigInt(t *testing.T) {
+	l, ok := new(big.Int).SetString("7237005577332262213973186563042994240857116359379907606001950938285454250989", 10)
+	if !ok {
+		t.Fatal("invalid decimal")
+	}
+	one := big.NewInt(1)
+	lMinusOne := new(big.Int).Sub(l, one)
+
+	tests := []struct {
+		name string
+		in   *big.Int
+		want *big.Int
+	}{
+		{"0", big.NewInt(0), big.NewInt(0)},
+		{"1", one, one},
+		{"l-1", lMinusOne, lMinusOne},
+		{"l", l, big.NewInt(0)},
+		{"l+1", new(big.Int).Add(l, one), one},
+		{"-1", big.NewInt(-1), lMinusOne},
+		{"-l", new(big.Int).Neg(l), big.NewInt(0)},
+		{"2^512", new(big.Int).Lsh(one, 512), new(big.Int).Mod(new(big.Int).Lsh(one, 512), l)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewScalar().SetBigInt(tt.in)
+			if err != nil {
+				t.Fatalf("SetBigInt() unexpected error: %v", err)
+			}
+			got := s.BigInt()
+			if got.Cmp(tt.want) != 0 {
+				t.Errorf("BigInt() = %v, want %v", got, tt.want)
+			}
+			// Round trip through the canonical representative
+			s2, err := NewScalar().SetBigInt(got)
+			if err != nil {
+				t.Fatalf("SetBigInt() unexpected error: %v", err)
+			}
+			if s2.Equal(s) != 1 {
+				t.Error("round trip produced a different scalar")
+			}
+		})
+	}
+
+	// -1 and l-1 denote the same field element
+	minusOne := NewScalar().Subtract(NewScalar(), newTestScalarUint(1))
+	fromBig, _ := NewScalar().SetBigInt(big.NewInt(-1))
+	if fromBig.Equal(minusOne) != 1 {
+		t.Error("SetBigInt(-1) should equal 0 - 1")
+	}
+
+	// The returned value is a fresh allocation
+	s := newTestScalar("fresh")
+	b1 := s.BigInt()
+	b1.SetInt64(0)
+	if s.BigInt().Sign() == 0 {
+		t.Error("modifying the result of BigInt() should not affect the scalar")
+	}
+
+	if _, err := NewScalar().SetBigInt(nil); err == nil {
+		t.Error("SetBigInt(nil) should return an error")
+	}
+}
+
+// newTestScalarUint returns the Scalar with value x < 256.
+func newTestScalarUint(x byte) *Scalar {
+	b := make([]byte, 32)
+	b[0] = x
+	s, _ := NewScalar().SetCanonicalBytes(b)
+	return s
+}
+
+func TestBatchInvert(t *testing.T) {
+	one := newTestScalarUint(1)
+
+	inputs := make([]*Scalar, 10)
+	for i := range inputs {
+		inputs[i] = newTestScalar(fmt.Sprint(i))
+	}
+	// Insert some zeros, including at both ends
+	inputs[0] = NewScalar()
+	inputs[4] = NewScalar()
+	inputs[9] = NewScalar()
+	inputsCopy := make([]Scalar, len(inputs))
+	for i := range inputs {
+		inputsCopy[i].Set(inputs[i])
+	}
+
+	outputs := BatchInvert(inputs)
+	if len(outputs) != len(inputs) {
+		t.Fatalf("BatchInvert() returned %d elements, want %d", len(outputs), len(inputs))
+	}
+	for i := range inputs {
+		if inputs[i].Equal(&inputsCopy[i]) != 1 {
+			t.Errorf("#%d: input was modified", i)
+		}
+		if inputs[i].Equal(NewScalar()) == 1 {
+			if outputs[i].Equal(NewScalar()) != 1 {
+				t.Errorf("#%d: inverse of 0 should be 0", i)
+			}
+			continue
+		}
+		if new(Scalar).Multiply(inputs[i], outputs[i]).Equal(one) != 1 {
+			t.Errorf("#%d: x * BatchInvert(x) != 1", i)
+		}
+		if new(Scalar).Invert(inputs[i]).Equal(outputs[i]) != 1 {
+			t.Errorf("#%d: BatchInvert(x) != Invert(x)", i)
+		}
+	}
+
+	if len(BatchInvert(nil)) != 0 {
+		t.Error("BatchInvert(nil) should return an empty slice")
+	}
+	if out := BatchInvert([]*Scalar{NewScalar()}); out[0].Equal(NewScalar()) != 1 {
+		t.Error("BatchInvert([0]) should return [0]")
+	}
+}
+
+func BenchmarkBatchInvert(b *testing.B) {
+	for _, n := range []int{3, 16, 64} {
+		inputs := make([]*Scalar, n)
+		for i := range inputs {
+			inputs[i] = newTestScalar(fmt.Sprint(i))
+		}
+		b.Run(fmt.Sprintf("batch/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				BatchInvert(inputs)
+			}
+		})
+		b.Run(fmt.Sprintf("naive/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				outputs := make([]*Scalar, n)
+				for j := range inputs {
+					outputs[j] = new(Scalar).Invert(inputs[j])
+				}
+			}
+		})
+	}
+}
+
+func TestScalarSetUint64(t *testing.T) {
+	tests := []uint64{0, 1, 2, 1 << 32, math.MaxUint64}
+	for _, x := range tests {
+		s := NewScalar().SetUint64(x)
+		want := new(big.Int).SetUint64(x)
+		if got := s.BigInt(); got.Cmp(want) != 0 {
+			t.Errorf("SetUint64(%d) = %v", x, got)
+		}
+	}
+
+	// 2^32 = 2^16 * 2^16
+	x := NewScalar().SetUint64(1 << 16)
+	x.Multiply(x, x)
+	if x.Equal(NewScalar().SetUint64(1<<32)) != 1 {
+		t.Error("SetUint64(2^16)^2 != SetUint64(2^32)")
+	}
+
+	// max + 1 = 2^64 = 2^32 * 2^32
+	max := NewScalar().SetUint64(math.MaxUint64)
+	max.Add(max, NewScalar().SetUint64(1))
+	y := NewScalar().SetUint64(1 << 32)
+	y.Multiply(y, y)
+	if max.Equal(y) != 1 {
+		t.Error("SetUint64(2^64-1) + 1 != 2^64")
+	}
+}
+
+func TestScalarMarshalBinary(t *testing.T) {
+	x := newTestScalar("binary")
+	data, err := x.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() unexpected error: %v", err)
+	}
+	if !bytes.Equal(data, x.Bytes()) {
+		t.Error("MarshalBinary() should return the canonical encoding")
+	}
+
+	var y Scalar
+	if err = y.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() unexpected error: %v", err)
+	}
+	if y.Equal(x) != 1 {
+		t.Error("round trip produced a different scalar")
+	}
+
+	// l is not a canonical encoding
+	nonCanonical := []byte{
+		0xed, 0xd3, 0xf5, 0x5c, 0x1a, 0x63, 0x12, 0x58,
+		0xd6, 0x9c, 0xf7, 0xa2, 0xde, 0xf9, 0xde, 0x14,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+	}
+	invalid := map[string][]byte{
+		"nil":           nil,
+		"short":         data[:31],
+		"long":          append(append([]byte{}, data...), 0),
+		"non canonical": nonCanonical,
+	}
+	for name, in := range invalid {
+		y.Set(x)
+		if err = y.UnmarshalBinary(in); err == nil {
+			t.Errorf("%s: UnmarshalBinary() should fail", name)
+		}
+		if y.Equal(x) != 1 {
+			t.Errorf("%s: receiver was modified on error", name)
+		}
+	}
+}
+
+func TestScalarGob(t *testing.T) {
+	type session struct {
+		Nonce *Scalar
+		Share Scalar
+	}
+	in := session{
+		Nonce: newTestScalar("nonce"),
+		Share: *newTestScalar("share"),
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&in); err != nil {
+		t.Fatalf("gob encode: %v", err)
+	}
+	var out session
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("gob decode: %v", err)
+	}
+	if out.Nonce.Equal(in.Nonce) != 1 || out.Share.Equal(&in.Share) != 1 {
+		t.Error("gob round trip produced different scalars")
+	}
+}
+
+func TestScalarIsZero(t *testing.T) {
+	if NewScalar().IsZero() != 1 {
+		t.Error("NewScalar() should be zero")
+	}
+	var zeroValue Scalar
+	if zeroValue.IsZero() != 1 {
+		t.Error("the zero value should be zero")
+	}
+	if newTestScalar("nonzero").IsZero() != 0 {
+		t.Error("a random scalar should not be zero")
+	}
+	if NewScalar().SetUint64(1<<40).IsZero() != 0 {
+		t.Error("2^40 should not be zero")
+	}
+
+	// l, as a 64 byte wide input, reduces to zero
+	wide := make([]byte, 64)
+	copy(wide, []byte{
+		0xed, 0xd3, 0xf5, 0x5c, 0x1a, 0x63, 0x12, 0x58,
+		0xd6, 0x9c, 0xf7, 0xa2, 0xde, 0xf9, 0xde, 0x14,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+	})
+	l, _ := NewScalar().SetUniformBytes(wide)
+	if l.IsZero() != 1 {
+		t.Error("l should reduce to zero")
+	}
+
+	x := newTestScalar("x")
+	if NewScalar().Subtract(x, x).IsZero() != 1 {
+		t.Error("x - x should be zero")
+	}
+}
+
+func TestScalarHex(t *testing.T) {
+	x := NewScalar().SetUint64(0x0102)
+	want := "0201000000000000000000000000000000000000000000000000000000000000"
+	if got := x.Hex(); got != want {
+		t.Errorf("Hex() = %s, want %s", got, want)
+	}
+
+	y := newTestScalar("hex")
+	z, err := NewScalar().SetHex(y.Hex())
+	if err != nil {
+		t.Fatalf("SetHex() unexpected error: %v", err)
+	}
+	if z.Equal(y) != 1 {
+		t.Error("round trip produced a different scalar")
+	}
+
+	// Upper case is accepted as well
+	if _, err = NewScalar().SetHex(strings.ToUpper(y.Hex())); err != nil {
+		t.Errorf("SetHex() should accept upper case: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		in      string
+		wantErr string
+	}{
+		{"odd length", want[:63], "odd length"},
+		{"invalid character", "zz" + want[2:], "invalid character"},
+		{"short", want[:62], "32 bytes"},
+		{"long", want + "00", "32 bytes"},
+		// l
+		{"non canonical", "edd3f55c1a631258d69cf7a2def9de1400000000000000000000000000000010", "canonical"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := new(Scalar).Set(y)
+			_, err := s.SetHex(tt.in)
+			if err == nil {
+				t.Fatal("SetHex() should fail")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("SetHex() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+			if s.Equal(y) != 1 {
+				t.Error("receiver was modified on error")
+			}
+		})
+	}
+}
+
+func TestRandomScalar(t *testing.T) {
+	seed := make([]byte, 64)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+
+	x, err := RandomScalar(bytes.NewReader(seed))
+	if err != nil {
+		t.Fatalf("RandomScalar() unexpected error: %v", err)
+	}
+	y, err := RandomScalar(bytes.NewReader(seed))
+	if err != nil {
+		t.Fatalf("RandomScalar() unexpected error: %v", err)
+	}
+	if x.Equal(y) != 1 {
+		t.Error("RandomScalar() should be deterministic given the same reader")
+	}
+	want, _ := NewScalar().SetUniformBytes(seed)
+	if x.Equal(want) != 1 {
+		t.Error("RandomScalar() should be equivalent to SetUniformBytes on 64 bytes")
+	}
+
+	// Only the first 64 bytes are consumed
+	r := bytes.NewReader(append(append([]byte{}, seed...), seed...))
+	_, _ = RandomScalar(r)
+	if r.Len() != 64 {
+		t.Errorf("RandomScalar() consumed %d bytes, want 64", 128-r.Len())
+	}
+
+	// Short read
+	if _, err = RandomScalar(bytes.NewReader(seed[:63])); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("RandomScalar() on a short read should return io.ErrUnexpectedEOF, got %v", err)
+	}
+	if _, err = RandomScalar(bytes.NewReader(nil)); !errors.Is(err, io.EOF) {
+		t.Errorf("RandomScalar() on an empty reader should return io.EOF, got %v", err)
+	}
+}
+
+func TestScalarPow(t *testing.T) {
+	one := NewScalar().SetUint64(1)
+	x := newTestScalar("pow")
+
+	if NewScalar().Pow(x, 0).Equal(one) != 1 {
+		t.Error("x^0 should be 1")
+	}
+	if NewScalar().Pow(NewScalar(), 0).Equal(one) != 1 {
+		t.Error("0^0 should be 1")
+	}
+	if NewScalar().Pow(x, 1).Equal(x) != 1 {
+		t.Error("x^1 should be x")
+	}
+	if NewScalar().Pow(NewScalar().SetUint64(2), 10).Equal(NewScalar().SetUint64(1024)) != 1 {
+		t.Error("2^10 should be 1024")
+	}
+
+	for _, exp := range []uint64{2, 3, 37, 100, 257} {
+		expected := NewScalar().SetUint64(1)
+		for i := uint64(0); i < exp; i++ {
+			expected.Multiply(expected, x)
+		}
+		if NewScalar().Pow(x, exp).Equal(expected) != 1 {
+			t.Errorf("x^%d differs from repeated multiplication", exp)
+		}
+		// The receiver may alias base
+		if new(Scalar).Set(x).Pow(x, exp).Equal(expected) != 1 {
+			t.Errorf("x^%d with aliasing differs from repeated multiplication", exp)
+		}
+	}
+
+	// x^(l-1) = 1 by Fermat's little theorem, which we check as x^(2^64-1) * x^(l-2^64) = 1
+	// using the big.Int conversion for the larger exponent.
+	lMinusOne := new(big.Int).Sub(scalarOrder, big.NewInt(1))
+	bigExp := new(big.Int).Set(lMinusOne)
+	acc := NewScalar().SetUint64(1)
+	for bigExp.Sign() > 0 {
+		chunk := new(big.Int).And(bigExp, new(big.Int).SetUint64(math.MaxUint64))
+		bigExp.Rsh(bigExp, 64)
+		acc.Multiply(acc, NewScalar().Pow(x, chunk.Uint64()))
+		// Move x to x^(2^64) for the next chunk
+		x.Pow(x, 1<<32)
+		x.Pow(x, 1<<32)
+	}
+	if acc.Equal(one) != 1 {
+		t.Error("x^(l-1) should be 1")
+	}
+}
+
+func TestScalarZeroize(t *testing.T) {
+	s, err := RandomScalar(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.IsZero() == 1 {
+		t.Fatal("random scalar was zero")
+	}
+	s.Zeroize()
+	if !bytes.Equal(s.Bytes(), make([]byte, 32)) {
+		t.Errorf("Zeroize() left non-zero bytes: %x", s.Bytes())
+	}
+	if s.Equal(NewScalar()) != 1 {
+		t.Error("Zeroize() did not set the scalar to 0")
+	}
+}
@@ -0,0 +1,54 @@
+package state
+
+import (
+	"context"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+)
+
+// HandleMessageContext is the same as HandleMessage, but first checks whether ctx is done.
+// In that case the protocol is aborted, and ctx.Err() is returned.
+// Once aborted, no further messages are accepted.
+func (s *State) HandleMessageContext(ctx context.Context, msg *messages.Message) error {
+	if err := s.checkContext(ctx); err != nil {
+		return err
+	}
+	return s.HandleMessage(msg)
+}
+
+// ProcessAllContext is the same as ProcessAll, but first checks whether ctx is done.
+// In that case the protocol is aborted, and ctx.Err() is returned.
+func (s *State) ProcessAllContext(ctx context.Context) ([]*messages.Message, error) {
+	if err := s.checkContext(ctx); err != nil {
+		return nil, err
+	}
+	return s.ProcessAll(), nil
+}
+
+// WaitForErrorContext is the same as WaitForError, but returns ctx.Err() as soon as ctx is done.
+// In that case the protocol is aborted.
+func (s *State) WaitForErrorContext(ctx context.Context) error {
+	select {
+	case <-s.doneChan:
+		return s.Err()
+	case <-ctx.Done():
+		s.abort(ctx.Err())
+		return ctx.Err()
+	}
+}
+
+// checkContext aborts the protocol and returns ctx.Err() if ctx is done.
+func (s *State) checkContext(ctx context.Context) error {
+	err := ctx.Err()
+	if err != nil {
+		s.abort(err)
+	}
+	return err
+}
+
+// abort stops the protocol with err, if it has not finished already.
+func (s *State) abort(err error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.reportError(NewError(0, err))
+}
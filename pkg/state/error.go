@@ -26,3 +26,113 @@ func NewError(partyID party.ID, err error) *Error {
 func (e Error) Error() string {
 	return fmt.Sprintf("party %d: round %d: %s", e.PartyID, e.RoundNumber, e.err.Error())
 }
+
+// Unwrap returns the underlying error.
+func (e Error) Unwrap() error {
+	return e.err
+}
+
+// TimeoutError is wrapped by the Error reported when a round times out, and wraps ErrTimeout.
+// It lets the caller select another set of parties for a retry, by reporting which ones sent their message.
+type TimeoutError struct {
+	// Round is the number of the round which timed out.
+	Round int
+
+	// Received contains the parties whose message for Round was received, and Missing those who did not send one.
+	// Both are sorted, and do not contain the party itself.
+	Received, Missing party.IDSlice
+}
+
+// Error implements error.
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("round %d: %s: received messages from %v, missing %v", e.Round, ErrTimeout, e.Received, e.Missing)
+}
+
+// Unwrap returns ErrTimeout.
+func (e *TimeoutError) Unwrap() error {
+	return ErrTimeout
+}
+
+// IncompleteError is returned by State.Result while the protocol is running, and wraps ErrIncomplete.
+type IncompleteError struct {
+	// Round is the number of the current round.
+	Round int
+
+	// Missing contains the parties whose message for Round was not yet received.
+	// It is sorted, and does not contain the party itself.
+	Missing party.IDSlice
+}
+
+// Error implements error.
+func (e *IncompleteError) Error() string {
+	return fmt.Sprintf("round %d: %s: missing messages from %v", e.Round, ErrIncomplete, e.Missing)
+}
+
+// Unwrap returns ErrIncomplete.
+func (e *IncompleteError) Unwrap() error {
+	return ErrIncomplete
+}
+
+// ValidationError is returned by State.HandleMessage when a message from Sender is rejected, for example because it is
+// a duplicate, wrapping ErrDuplicateMessage, or is not for the current round, wrapping ErrWrongRound.
+// The message is then ignored, and the protocol continues.
+//
+// A round also reports an Error wrapping a ValidationError when the content of a message is malformed,
+// such as commitments of the wrong degree, in which case the protocol aborts.
+type ValidationError struct {
+	// Sender is the party who sent the message.
+	Sender party.ID
+	err    error
+}
+
+// NewValidationError returns an Error wrapping a ValidationError, for a malformed message from sender.
+func NewValidationError(sender party.ID, err error) *Error {
+	return NewError(sender, &ValidationError{Sender: sender, err: err})
+}
+
+// Error implements error.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid message from party %d: %s", e.Sender, e.err)
+}
+
+// Unwrap returns the reason the message was rejected.
+func (e *ValidationError) Unwrap() error {
+	return e.err
+}
+
+// AbortError is wrapped by the Error reported when the messages of some parties fail a cryptographic verification,
+// such as a zero-knowledge proof, a VSS check or a signature share.
+// As long as the messages are authenticated, this proves that the Culprits misbehaved.
+type AbortError struct {
+	// Culprits contains the IDs of all parties whose messages failed the verification, in increasing order.
+	// It is empty when the failure cannot be attributed, for example when the final signature is invalid.
+	Culprits party.IDSlice
+	err      error
+}
+
+// NewAbortError returns an Error wrapping an AbortError for err, which designates the culprits.
+// The PartyID of the Error is set when there is exactly one culprit.
+func NewAbortError(err error, culprits ...party.ID) *Error {
+	abortErr := &AbortError{
+		Culprits: party.NewIDSlice(culprits),
+		err:      err,
+	}
+	var culprit party.ID
+	if len(abortErr.Culprits) == 1 {
+		culprit = abortErr.Culprits[0]
+	}
+	return NewError(culprit, abortErr)
+}
+
+// Error implements error.
+func (e *AbortError) Error() string {
+	if len(e.Culprits) == 0 {
+		return e.err.Error()
+	}
+	return fmt.Sprintf("%s: culprits %v", e.err, e.Culprits)
+}
+
+// Unwrap returns the verification which failed.
+func (e *AbortError) Unwrap() error {
+	return e.err
+}
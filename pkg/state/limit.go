@@ -0,0 +1,59 @@
+package state
+
+import (
+	"errors"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+)
+
+// ErrTooManyMessages is returned by HandleMessage and HandleMessages when a party has sent more messages
+// for a round than allowed by SetMessageLimit.
+var ErrTooManyMessages = errors.New("too many messages from this party in this round")
+
+// SetMessageLimit bounds the number of messages HandleMessage and HandleMessages consider from each party for a round to n,
+// including those which HandleMessage rejects, for example as duplicates.
+// HandleMessages only counts the messages of a batch it accepts, so that a rejected batch can be corrected and retried.
+// Messages are counted against the round of their messages.MessageType, so that the messages an honest party sends
+// for the next round before the current one is over do not count against the current round.
+// Once a party exceeds the limit for a round, its messages for that round are dropped: they are rejected with a
+// ValidationError wrapping ErrTooManyMessages without being checked, and only the first of them is logged.
+// This bounds the work and the logs a party flooding the State can cause.
+//
+// The first valid message of a party for a round is the one which is used, whatever the limit.
+// A limit n ≤ 0 disables the limit, which is the default.
+func (s *State) SetMessageLimit(n int) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if n < 0 {
+		n = 0
+	}
+	s.messageLimit = n
+	s.messageCount = make(map[handledKey]int)
+}
+
+// exceedsLimit returns true if counting msg with countMessage would exceed the limit set with SetMessageLimit.
+func (s *State) exceedsLimit(msg *messages.Message) bool {
+	if s.messageLimit == 0 || msg.From == s.round.SelfID() || !s.round.PartyIDs().Contains(msg.From) {
+		return false
+	}
+	return s.messageCount[handledKey{from: msg.From, msgType: msg.Type}] >= s.messageLimit
+}
+
+// countMessage records that msg was received for the round of its type, and returns an error if its sender
+// exceeded the limit set with SetMessageLimit for that round.
+// Messages from the party itself, and from parties outside the protocol, are not counted.
+func (s *State) countMessage(msg *messages.Message) error {
+	if s.messageLimit == 0 || msg.From == s.round.SelfID() || !s.round.PartyIDs().Contains(msg.From) {
+		return nil
+	}
+	key := handledKey{from: msg.From, msgType: msg.Type}
+	s.messageCount[key]++
+	count := s.messageCount[key]
+	if count <= s.messageLimit {
+		return nil
+	}
+	if count == s.messageLimit+1 {
+		s.logger.Debug("message limit reached", "round", s.roundNumber, "from", msg.From, "limit", s.messageLimit, "type", msg.Type)
+	}
+	return s.wrapError(&ValidationError{Sender: msg.From, err: ErrTooManyMessages}, msg.From)
+}
@@ -0,0 +1,25 @@
+package state
+
+// A Logger receives debug events about the messages accepted or rejected by a State, and about its rounds.
+// It is satisfied by *slog.Logger.
+//
+// The arguments are alternating keys and values, as with slog. They never contain secret values such as
+// shares or nonces, only party IDs, round numbers, message types and errors.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+
+// SetLogger sets the Logger to which the State reports debug events.
+// A nil Logger disables logging, which is the default.
+func (s *State) SetLogger(l Logger) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if l == nil {
+		l = nopLogger{}
+	}
+	s.logger = l
+}
@@ -0,0 +1,203 @@
+package state
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+)
+
+// stateVersion is the version of the encoding produced by State.MarshalBinary.
+// Version 1 encoded party IDs on 2 bytes, and is rejected with ErrStateVersion.
+const stateVersion byte = 2
+
+var (
+	ErrStateVersion     = errors.New("state: unsupported serialization version")
+	ErrStateSessionID   = errors.New("state: serialized state belongs to a different session")
+	ErrStateUnsupported = errors.New("state: round does not support serialization")
+)
+
+// A MarshalableRound is a Round whose State can be saved with State.MarshalBinary
+// and restored with State.UnmarshalBinary.
+//
+// All rounds of the protocol share the data of the first round, so MarshalBinary and UnmarshalBinary
+// are only implemented by Round0, and UnmarshalBinary is only ever called on a fresh Round0.
+type MarshalableRound interface {
+	Round
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+
+	// SessionID identifies the protocol and the parameters with which the Round was created.
+	// A serialized State can only be restored into a State with the same SessionID.
+	SessionID() []byte
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// It serializes the current round number, the data of the round, and all messages received for the current
+// and future rounds. The round must implement MarshalableRound.
+//
+// The output contains secret data such as private nonces, and should be stored encrypted.
+// It must be restored at most once, since executing the remaining rounds twice with different messages
+// from the other parties may leak the party's secret.
+func (s *State) MarshalBinary() ([]byte, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.done {
+		return nil, errors.New("state: protocol already finished")
+	}
+	round, ok := s.round.(MarshalableRound)
+	if !ok {
+		return nil, ErrStateUnsupported
+	}
+	roundData, err := round.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("state: failed to marshal round: %w", err)
+	}
+
+	msgs := make([]*messages.Message, 0, len(s.receivedMessages)+len(s.queue))
+	for _, msg := range s.receivedMessages {
+		if msg != nil {
+			msgs = append(msgs, msg)
+		}
+	}
+	msgs = append(msgs, s.queue...)
+
+	sessionID := round.SessionID()
+
+	var buf bytes.Buffer
+	buf.WriteByte(stateVersion)
+	writeBytes(&buf, sessionID)
+	_ = binary.Write(&buf, binary.BigEndian, uint32(s.roundNumber))
+	writeBytes(&buf, roundData)
+	_ = binary.Write(&buf, binary.BigEndian, uint32(len(msgs)))
+	for _, msg := range msgs {
+		data, err := msg.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("state: failed to marshal message: %w", err)
+		}
+		writeBytes(&buf, data)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// It must be called on a fresh State, created with the same parameters as the one which was serialized,
+// before any message is handled.
+// It returns ErrStateVersion or ErrStateSessionID if data was produced by a different version or session.
+func (s *State) UnmarshalBinary(data []byte) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.done || s.roundNumber != 0 {
+		return errors.New("state: can only restore into a fresh state")
+	}
+	round, ok := s.round.(MarshalableRound)
+	if !ok {
+		return ErrStateUnsupported
+	}
+
+	r := bytes.NewReader(data)
+	version, err := r.ReadByte()
+	if err != nil {
+		return fmt.Errorf("state: %w", messages.ErrInvalidMessage)
+	}
+	if version != stateVersion {
+		return fmt.Errorf("%w %d, expected %d", ErrStateVersion, version, stateVersion)
+	}
+	sessionID, err := readBytes(r)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(sessionID, round.SessionID()) {
+		return ErrStateSessionID
+	}
+
+	var roundNumber uint32
+	if err = binary.Read(r, binary.BigEndian, &roundNumber); err != nil {
+		return fmt.Errorf("state: %w", messages.ErrInvalidMessage)
+	}
+	if int(roundNumber) >= len(s.acceptedTypes) {
+		return errors.New("state: invalid round number")
+	}
+	roundData, err := readBytes(r)
+	if err != nil {
+		return err
+	}
+
+	var msgCount uint32
+	if err = binary.Read(r, binary.BigEndian, &msgCount); err != nil {
+		return fmt.Errorf("state: %w", messages.ErrInvalidMessage)
+	}
+	// Every message is prefixed by its length, so msgCount cannot exceed a quarter of the remaining bytes.
+	// This bounds the allocation below by the size of data.
+	if int64(msgCount)*4 > int64(r.Len()) {
+		return fmt.Errorf("state: %w", messages.ErrInvalidMessage)
+	}
+	msgs := make([]*messages.Message, 0, msgCount)
+	for i := uint32(0); i < msgCount; i++ {
+		msgData, err := readBytes(r)
+		if err != nil {
+			return err
+		}
+		var msg messages.Message
+		if err = msg.UnmarshalBinary(msgData); err != nil {
+			return fmt.Errorf("state: failed to unmarshal message: %w", err)
+		}
+		msgs = append(msgs, &msg)
+	}
+	if r.Len() != 0 {
+		return fmt.Errorf("state: %w", messages.ErrInvalidMessage)
+	}
+
+	if err = round.UnmarshalBinary(roundData); err != nil {
+		return fmt.Errorf("state: failed to unmarshal round: %w", err)
+	}
+
+	// Advance to the round we were in
+	var current Round = round
+	for i := uint32(0); i < roundNumber; i++ {
+		current = current.NextRound()
+	}
+	s.round = current
+	s.roundNumber = int(roundNumber)
+	s.ackMessage(s.roundNumber)
+	s.acceptedTypes = s.acceptedTypes[roundNumber:]
+
+	if roundNumber > 0 {
+		for id := range s.receivedMessages {
+			delete(s.receivedMessages, id)
+		}
+	}
+	s.queue = s.queue[:0]
+	for _, msg := range msgs {
+		s.handled[handledKey{from: msg.From, msgType: msg.Type}] = true
+		if msg.Type == s.acceptedTypes[0] {
+			s.receivedMessages[msg.From] = msg
+		} else {
+			s.queue = append(s.queue, msg)
+		}
+	}
+	return nil
+}
+
+func writeBytes(buf *bytes.Buffer, data []byte) {
+	_ = binary.Write(buf, binary.BigEndian, uint32(len(data)))
+	buf.Write(data)
+}
+
+func readBytes(r *bytes.Reader) ([]byte, error) {
+	var n uint32
+	if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+		return nil, fmt.Errorf("state: %w", messages.ErrInvalidMessage)
+	}
+	if int64(n) > int64(r.Len()) {
+		return nil, fmt.Errorf("state: %w", messages.ErrInvalidMessage)
+	}
+	data := make([]byte, n)
+	_, _ = r.Read(data)
+	return data, nil
+}
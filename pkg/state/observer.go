@@ -0,0 +1,28 @@
+package state
+
+import "github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+
+// An Observer is notified of the progress of a State, for example to record metrics or logs.
+//
+// Its methods are called synchronously while the State is locked,
+// so they should return quickly and must not call methods of the State.
+type Observer interface {
+	// OnRoundStart is called when the State starts processing the given round,
+	// after all messages for it have been received.
+	OnRoundStart(round int)
+
+	// OnMessageProcessed is called after a message from the given party was successfully processed
+	// in the current round.
+	OnMessageProcessed(from party.ID)
+
+	// OnError is called once, when the protocol aborts with err.
+	OnError(err error)
+}
+
+// SetObserver sets the Observer notified of the progress of the State.
+// A nil Observer disables the notifications.
+func (s *State) SetObserver(o Observer) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.observer = o
+}
@@ -73,3 +73,15 @@ type Round interface {
 	// PartyIDs returns a set containing all parties participating in the round
 	PartyIDs() party.IDSlice
 }
+
+// A SenderRound is a Round in which only some of the parties send a message to SelfID.
+// The State then waits for messages from the parties returned by Senders instead of all PartyIDs,
+// and rejects messages for this round from any other party.
+// Rounds of protocols in which all parties send messages do not need to implement it.
+type SenderRound interface {
+	Round
+
+	// Senders returns the parties from which a message is expected in the current round.
+	// It may contain SelfID, which is ignored.
+	Senders() party.IDSlice
+}
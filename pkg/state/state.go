@@ -10,6 +10,26 @@ import (
 	"github.com/taurusgroup/frost-ed25519/pkg/messages"
 )
 
+var (
+	// ErrTimeout is wrapped by the Error reported when no message was received in time, through a TimeoutError.
+	ErrTimeout = errors.New("message timeout")
+
+	// ErrZeroized is wrapped by the Error reported when Zeroize is called before the protocol has finished.
+	ErrZeroized = errors.New("secret data was erased")
+
+	// ErrDuplicateMessage is returned by HandleMessage when a party sends two messages for the same round.
+	ErrDuplicateMessage = errors.New("message from this party was already received")
+
+	// ErrWrongRound is returned by HandleMessage when a message is neither for the current round nor for a later one.
+	ErrWrongRound = errors.New("message is not for the current round")
+
+	// ErrFinished is returned by HandleMessage when the protocol has already finished.
+	ErrFinished = errors.New("protocol already finished")
+
+	// ErrIncomplete is wrapped by the IncompleteError returned by Result before the protocol has finished.
+	ErrIncomplete = errors.New("protocol has not finished")
+)
+
 // State is a struct that manages the state for the round based protocol.
 //
 // It handles the initial message reception, by storing them internally and feeding them to
@@ -19,12 +39,27 @@ type State struct {
 	receivedMessages map[party.ID]*messages.Message
 	queue            []*messages.Message
 
+	// handled records which messages were already accepted, for the current and all future rounds.
+	handled map[handledKey]bool
+
 	timer
 
 	roundNumber int
 
 	round Round
 
+	observer Observer
+	logger   Logger
+
+	// messageLimit is the number of messages considered from each party in a round, or 0 if there is no limit,
+	// and messageCount counts the messages of each party for each round.
+	messageLimit int
+	messageCount map[handledKey]int
+
+	// startedAt is the time the State was created, roundStartedAt that at which the current round started,
+	// and finishedAt that at which the protocol finished or aborted.
+	startedAt, roundStartedAt, finishedAt time.Time
+
 	doneChan chan struct{}
 	done     bool
 	err      *Error
@@ -34,17 +69,22 @@ type State struct {
 
 func NewBaseState(round Round, timeout time.Duration) (*State, error) {
 	N := round.PartyIDs().N()
+	now := time.Now()
 	s := &State{
 		acceptedTypes:    append([]messages.MessageType{}, round.AcceptedMessageTypes()...),
 		receivedMessages: make(map[party.ID]*messages.Message, N),
 		queue:            make([]*messages.Message, 0, N),
+		handled:          make(map[handledKey]bool, len(round.AcceptedMessageTypes())*int(N)),
 		round:            round,
 		doneChan:         make(chan struct{}),
+		logger:           nopLogger{},
+		startedAt:        now,
+		roundStartedAt:   now,
 	}
 
 	s.timer = newTimer(timeout, func() {
 		s.mtx.Lock()
-		s.reportError(NewError(0, errors.New("message timeout")))
+		s.reportError(NewError(0, s.timeoutError()))
 		s.mtx.Unlock()
 	})
 
@@ -61,7 +101,7 @@ func (s *State) wrapError(err error, culprit party.ID) error {
 	if culprit == 0 {
 		return fmt.Errorf("party %d, round %d: %w", s.round.SelfID(), s.roundNumber, err)
 	}
-	return fmt.Errorf("party %d, round %d, culprit %d: %w", s.round.SelfID(), culprit, s.roundNumber, err)
+	return fmt.Errorf("party %d, round %d, culprit %d: %w", s.round.SelfID(), s.roundNumber, culprit, err)
 }
 
 // HandleMessage should be called on an unmarshalled messages.Message appropriate for the protocol execution.
@@ -73,57 +113,142 @@ func (s *State) wrapError(err error, culprit party.ID) error {
 // - Have we already received a message from the party for this round?
 //
 // If all these checks pass, then the message is either stored for the current round,
-// or put in a queue for later rounds.
+// or put in a queue for later rounds. Queued messages are only processed once the current round is complete.
+// Subsequent messages from the same party for the same round are rejected with ErrDuplicateMessage,
+// and do not affect the first one.
 //
 // Note: the properties of the messages are checked in ProcessAll.
 // Therefore, the check here should be a quite fast.
 func (s *State) HandleMessage(msg *messages.Message) error {
-	senderID := msg.From
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
 
+	if err := s.countMessage(msg); err != nil {
+		return err
+	}
+	ok, err := s.checkMessage(msg)
+	if err != nil || !ok {
+		return err
+	}
+	s.acceptMessage(msg)
+	return nil
+}
+
+// HandleMessages is like HandleMessage, but handles all messages of the current round at once,
+// for example when a coordinator relays them together.
+// msgs must contain exactly one message from every party expected to send a message in the current round.
+// Messages from SelfID are ignored, so that the same list can be given to all parties.
+// Otherwise, an error is returned and none of the messages are handled.
+func (s *State) HandleMessages(msgs []*messages.Message) error {
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
 
+	if s.done || len(s.acceptedTypes) == 0 {
+		return s.wrapError(ErrFinished, 0)
+	}
+	currentType := s.acceptedTypes[0]
+
+	accepted := make([]*messages.Message, 0, len(msgs))
+	senders := make(map[party.ID]bool, len(msgs))
+	for _, msg := range msgs {
+		if msg.From == s.round.SelfID() {
+			continue
+		}
+		if msg.Type != currentType {
+			return s.rejectMessage(msg, ErrWrongRound)
+		}
+		if senders[msg.From] {
+			return s.rejectMessage(msg, ErrDuplicateMessage)
+		}
+		ok, err := s.checkMessage(msg)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return s.rejectMessage(msg, errors.New("message is not addressed to this party"))
+		}
+		senders[msg.From] = true
+		accepted = append(accepted, msg)
+	}
+	if expected := s.expectedMessages(); len(accepted) != expected {
+		return s.wrapError(fmt.Errorf("expected messages from %d parties, got %d", expected, len(accepted)), 0)
+	}
+
+	// The messages are only counted once the batch is valid, so that a rejected batch can be retried
+	for _, msg := range accepted {
+		if s.exceedsLimit(msg) {
+			return s.countMessage(msg)
+		}
+	}
+	for _, msg := range accepted {
+		_ = s.countMessage(msg)
+		s.acceptMessage(msg)
+	}
+	return nil
+}
+
+// checkMessage performs the checks of HandleMessage, without modifying the state.
+// It returns false if msg should be ignored, and an error if it should be rejected.
+func (s *State) checkMessage(msg *messages.Message) (bool, error) {
+	senderID := msg.From
+
 	if s.done {
-		return s.wrapError(errors.New("protocol already finished"), senderID)
+		return false, s.rejectMessage(msg, ErrFinished)
 	}
 
 	if len(s.acceptedTypes) == 0 {
-		return s.wrapError(errors.New("no more messages being accepted"), senderID)
+		return false, s.rejectMessage(msg, ErrFinished)
 	}
 
 	// Ignore messages from self
 	if senderID == s.round.SelfID() {
-		return nil
+		return false, nil
 	}
 
 	// Ignore message not addressed to us
 	if !msg.IsBroadcast() && msg.To != s.round.SelfID() {
-		return nil
+		return false, nil
 	}
 	// Is the sender in our list of participants?
 	if !s.round.PartyIDs().Contains(senderID) {
-		return s.wrapError(errors.New("sender is not a party"), senderID)
+		return false, s.rejectMessage(msg, errors.New("sender is not a party"))
 	}
 
-	// Check if we have already received a message from this party.
-	// exists should never be false, but you never know
-	if _, exists := s.receivedMessages[senderID]; exists {
-		return s.wrapError(errors.New("message from this party was already received"), senderID)
+	if msg.Type == messages.MessageTypeNone || !s.isAcceptedType(msg.Type) {
+		return false, s.rejectMessage(msg, fmt.Errorf("%w: type %d", ErrWrongRound, msg.Type))
 	}
 
-	if !s.isAcceptedType(msg.Type) {
-		return s.wrapError(errors.New("message type is not accepted for this type of round"), senderID)
+	// The first message of each type from a party is the one that is used
+	if s.handled[handledKey{from: senderID, msgType: msg.Type}] {
+		return false, s.rejectMessage(msg, ErrDuplicateMessage)
 	}
 
-	s.ackMessage()
+	if msg.Type == s.acceptedTypes[0] && !s.isSender(senderID) {
+		return false, s.rejectMessage(msg, errors.New("sender is not expected to send a message in this round"))
+	}
+	return true, nil
+}
 
+// acceptMessage stores msg, which must have passed checkMessage, for the current round or a later one.
+func (s *State) acceptMessage(msg *messages.Message) {
+	senderID := msg.From
 	if msg.Type == s.acceptedTypes[0] {
 		s.receivedMessages[senderID] = msg
+		s.logger.Debug("message accepted", "round", s.roundNumber, "from", senderID, "type", msg.Type)
 	} else {
+		// Messages for later rounds are only processed once the current round is complete
 		s.queue = append(s.queue, msg)
+		s.logger.Debug("message queued for a later round", "round", s.roundNumber, "from", senderID, "type", msg.Type)
 	}
+	s.handled[handledKey{from: senderID, msgType: msg.Type}] = true
 
-	return nil
+	s.ackMessage(s.roundNumber)
+}
+
+// rejectMessage logs that msg was rejected because of err, and returns err wrapped in a ValidationError.
+func (s *State) rejectMessage(msg *messages.Message, err error) error {
+	s.logger.Debug("message rejected", "round", s.roundNumber, "from", msg.From, "type", msg.Type, "reason", err)
+	return s.wrapError(&ValidationError{Sender: msg.From, err: err}, msg.From)
 }
 
 // ProcessAll checks whether all messages for this round have been received.
@@ -141,15 +266,23 @@ func (s *State) ProcessAll() []*messages.Message {
 	}
 
 	// Only continue if we received messages from all
-	if len(s.receivedMessages) != int(s.round.PartyIDs().N()-1) {
+	if len(s.receivedMessages) != s.expectedMessages() {
 		return nil
 	}
 
+	s.logger.Debug("round started", "round", s.roundNumber)
+	if s.observer != nil {
+		s.observer.OnRoundStart(s.roundNumber)
+	}
+
 	for _, msg := range s.receivedMessages {
 		if err := s.round.ProcessMessage(msg); err != nil {
 			s.reportError(err)
 			return nil
 		}
+		if msg != nil && s.observer != nil {
+			s.observer.OnMessageProcessed(msg.From)
+		}
 	}
 
 	// remove all messages that have been processed
@@ -162,6 +295,7 @@ func (s *State) ProcessAll() []*messages.Message {
 		s.reportError(err)
 		return nil
 	}
+	s.logger.Debug("round finished", "round", s.roundNumber, "messages", len(newMessages))
 
 	// remove the messages for the next round from the queue
 	s.acceptedTypes = s.acceptedTypes[1:]
@@ -184,12 +318,72 @@ func (s *State) ProcessAll() []*messages.Message {
 		s.finish()
 	} else {
 		s.roundNumber++
+		s.roundStartedAt = time.Now()
 		s.round = nextRound
+		s.ackMessage(s.roundNumber)
 	}
 
 	return newMessages
 }
 
+// handledKey identifies the message of a given round sent by a party.
+type handledKey struct {
+	from    party.ID
+	msgType messages.MessageType
+}
+
+// timeoutError returns a TimeoutError for the current round.
+func (s *State) timeoutError() *TimeoutError {
+	received, missing := s.receivedFrom()
+	return &TimeoutError{
+		Round:    s.roundNumber,
+		Received: received,
+		Missing:  missing,
+	}
+}
+
+// receivedFrom returns the sorted senders of the current round other than the party itself,
+// split between those whose message was received and those whose message is missing.
+func (s *State) receivedFrom() (received, missing party.IDSlice) {
+	senders := s.round.PartyIDs()
+	if r, ok := s.round.(SenderRound); ok {
+		senders = r.Senders()
+	}
+	var r, m []party.ID
+	for _, id := range senders {
+		if id == s.round.SelfID() {
+			continue
+		}
+		if s.receivedMessages[id] != nil {
+			r = append(r, id)
+		} else {
+			m = append(m, id)
+		}
+	}
+	return party.NewIDSlice(r), party.NewIDSlice(m)
+}
+
+// isSender returns true if id is expected to send a message in the current round.
+func (s *State) isSender(id party.ID) bool {
+	if r, ok := s.round.(SenderRound); ok {
+		return r.Senders().Contains(id)
+	}
+	return true
+}
+
+// expectedMessages returns the number of messages required to process the current round.
+func (s *State) expectedMessages() int {
+	r, ok := s.round.(SenderRound)
+	if !ok {
+		return int(s.round.PartyIDs().N() - 1)
+	}
+	senders := r.Senders()
+	if senders.Contains(s.round.SelfID()) {
+		return int(senders.N() - 1)
+	}
+	return int(senders.N())
+}
+
 func (s *State) isAcceptedType(msgType messages.MessageType) bool {
 	for _, otherType := range s.acceptedTypes {
 		if otherType == msgType {
@@ -207,6 +401,7 @@ func (s *State) finish() {
 		return
 	}
 	s.done = true
+	s.finishedAt = time.Now()
 	s.round.Reset()
 	s.stopTimer()
 	close(s.doneChan)
@@ -223,9 +418,34 @@ func (s *State) reportError(err *Error) {
 	if s.err == nil {
 		err.RoundNumber = s.roundNumber
 		s.err = err
+		s.logger.Debug("protocol aborted", "round", s.roundNumber, "culprit", err.PartyID, "error", err.err)
+		if s.observer != nil {
+			s.observer.OnError(err)
+		}
 	}
 }
 
+// Zeroize erases the secret data held by the rounds of the protocol, such as nonces and secret shares,
+// by calling Round.Reset, and discards all buffered messages.
+// This already happens when the protocol finishes, but Zeroize can be used to do so earlier, for example when the
+// protocol is abandoned, in which case it aborts with an Error wrapping ErrZeroized.
+// The output of a successful protocol is not affected.
+//
+// As with ristretto.Scalar.Zeroize, copies made by the Go runtime are not erased.
+func (s *State) Zeroize() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if !s.done {
+		s.reportError(NewError(0, ErrZeroized))
+	}
+	s.round.Reset()
+	for id := range s.receivedMessages {
+		delete(s.receivedMessages, id)
+	}
+	s.queue = nil
+}
+
 // Done should be called like context.Done:
 //
 // select {
@@ -247,15 +467,38 @@ func (s *State) Err() error {
 // This happens either when the protocol has finished correctly,
 // or if an error has been detected.
 func (s *State) WaitForError() error {
-	if !s.done {
-		<-s.doneChan
-	}
+	<-s.doneChan
 	return s.Err()
 }
 
+// Result returns the outcome of the protocol without blocking: nil if it has successfully finished, the Error it
+// aborted with otherwise, or an IncompleteError wrapping ErrIncomplete while it is still running.
+// The IncompleteError lists the parties whose message for the current round is missing. If none are, then
+// ProcessAll must be called to process the round.
+//
+// Unlike reading the output of the protocol directly, it lets a caller detect that the output is not yet available.
+func (s *State) Result() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.done {
+		return s.Err()
+	}
+	_, missing := s.receivedFrom()
+	return &IncompleteError{
+		Round:   s.roundNumber,
+		Missing: missing,
+	}
+}
+
 // IsFinished returns true if the protocol has aborted or successfully finished.
 func (s *State) IsFinished() bool {
-	return s.done
+	select {
+	case <-s.doneChan:
+		return true
+	default:
+		return false
+	}
 }
 
 //
@@ -264,7 +507,13 @@ func (s *State) IsFinished() bool {
 
 type timer struct {
 	t *time.Timer
+	f func()
+
+	// d is the default timeout, used for rounds without a specific timeout.
 	d time.Duration
+
+	// roundTimeouts maps round numbers to the timeout used in that round.
+	roundTimeouts map[int]time.Duration
 }
 
 func newTimer(d time.Duration, f func()) timer {
@@ -274,14 +523,30 @@ func newTimer(d time.Duration, f func()) timer {
 	}
 	return timer{
 		t: t,
+		f: f,
 		d: d,
 	}
 }
 
-func (t *timer) ackMessage() {
-	if t.t != nil {
-		t.t.Stop()
-		t.t.Reset(t.d)
+// duration returns the timeout for the given round.
+func (t *timer) duration(round int) time.Duration {
+	if d, ok := t.roundTimeouts[round]; ok {
+		return d
+	}
+	return t.d
+}
+
+// ackMessage restarts the timer with the timeout for the given round.
+func (t *timer) ackMessage(round int) {
+	t.stopTimer()
+	d := t.duration(round)
+	if d <= 0 {
+		return
+	}
+	if t.t == nil {
+		t.t = time.AfterFunc(d, t.f)
+	} else {
+		t.t.Reset(d)
 	}
 }
 
@@ -290,3 +555,21 @@ func (t *timer) stopTimer() {
 		t.t.Stop()
 	}
 }
+
+// SetRoundTimeout sets the maximum time allowed between two messages received during the given round,
+// as well as between the start of the round and the first message.
+// It overrides the timeout given to NewBaseState, which remains the default for other rounds.
+// A duration of 0 indicates no timeout for the round.
+// When it expires, the protocol aborts with an Error wrapping ErrTimeout, whose RoundNumber is round.
+func (s *State) SetRoundTimeout(round int, d time.Duration) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.roundTimeouts == nil {
+		s.roundTimeouts = make(map[int]time.Duration)
+	}
+	s.roundTimeouts[round] = d
+	if round == s.roundNumber && !s.done {
+		s.ackMessage(round)
+	}
+}
@@ -0,0 +1,102 @@
+package state
+
+import (
+	"time"
+
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+)
+
+// Phase describes what a State is doing, as reported by State.Status.
+type Phase int
+
+const (
+	// PhaseWaiting means that some messages of the current round have not yet been received.
+	PhaseWaiting Phase = iota
+
+	// PhaseReady means that all messages of the current round were received, and that ProcessAll must be called.
+	PhaseReady
+
+	// PhaseFinished means that the protocol has successfully finished.
+	PhaseFinished
+
+	// PhaseAborted means that the protocol has aborted with an error.
+	PhaseAborted
+)
+
+// String implements fmt.Stringer.
+func (p Phase) String() string {
+	switch p {
+	case PhaseWaiting:
+		return "waiting"
+	case PhaseReady:
+		return "ready"
+	case PhaseFinished:
+		return "finished"
+	case PhaseAborted:
+		return "aborted"
+	default:
+		return "unknown"
+	}
+}
+
+// SessionStatus is a snapshot of the progress of a State, returned by State.Status.
+type SessionStatus struct {
+	// Round is the number of the current round, or of the last one once the protocol is terminal.
+	Round int
+
+	// Phase is the current Phase of the State.
+	Phase Phase
+
+	// Elapsed is the time since the State was created, until the protocol became terminal.
+	Elapsed time.Duration
+
+	// RoundElapsed is the time since the current round started, until the protocol became terminal.
+	RoundElapsed time.Duration
+
+	// Expected contains the parties expected to send a message in the current round, other than the party itself,
+	// and Received those whose message was received. Both are sorted, and empty once the protocol is terminal,
+	// or if the round does not expect any message.
+	Expected, Received party.IDSlice
+
+	// Terminal is true if the protocol has finished or aborted, in which case Err is the error it aborted with.
+	Terminal bool
+	Err      error
+}
+
+// Status returns a snapshot of the progress of the protocol, without blocking.
+// It can be called concurrently with the other methods of the State, for example to export metrics.
+func (s *State) Status() SessionStatus {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	end := time.Now()
+	if s.done {
+		end = s.finishedAt
+	}
+	status := SessionStatus{
+		Round:        s.roundNumber,
+		Elapsed:      end.Sub(s.startedAt),
+		RoundElapsed: end.Sub(s.roundStartedAt),
+		Terminal:     s.done,
+		Err:          s.Err(),
+	}
+
+	switch {
+	case s.done && s.err != nil:
+		status.Phase = PhaseAborted
+	case s.done:
+		status.Phase = PhaseFinished
+	case len(s.receivedMessages) == s.expectedMessages():
+		status.Phase = PhaseReady
+	default:
+		status.Phase = PhaseWaiting
+	}
+
+	if !s.done && len(s.acceptedTypes) > 0 && s.acceptedTypes[0] != messages.MessageTypeNone {
+		received, missing := s.receivedFrom()
+		status.Received = received
+		status.Expected = party.NewIDSlice(append(received.Copy(), missing...))
+	}
+	return status
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/sign"
+	"github.com/taurusgroup/frost-ed25519/pkg/helpers"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+func TestSignBatch(t *testing.T) {
+	_, signSet, secretShares, publicShares := setupParties(2, 5)
+	msgs := [][]byte{[]byte("first message"), []byte("second message"), []byte("third message")}
+
+	states := map[party.ID]*state.State{}
+	outputs := map[party.ID][]*sign.Output{}
+	for _, id := range signSet {
+		var err error
+		states[id], outputs[id], err = frost.NewSignBatchState(signSet, secretShares[id], publicShares, msgs, 0)
+		require.NoError(t, err)
+	}
+
+	// Only two rounds of communication are needed
+	var rounds int
+	var in [][]byte
+	for {
+		var out [][]byte
+		for _, s := range states {
+			next, err := helpers.PartyRoutine(in, s)
+			require.NoError(t, err)
+			out = append(out, next...)
+		}
+		if len(out) == 0 {
+			break
+		}
+		in = out
+		rounds++
+	}
+	assert.Equal(t, 2, rounds)
+
+	pk := publicShares.GroupKey.ToEd25519()
+	for id, s := range states {
+		require.NoError(t, s.WaitForError())
+		require.Len(t, outputs[id], len(msgs))
+		for i, message := range msgs {
+			sig := outputs[id][i].Signature
+			require.NotNil(t, sig)
+			assert.True(t, ed25519.Verify(pk, message, sig.ToEd25519()), "party %d message %d", id, i)
+			assert.Equal(t, 1, sig.Equal(outputs[signSet[0]][i].Signature))
+		}
+		// Each message has its own nonces
+		assert.False(t, outputs[id][0].Signature.R.Equal(&outputs[id][1].Signature.R) == 1)
+	}
+}
+
+func TestSignBatch_WrongLength(t *testing.T) {
+	_, signSet, secretShares, publicShares := setupParties(1, 2)
+	msgs := [][]byte{[]byte("first message"), []byte("second message")}
+
+	s1, _, err := frost.NewSignBatchState(signSet, secretShares[1], publicShares, msgs, 0)
+	require.NoError(t, err)
+	s2, _, err := frost.NewSignBatchState(signSet, secretShares[2], publicShares, msgs[:1], 0)
+	require.NoError(t, err)
+
+	out, err := helpers.PartyRoutine(nil, s2)
+	require.NoError(t, err)
+	_, _ = helpers.PartyRoutine(nil, s1)
+	_, _ = helpers.PartyRoutine(out, s1)
+
+	err = s1.WaitForError()
+	require.Error(t, err)
+	var stateErr *state.Error
+	require.ErrorAs(t, err, &stateErr)
+	assert.Equal(t, party.ID(2), stateErr.PartyID)
+
+	_, _, err = frost.NewSignBatchState(signSet, secretShares[1], publicShares, nil, 0)
+	assert.Error(t, err)
+}
+
+func TestSignMultiKey(t *testing.T) {
+	// Two distinct 2-of-3 keys, shared by the same parties
+	_, signSet, secretShares1, publicShares1 := setupParties(1, 3)
+	_, _, secretShares2, publicShares2 := setupParties(1, 3)
+	require.False(t, publicShares1.GroupKey.Equal(publicShares2.GroupKey))
+	shares := []*eddsa.Public{publicShares1, publicShares2}
+
+	states := map[party.ID]*state.State{}
+	outputs := map[party.ID][]*sign.Output{}
+	for _, id := range signSet {
+		var err error
+		secrets := []*eddsa.SecretShare{secretShares1[id], secretShares2[id]}
+		states[id], outputs[id], err = frost.NewSignMultiKeyState(signSet, secrets, shares, MESSAGE, 0)
+		require.NoError(t, err)
+	}
+
+	// Only two rounds of communication are needed
+	var rounds int
+	var in [][]byte
+	for {
+		var out [][]byte
+		for _, s := range states {
+			next, err := helpers.PartyRoutine(in, s)
+			require.NoError(t, err)
+			out = append(out, next...)
+		}
+		if len(out) == 0 {
+			break
+		}
+		in = out
+		rounds++
+	}
+	assert.Equal(t, 2, rounds)
+
+	for id, s := range states {
+		require.NoError(t, s.WaitForError())
+		require.Len(t, outputs[id], len(shares))
+		for i, public := range shares {
+			sig := outputs[id][i].Signature
+			require.NotNil(t, sig)
+			assert.True(t, ed25519.Verify(public.GroupKey.ToEd25519(), MESSAGE, sig.ToEd25519()), "party %d key %d", id, i)
+			assert.False(t, ed25519.Verify(shares[1-i].GroupKey.ToEd25519(), MESSAGE, sig.ToEd25519()), "party %d key %d", id, i)
+		}
+		// Each key has its own nonces
+		assert.False(t, outputs[id][0].Signature.R.Equal(&outputs[id][1].Signature.R) == 1)
+	}
+
+	// The secret shares must belong to the same party, and match the keys
+	_, _, err := frost.NewSignMultiKeyState(signSet, []*eddsa.SecretShare{secretShares1[1], secretShares2[2]}, shares, MESSAGE, 0)
+	assert.Error(t, err)
+	_, _, err = frost.NewSignMultiKeyState(signSet, []*eddsa.SecretShare{secretShares1[1]}, shares, MESSAGE, 0)
+	assert.Error(t, err)
+	_, _, err = frost.NewSignMultiKeyState(signSet, nil, nil, MESSAGE, 0)
+	assert.Error(t, err)
+}
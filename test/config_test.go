@@ -0,0 +1,71 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/keygen"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/sign"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+func TestConfig(t *testing.T) {
+	_, signSet, secretShares, publicShares := setupParties(1, 3)
+
+	states := map[party.ID]*state.State{}
+	outputs := map[party.ID]*sign.Output{}
+	for _, id := range signSet {
+		config, err := frost.NewConfig(&keygen.Output{Public: publicShares, SecretKey: secretShares[id]})
+		require.NoError(t, err)
+		assert.Equal(t, id, config.SelfID)
+		require.NoError(t, config.Validate())
+
+		states[id], outputs[id], err = config.NewSignState(signSet, MESSAGE, 0)
+		require.NoError(t, err)
+	}
+	require.NoError(t, runRounds(states))
+	for _, id := range signSet {
+		require.NoError(t, states[id].WaitForError())
+		assert.True(t, ed25519.Verify(publicShares.GroupKey.ToEd25519(), MESSAGE, outputs[id].Signature.ToEd25519()))
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	_, _, secretShares, publicShares := setupParties(1, 3)
+	_, _, otherShares, otherPublic := setupParties(1, 3)
+
+	// A share whose secret was modified, but not its public key
+	tampered := *secretShares[2]
+	tampered.Secret.Add(&tampered.Secret, ristretto.NewScalar().SetUint64(1))
+
+	for _, tt := range []struct {
+		name   string
+		config frost.Config
+	}{
+		{"share of another key", frost.Config{SelfID: 1, SecretShare: otherShares[1], Public: publicShares}},
+		{"public of another key", frost.Config{SelfID: 1, SecretShare: secretShares[1], Public: otherPublic}},
+		{"secret does not match its public key", frost.Config{SelfID: 2, SecretShare: &tampered, Public: publicShares}},
+		{"share of another party", frost.Config{SelfID: 1, SecretShare: secretShares[2], Public: publicShares}},
+		{"party not in public", frost.Config{SelfID: 4, SecretShare: eddsa.NewSecretShare(4, &secretShares[1].Secret), Public: publicShares}},
+		{"zero ID", frost.Config{SelfID: 0, SecretShare: eddsa.NewSecretShare(0, &secretShares[1].Secret), Public: publicShares}},
+		{"missing share", frost.Config{SelfID: 1, Public: publicShares}},
+		{"missing public", frost.Config{SelfID: 1, SecretShare: secretShares[1]}},
+	} {
+		assert.Error(t, tt.config.Validate(), tt.name)
+		_, _, err := tt.config.NewSignState(party.IDSlice{1, 2}, MESSAGE, 0)
+		assert.Error(t, err, tt.name)
+		_, _, err = tt.config.NewRefreshState(0)
+		assert.Error(t, err, tt.name)
+	}
+
+	_, err := frost.NewConfig(&keygen.Output{Public: publicShares, SecretKey: otherShares[1]})
+	assert.Error(t, err)
+	_, err = frost.NewConfig(&keygen.Output{})
+	assert.Error(t, err)
+}
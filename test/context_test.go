@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+func TestState_Context(t *testing.T) {
+	_, signIDs, secretShares, publicShares := setupParties(2, 5)
+
+	states := map[party.ID]*state.State{}
+	for _, id := range signIDs {
+		var err error
+		states[id], _, err = frost.NewSignState(signIDs, secretShares[id], publicShares, MESSAGE, 0)
+		require.NoError(t, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var msgs1 []*messages.Message
+	for _, s := range states {
+		out, err := s.ProcessAllContext(ctx)
+		require.NoError(t, err)
+		msgs1 = append(msgs1, out...)
+	}
+
+	s := states[signIDs[0]]
+	require.NoError(t, s.HandleMessageContext(ctx, msgs1[1]))
+
+	cancel()
+
+	assert.ErrorIs(t, s.HandleMessageContext(ctx, msgs1[2]), context.Canceled)
+	out, err := s.ProcessAllContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, out)
+
+	// The session is aborted and does not accept messages anymore
+	assert.True(t, s.IsFinished())
+	assert.Error(t, s.HandleMessage(msgs1[2]))
+	assert.ErrorIs(t, s.WaitForError(), context.Canceled)
+}
+
+func TestState_WaitForErrorContext(t *testing.T) {
+	_, signIDs, secretShares, publicShares := setupParties(2, 5)
+	s, _, err := frost.NewSignState(signIDs, secretShares[signIDs[0]], publicShares, MESSAGE, 0)
+	require.NoError(t, err)
+	s.ProcessAll()
+
+	// No other party sends anything, so we only return because of the deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = s.WaitForErrorContext(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+	assert.True(t, s.IsFinished())
+	assert.ErrorIs(t, s.Err(), context.DeadlineExceeded)
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/helpers"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+func TestTrustedDeal(t *testing.T) {
+	public, secretShares, err := frost.TrustedDeal(1, []party.ID{1, 2, 3}, nil)
+	require.NoError(t, err)
+	require.Len(t, secretShares, 3)
+	assert.Equal(t, party.Size(1), public.Threshold)
+	assert.True(t, public.PartyIDs.Equal(party.IDSlice{1, 2, 3}))
+
+	for id, share := range secretShares {
+		assert.Equal(t, id, share.ID)
+		assert.Equal(t, 1, share.Public.Equal(public.Shares[id]))
+	}
+	assert.NoError(t, ValidateSecrets(secretShares, public.GroupKey, public))
+
+	for _, quorum := range []party.IDSlice{{1, 2}, {1, 3}, {2, 3}, {1, 2, 3}} {
+		sig := runSign(t, quorum, secretShares, public, MESSAGE)
+		assert.True(t, public.GroupKey.Verify(MESSAGE, sig), "quorum %v", quorum)
+		assert.True(t, ed25519.Verify(public.GroupKey.ToEd25519(), MESSAGE, sig.ToEd25519()), "quorum %v", quorum)
+	}
+
+	// The same randomness produces the same sharing
+	public1, shares1, err := frost.TrustedDeal(2, []party.ID{4, 8, 15, 16}, &seededReader{seed: []byte("dealer")})
+	require.NoError(t, err)
+	public2, shares2, err := frost.TrustedDeal(2, []party.ID{16, 15, 8, 4}, &seededReader{seed: []byte("dealer")})
+	require.NoError(t, err)
+	assert.True(t, public1.Equal(public2))
+	for id := range shares1 {
+		assert.Equal(t, 1, shares1[id].Secret.Equal(&shares2[id].Secret))
+	}
+
+	for _, tt := range []struct {
+		name      string
+		threshold party.Size
+		partyIDs  []party.ID
+	}{
+		{"threshold 0", 0, []party.ID{1, 2, 3}},
+		{"threshold N", 3, []party.ID{1, 2, 3}},
+		{"duplicates", 1, []party.ID{1, 2, 2}},
+		{"zero ID", 1, []party.ID{0, 1, 2}},
+		{"empty", 1, nil},
+	} {
+		_, _, err := frost.TrustedDeal(tt.threshold, tt.partyIDs, nil)
+		assert.Error(t, err, tt.name)
+	}
+}
+
+func TestSplitExistingKey(t *testing.T) {
+	pk, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	public, secretShares, err := frost.SplitExistingKey(priv, 2, []party.ID{1, 2, 3, 4, 5}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(pk), []byte(public.GroupKey.ToEd25519()))
+	assert.NoError(t, ValidateSecrets(secretShares, public.GroupKey, public))
+
+	for _, quorum := range []party.IDSlice{{1, 2, 3}, {2, 4, 5}} {
+		sig := runSign(t, quorum, secretShares, public, MESSAGE)
+		assert.True(t, ed25519.Verify(pk, MESSAGE, sig.ToEd25519()), "quorum %v", quorum)
+	}
+
+	// The public key in priv must match its seed
+	tampered := append(ed25519.PrivateKey{}, priv...)
+	tampered[ed25519.SeedSize] ^= 1
+	_, _, err = frost.SplitExistingKey(tampered, 2, []party.ID{1, 2, 3}, nil)
+	assert.Error(t, err)
+
+	_, _, err = frost.SplitExistingKey(priv[:ed25519.SeedSize], 2, []party.ID{1, 2, 3}, nil)
+	assert.Error(t, err)
+	_, _, err = frost.SplitExistingKey(priv, 3, []party.ID{1, 2, 3}, nil)
+	assert.Error(t, err)
+}
+
+func TestReconstruct(t *testing.T) {
+	partyIDs, _, _, _ := setupParties(2, 5)
+	secret, secretShares := helpers.GenerateSecrets(partyIDs, 2)
+	public := helpers.GeneratePublic(2, secretShares)
+
+	subset := func(ids ...party.ID) map[party.ID]*eddsa.SecretShare {
+		shares := make(map[party.ID]*eddsa.SecretShare, len(ids))
+		for _, id := range ids {
+			shares[id] = secretShares[id]
+		}
+		return shares
+	}
+
+	for _, quorum := range []party.IDSlice{{1, 2, 3}, {1, 4, 5}, {2, 3, 4, 5}, partyIDs} {
+		reconstructed, err := frost.Reconstruct(subset(quorum...), public)
+		require.NoError(t, err)
+		assert.Equal(t, 1, reconstructed.Equal(secret), "quorum %v", quorum)
+	}
+
+	// The reconstructed key of an imported Ed25519 key signs like the original
+	pk, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	public2, shares2, err := frost.SplitExistingKey(priv, 1, []party.ID{1, 2, 3}, nil)
+	require.NoError(t, err)
+	reconstructed, err := frost.Reconstruct(shares2, public2)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(pk), []byte(eddsa.NewPublicKeyFromPoint(new(ristretto.Element).ScalarBaseMult(reconstructed)).ToEd25519()))
+
+	// Too few shares
+	_, err = frost.Reconstruct(subset(1, 2), public)
+	assert.Error(t, err)
+	_, err = frost.Reconstruct(nil, public)
+	assert.Error(t, err)
+
+	// A share given under the wrong ID
+	shares := subset(1, 2)
+	shares[3] = secretShares[4]
+	_, err = frost.Reconstruct(shares, public)
+	assert.Error(t, err)
+
+	// A corrupted share
+	corrupted := *secretShares[3]
+	corrupted.Secret.Add(&corrupted.Secret, ristretto.NewScalar().SetUint64(1))
+	shares = subset(1, 2)
+	shares[3] = &corrupted
+	_, err = frost.Reconstruct(shares, public)
+	assert.Error(t, err)
+
+	// Shares of a different sharing
+	_, otherShares := helpers.GenerateSecrets(partyIDs, 2)
+	_, err = frost.Reconstruct(otherShares, public)
+	assert.Error(t, err)
+
+	// A party which is not part of public
+	shares = subset(1, 2, 3)
+	shares[6] = eddsa.NewSecretShare(6, secret)
+	_, err = frost.Reconstruct(shares, public)
+	assert.Error(t, err)
+
+	// A share at the evaluation point of the secret
+	shares = subset(1, 2, 3)
+	shares[0] = eddsa.NewSecretShare(0, secret)
+	_, err = frost.Reconstruct(shares, public)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "contain 0")
+	}
+}
+
+func TestDeriveSecretShare(t *testing.T) {
+	root := bytes.Repeat([]byte{0x42}, frost.MinRootSecretSize)
+	info := []byte("signer group 1")
+	partyIDs := helpers.GenerateSet(5)
+	threshold := party.Size(2)
+
+	public, shares, err := frost.DeriveTrustedDeal(root, threshold, partyIDs, info)
+	require.NoError(t, err)
+
+	for _, id := range partyIDs {
+		// The same inputs give the same share, which is that of the dealer
+		share, err := frost.DeriveSecretShare(root, threshold, id, info)
+		require.NoError(t, err)
+		again, err := frost.DeriveSecretShare(root, threshold, id, info)
+		require.NoError(t, err)
+		assert.True(t, share.Equal(again), "party %d", id)
+		assert.True(t, share.Equal(shares[id]), "party %d", id)
+		assert.Equal(t, 1, public.Shares[id].Equal(&share.Public), "party %d", id)
+
+		// Other parameters give unrelated shares
+		others := []struct {
+			root      []byte
+			threshold party.Size
+			info      []byte
+		}{
+			{bytes.Repeat([]byte{0x43}, frost.MinRootSecretSize), threshold, info},
+			{root, threshold + 1, info},
+			{root, threshold, []byte("signer group 2")},
+		}
+		for _, other := range others {
+			otherShare, err := frost.DeriveSecretShare(other.root, other.threshold, id, other.info)
+			require.NoError(t, err)
+			assert.False(t, share.Equal(otherShare), "party %d", id)
+		}
+	}
+
+	// Any quorum of derived shares interpolates to the group key, and signs for it
+	for _, quorum := range []party.IDSlice{{1, 2, 3}, {2, 4, 5}, partyIDs} {
+		derived := make(map[party.ID]*eddsa.SecretShare, len(quorum))
+		for _, id := range quorum {
+			derived[id], err = frost.DeriveSecretShare(root, threshold, id, info)
+			require.NoError(t, err)
+		}
+		_, err := frost.Reconstruct(derived, public)
+		assert.NoError(t, err, quorum)
+	}
+	sig := runSign(t, party.IDSlice{1, 3, 5}, shares, public, MESSAGE)
+	assert.True(t, ed25519.Verify(public.GroupKey.ToEd25519(), MESSAGE, sig.ToEd25519()))
+
+	// Those of another derivation are not consistent with public
+	quorum := party.IDSlice{1, 2, 3}
+	derived := make(map[party.ID]*eddsa.SecretShare, len(quorum))
+	for _, id := range quorum {
+		derived[id], err = frost.DeriveSecretShare(root, threshold, id, []byte("signer group 2"))
+		require.NoError(t, err)
+	}
+	_, err = frost.Reconstruct(derived, public)
+	assert.Error(t, err)
+
+	_, err = frost.DeriveSecretShare(root[:frost.MinRootSecretSize-1], threshold, 1, info)
+	assert.Error(t, err, "short root secret")
+	_, err = frost.DeriveSecretShare(root, threshold, 0, info)
+	assert.Error(t, err, "zero ID")
+	_, err = frost.DeriveSecretShare(root, 255, 1, info)
+	assert.Error(t, err, "threshold too large")
+	_, err = frost.DeriveSecretShare(root, 254, 1, info)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/sign"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+// newSignStates creates the states of all signers, and returns them with the messages of the first round.
+func newSignStates(t *testing.T, threshold, n party.Size) (party.IDSlice, map[party.ID]*state.State, map[party.ID]*sign.Output, []*messages.Message) {
+	_, signSet, secretShares, publicShares := setupParties(threshold, n)
+
+	states := map[party.ID]*state.State{}
+	outputs := map[party.ID]*sign.Output{}
+	var msgs1 []*messages.Message
+	for _, id := range signSet {
+		var err error
+		states[id], outputs[id], err = frost.NewSignState(signSet, secretShares[id], publicShares, MESSAGE, 0)
+		require.NoError(t, err)
+		msgs1 = append(msgs1, states[id].ProcessAll()...)
+	}
+	return signSet, states, outputs, msgs1
+}
+
+func TestState_DuplicateMessage(t *testing.T) {
+	signSet, states, outputs, msgs1 := newSignStates(t, 2, 5)
+	for _, id := range signSet {
+		for _, msg := range msgs1 {
+			require.NoError(t, states[id].HandleMessage(msg))
+		}
+	}
+
+	// A retransmission is rejected
+	s := states[signSet[0]]
+	for _, msg := range msgs1 {
+		if msg.From != signSet[0] {
+			assert.ErrorIs(t, s.HandleMessage(msg), state.ErrDuplicateMessage)
+		}
+	}
+
+	// A different message for the same round does not replace the first one
+	var other messages.Message
+	data, err := msgs1[1].MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, other.UnmarshalBinary(data))
+	other.Sign1.Di.Set(&other.Sign1.Ei)
+	assert.ErrorIs(t, s.HandleMessage(&other), state.ErrDuplicateMessage)
+
+	require.NoError(t, runRounds(states))
+	for _, id := range signSet {
+		require.NoError(t, states[id].WaitForError())
+	}
+	sig := outputs[signSet[0]].Signature
+	require.NotNil(t, sig)
+	for _, id := range signSet {
+		assert.Equal(t, 1, outputs[id].Signature.Equal(sig))
+	}
+}
+
+func TestState_EarlyMessage(t *testing.T) {
+	signSet, states, outputs, msgs1 := newSignStates(t, 2, 3)
+	slowID := signSet[0]
+	slow := states[slowID]
+
+	// All parties but the slow one move on to round 2
+	var msgs2 []*messages.Message
+	for _, id := range signSet[1:] {
+		for _, msg := range msgs1 {
+			require.NoError(t, states[id].HandleMessage(msg))
+		}
+		msgs2 = append(msgs2, states[id].ProcessAll()...)
+	}
+	require.Len(t, msgs2, 2)
+
+	// The slow party receives the commitment of party 2 and its signature share, but not the commitment of party 3.
+	for _, msg := range msgs1 {
+		if msg.From == signSet[1] {
+			require.NoError(t, slow.HandleMessage(msg))
+		}
+	}
+	for _, msg := range msgs2 {
+		if msg.From == signSet[1] {
+			require.NoError(t, slow.HandleMessage(msg))
+			assert.ErrorIs(t, slow.HandleMessage(msg), state.ErrDuplicateMessage)
+		}
+	}
+	assert.Nil(t, slow.ProcessAll(), "round 1 must wait for all commitments")
+
+	for _, msg := range msgs1 {
+		if msg.From == signSet[2] {
+			require.NoError(t, slow.HandleMessage(msg))
+		}
+	}
+	msgs2 = append(msgs2, slow.ProcessAll()...)
+	require.Len(t, msgs2, 3)
+	assert.False(t, slow.IsFinished(), "round 2 must wait for all signature shares")
+
+	for _, msg := range msgs2 {
+		if msg.From == signSet[2] {
+			require.NoError(t, slow.HandleMessage(msg))
+		}
+	}
+	slow.ProcessAll()
+	require.NoError(t, slow.WaitForError())
+
+	for _, id := range signSet[1:] {
+		for _, msg := range msgs2 {
+			require.NoError(t, states[id].HandleMessage(msg))
+		}
+		states[id].ProcessAll()
+		require.NoError(t, states[id].WaitForError())
+		assert.Equal(t, 1, outputs[id].Signature.Equal(outputs[slowID].Signature))
+	}
+}
+
+func TestState_SetMessageLimit(t *testing.T) {
+	signSet, states, outputs, msgs1 := newSignStates(t, 2, 3)
+	s := states[signSet[0]]
+	s.SetMessageLimit(2)
+
+	logger := &recordingLogger{}
+	s.SetLogger(logger)
+
+	// first returns the commitment of the second party, and others a different commitment for the same round.
+	var first *messages.Message
+	for _, msg := range msgs1 {
+		if msg.From == signSet[1] {
+			first = msg
+		}
+	}
+	n := uint64(0)
+	other := func() *messages.Message {
+		n++
+		D := new(ristretto.Element).ScalarBaseMult(ristretto.NewScalar().SetUint64(2 * n))
+		E := new(ristretto.Element).ScalarBaseMult(ristretto.NewScalar().SetUint64(2*n + 1))
+		return messages.NewSign1(signSet[1], D, E)
+	}
+
+	require.NoError(t, s.HandleMessage(first))
+	assert.ErrorIs(t, s.HandleMessage(other()), state.ErrDuplicateMessage)
+	for i := 0; i < 10; i++ {
+		err := s.HandleMessage(other())
+		assert.ErrorIs(t, err, state.ErrTooManyMessages)
+		var validationErr *state.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, signSet[1], validationErr.Sender)
+	}
+	// Even the original message is dropped once the limit is reached
+	assert.ErrorIs(t, s.HandleMessage(first), state.ErrTooManyMessages)
+	assert.Equal(t, 1, strings.Count(logger.String(), `msg="message limit reached" round=1 from=2 limit=2`))
+
+	// The other parties are not affected
+	for _, msg := range msgs1 {
+		if msg.From == signSet[2] {
+			require.NoError(t, s.HandleMessage(msg))
+		}
+	}
+
+	// Only the first commitment was retained, so the session produces a valid signature
+	for _, id := range signSet[1:] {
+		for _, msg := range msgs1 {
+			require.NoError(t, states[id].HandleMessage(msg))
+		}
+	}
+	require.NoError(t, runRounds(states))
+	for _, id := range signSet {
+		require.NoError(t, states[id].WaitForError())
+	}
+	sig := outputs[signSet[0]].Signature
+	require.NotNil(t, sig)
+	for _, id := range signSet {
+		assert.Equal(t, 1, outputs[id].Signature.Equal(sig))
+	}
+}
+
+func TestState_SetMessageLimit_NextRound(t *testing.T) {
+	signSet, states, outputs, msgs1 := newSignStates(t, 2, 3)
+	s := states[signSet[0]]
+	s.SetMessageLimit(1)
+
+	// The other parties finish the first round, and send their signature shares before s has received all commitments
+	var msgs2 []*messages.Message
+	for _, id := range signSet[1:] {
+		for _, msg := range msgs1 {
+			require.NoError(t, states[id].HandleMessage(msg))
+		}
+		msgs2 = append(msgs2, states[id].ProcessAll()...)
+	}
+	for _, id := range signSet[1:] {
+		for _, msgs := range [][]*messages.Message{msgs1, msgs2} {
+			for _, msg := range msgs {
+				if msg.From == id {
+					require.NoError(t, s.HandleMessage(msg), "message %v from %d", msg.Type, id)
+				}
+			}
+		}
+	}
+
+	// The signature shares of the other parties are also exchanged between them
+	for _, msg := range msgs2 {
+		for _, id := range signSet[1:] {
+			if msg.From != id {
+				require.NoError(t, states[id].HandleMessage(msg))
+			}
+		}
+	}
+
+	require.NoError(t, runRounds(states))
+	for _, id := range signSet {
+		require.NoError(t, states[id].WaitForError())
+	}
+	assert.Equal(t, 1, outputs[signSet[0]].Signature.Equal(outputs[signSet[1]].Signature))
+}
+
+func TestState_SetMessageLimit_HandleMessages(t *testing.T) {
+	signSet, states, _, msgs1 := newSignStates(t, 2, 3)
+	s := states[signSet[0]]
+	s.SetMessageLimit(1)
+
+	// A rejected batch does not count against the limit, so that the corrected batch is accepted
+	var fromOthers []*messages.Message
+	for _, msg := range msgs1 {
+		if msg.From != signSet[0] {
+			fromOthers = append(fromOthers, msg)
+		}
+	}
+	rejected := append(append([]*messages.Message{}, fromOthers...), fromOthers[1])
+	assert.ErrorIs(t, s.HandleMessages(rejected), state.ErrDuplicateMessage)
+	require.NoError(t, s.HandleMessages(msgs1))
+
+	// A message counted by HandleMessage makes the batch exceed the limit
+	signSet, states, _, msgs1 = newSignStates(t, 2, 3)
+	s = states[signSet[0]]
+	s.SetMessageLimit(1)
+	for _, msg := range msgs1 {
+		if msg.From == signSet[1] {
+			// Not addressed to s, so it is ignored, but counted
+			misrouted := *msg
+			misrouted.To = signSet[2]
+			require.NoError(t, s.HandleMessage(&misrouted))
+		}
+	}
+	err := s.HandleMessages(msgs1)
+	assert.ErrorIs(t, err, state.ErrTooManyMessages)
+	var validationErr *state.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, signSet[1], validationErr.Sender)
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/sign"
+	"github.com/taurusgroup/frost-ed25519/pkg/helpers"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+// runKeygenTampered runs a key generation between 3 parties, in which tamper may modify every message before it is
+// delivered, and returns the error of party 1.
+func runKeygenTampered(t *testing.T, tamper func(msg *messages.Message)) error {
+	partyIDs := helpers.GenerateSet(3)
+	states := map[party.ID]*state.State{}
+	for _, id := range partyIDs {
+		var err error
+		states[id], _, err = frost.NewKeygenState(id, partyIDs, 1, 0)
+		require.NoError(t, err)
+	}
+
+	var msgs [][]byte
+	for round := 0; round < 4; round++ {
+		var next [][]byte
+		for _, id := range partyIDs {
+			s := states[id]
+			if s.IsFinished() {
+				continue
+			}
+			for _, data := range msgs {
+				var msg messages.Message
+				require.NoError(t, msg.UnmarshalBinary(data))
+				_ = s.HandleMessage(&msg)
+			}
+			for _, msg := range s.ProcessAll() {
+				tamper(msg)
+				data, err := msg.MarshalBinary()
+				require.NoError(t, err)
+				next = append(next, data)
+			}
+		}
+		msgs = next
+	}
+	return states[1].WaitForError()
+}
+
+func TestErrors_Keygen(t *testing.T) {
+	one := ristretto.NewScalar().SetUint64(1)
+
+	// The proof of party 2 is invalid
+	err := runKeygenTampered(t, func(msg *messages.Message) {
+		if msg.Type == messages.MessageTypeKeyGen1 && msg.From == 2 {
+			msg.KeyGen1.Proof.R.Add(&msg.KeyGen1.Proof.R, one)
+		}
+	})
+	var abortErr *state.AbortError
+	require.ErrorAs(t, err, &abortErr)
+	assert.Equal(t, party.IDSlice{2}, abortErr.Culprits)
+
+	// Parties 2 and 3 send an invalid share to party 1
+	err = runKeygenTampered(t, func(msg *messages.Message) {
+		if msg.Type == messages.MessageTypeKeyGen2 && msg.To == 1 {
+			msg.KeyGen2.Share.Add(&msg.KeyGen2.Share, one)
+		}
+	})
+	require.ErrorAs(t, err, &abortErr)
+	assert.Equal(t, party.IDSlice{2, 3}, abortErr.Culprits)
+	var stateErr *state.Error
+	require.ErrorAs(t, err, &stateErr)
+	assert.Equal(t, party.ID(0), stateErr.PartyID, "the culprit is not unique")
+
+	// The commitments of party 3 are malformed
+	err = runKeygenTampered(t, func(msg *messages.Message) {
+		if msg.Type == messages.MessageTypeKeyGen1 && msg.From == 3 {
+			// The highest degree commitment is replaced by the identity, whose encoding is all zeros
+			data, err := msg.MarshalBinary()
+			require.NoError(t, err)
+			copy(data[len(data)-32:], make([]byte, 32))
+			var tampered messages.Message
+			require.NoError(t, tampered.UnmarshalBinary(data))
+			*msg = tampered
+		}
+	})
+	var validationErr *state.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, party.ID(3), validationErr.Sender)
+	assert.False(t, errors.As(err, &abortErr))
+}
+
+func TestErrors_Sign(t *testing.T) {
+	signSet, states, _, msgs1 := newSignStates(t, 2, 4)
+	s := states[signSet[0]]
+
+	// A message for another protocol is rejected, without aborting
+	keygenMsg := messages.NewKeyGen2(signSet[1], signSet[0], ristretto.NewScalar())
+	err := s.HandleMessage(keygenMsg)
+	assert.ErrorIs(t, err, state.ErrWrongRound)
+	var validationErr *state.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, signSet[1], validationErr.Sender)
+
+	for _, msg := range msgs1 {
+		require.NoError(t, s.HandleMessage(msg))
+	}
+	err = s.HandleMessage(msgs1[1])
+	assert.ErrorIs(t, err, state.ErrDuplicateMessage)
+	require.ErrorAs(t, err, &validationErr)
+	require.Len(t, s.ProcessAll(), 1)
+
+	// The commitments of the first round are no longer accepted
+	err = s.HandleMessage(msgs1[1])
+	assert.ErrorIs(t, err, state.ErrWrongRound)
+	require.ErrorAs(t, err, &validationErr)
+	assert.False(t, s.IsFinished())
+
+	// Once the protocol has finished, no message is accepted
+	s.Zeroize()
+	assert.ErrorIs(t, s.HandleMessage(msgs1[2]), state.ErrFinished)
+	assert.ErrorIs(t, s.WaitForError(), state.ErrZeroized)
+
+	// An invalid signature share aborts the protocol with the culprit
+	states = runSignTamperedStates(t, func(msg *messages.Message) {
+		if msg.Type == messages.MessageTypeSign2 && msg.From == 2 {
+			msg.Sign2.Zi.Add(&msg.Sign2.Zi, ristretto.NewScalar().SetUint64(1))
+		}
+	})
+	err = states[1].WaitForError()
+	assert.ErrorIs(t, err, sign.ErrValidateSigShare)
+	var abortErr *state.AbortError
+	require.ErrorAs(t, err, &abortErr)
+	assert.Equal(t, party.IDSlice{2}, abortErr.Culprits)
+
+	// A silent party makes the protocol time out
+	states = runSignTamperedStates(t, nil, 2)
+	err = states[1].WaitForError()
+	assert.ErrorIs(t, err, state.ErrTimeout)
+	var timeoutErr *state.TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, party.IDSlice{2}, timeoutErr.Missing)
+	assert.False(t, errors.As(err, &abortErr))
+}
+
+// runSignTamperedStates runs a signing session between 3 parties, in which tamper may modify every message before
+// it is delivered, and the silent parties do not send any message.
+func runSignTamperedStates(t *testing.T, tamper func(msg *messages.Message), silent ...party.ID) map[party.ID]*state.State {
+	_, signSet, secretShares, publicShares := setupParties(2, 3)
+	states := map[party.ID]*state.State{}
+	for _, id := range signSet {
+		var err error
+		states[id], _, err = frost.NewSignState(signSet, secretShares[id], publicShares, MESSAGE, 20*time.Millisecond)
+		require.NoError(t, err)
+	}
+
+	isSilent := func(id party.ID) bool {
+		for _, other := range silent {
+			if id == other {
+				return true
+			}
+		}
+		return false
+	}
+
+	var msgs [][]byte
+	for round := 0; round < 3; round++ {
+		var next [][]byte
+		for _, id := range signSet {
+			s := states[id]
+			for _, data := range msgs {
+				var msg messages.Message
+				require.NoError(t, msg.UnmarshalBinary(data))
+				_ = s.HandleMessage(&msg)
+			}
+			for _, msg := range s.ProcessAll() {
+				if isSilent(id) {
+					continue
+				}
+				if tamper != nil {
+					tamper(msg)
+				}
+				data, err := msg.MarshalBinary()
+				require.NoError(t, err)
+				next = append(next, data)
+			}
+		}
+		msgs = next
+	}
+	return states
+}
@@ -1,14 +1,21 @@
 package main
 
 import (
+	"bytes"
+	"crypto/sha512"
 	"errors"
+	"fmt"
+	"io"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
 	"github.com/taurusgroup/frost-ed25519/pkg/frost"
 	"github.com/taurusgroup/frost-ed25519/pkg/frost/keygen"
 	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
 	"github.com/taurusgroup/frost-ed25519/pkg/helpers"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
 	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
 	"github.com/taurusgroup/frost-ed25519/pkg/state"
 )
@@ -33,6 +40,7 @@ func TestKeygen(t *testing.T) {
 
 	msgsOut1 := make([][]byte, 0, N)
 	msgsOut2 := make([][]byte, 0, N*(N-1)/2)
+	msgsOut3 := make([][]byte, 0, N)
 
 	for _, s := range states {
 		msgs1, err := helpers.PartyRoutine(nil, s)
@@ -51,7 +59,15 @@ func TestKeygen(t *testing.T) {
 	}
 
 	for _, s := range states {
-		_, err := helpers.PartyRoutine(msgsOut2, s)
+		msgs3, err := helpers.PartyRoutine(msgsOut2, s)
+		if err != nil {
+			t.Error(err)
+		}
+		msgsOut3 = append(msgsOut3, msgs3...)
+	}
+
+	for _, s := range states {
+		_, err := helpers.PartyRoutine(msgsOut3, s)
 		if err != nil {
 			t.Error(err)
 		}
@@ -143,3 +159,374 @@ func ValidateSecrets(secrets map[party.ID]*eddsa.SecretShare, groupKey *eddsa.Pu
 
 	return nil
 }
+
+func TestKeygen_Output(t *testing.T) {
+	N := party.Size(5)
+	T := party.Size(2)
+	partyIDs := helpers.GenerateSet(N)
+
+	states := map[party.ID]*state.State{}
+	outputs := map[party.ID]*keygen.Output{}
+	for _, id := range partyIDs {
+		var err error
+		states[id], outputs[id], err = frost.NewKeygenState(id, partyIDs, T, 0)
+		require.NoError(t, err)
+	}
+	require.NoError(t, runRounds(states))
+
+	public := outputs[partyIDs[0]].Public
+	commitments := outputs[partyIDs[0]].Commitments
+	require.Len(t, commitments, int(T)+1)
+	assert.Equal(t, public.GroupKey.ToEd25519(), eddsa.NewPublicKeyFromPoint(commitments[0]).ToEd25519())
+
+	for _, id := range partyIDs {
+		require.NoError(t, states[id].WaitForError())
+		output := outputs[id]
+		assert.True(t, output.Public.Equal(public))
+		assert.Equal(t, id, output.SecretKey.ID)
+		assert.Equal(t, 1, output.SecretKey.Public.Equal(public.Shares[id]))
+		require.Len(t, output.Commitments, len(commitments))
+		for i := range commitments {
+			assert.Equal(t, 1, output.Commitments[i].Equal(commitments[i]))
+		}
+
+		assert.NoError(t, output.SecretKey.VerifyAgainstCommitments(output.Commitments, id))
+
+		// The public share is the evaluation of the committed polynomial
+		var expected, term ristretto.Element
+		expected.Set(ristretto.NewIdentityElement())
+		x := ristretto.NewScalar().SetUint64(1)
+		for _, c := range commitments {
+			term.ScalarMult(x, c)
+			expected.Add(&expected, &term)
+			x.Multiply(x, id.Scalar())
+		}
+		assert.Equal(t, 1, expected.Equal(public.Shares[id]), "party %d", id)
+	}
+
+	// Any T+1 public shares reconstruct the group key
+	for _, quorum := range []party.IDSlice{{1, 2, 3}, {1, 3, 5}, {2, 4, 5}, {1, 2, 3, 4, 5}} {
+		lagrange, err := party.LagrangeCoefficients(quorum)
+		require.NoError(t, err)
+		var groupKey, term ristretto.Element
+		groupKey.Set(ristretto.NewIdentityElement())
+		for _, id := range quorum {
+			term.ScalarMult(lagrange[id], public.Shares[id])
+			groupKey.Add(&groupKey, &term)
+		}
+		assert.True(t, public.GroupKey.Equal(eddsa.NewPublicKeyFromPoint(&groupKey)), "quorum %v", quorum)
+	}
+
+	// T shares do not
+	lagrange, err := party.LagrangeCoefficients(party.IDSlice{1, 2})
+	require.NoError(t, err)
+	var groupKey, term ristretto.Element
+	groupKey.Set(ristretto.NewIdentityElement())
+	for id, l := range lagrange {
+		term.ScalarMult(l, public.Shares[id])
+		groupKey.Add(&groupKey, &term)
+	}
+	assert.False(t, public.GroupKey.Equal(eddsa.NewPublicKeyFromPoint(&groupKey)))
+}
+
+// seededReader is a deterministic stream of bytes SHA-512(seed ∥ counter), for reproducible tests only.
+type seededReader struct {
+	seed    []byte
+	counter uint64
+	buf     []byte
+}
+
+func (r *seededReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(r.buf) == 0 {
+			block := sha512.Sum512(append(append([]byte{}, r.seed...), byte(r.counter>>8), byte(r.counter)))
+			r.buf = block[:]
+			r.counter++
+		}
+		copied := copy(p[n:], r.buf)
+		r.buf = r.buf[copied:]
+		n += copied
+	}
+	return n, nil
+}
+
+func runKeygenSeeded(t *testing.T, partyIDs party.IDSlice, threshold party.Size, seed string) map[party.ID]*keygen.Output {
+	states := map[party.ID]*state.State{}
+	outputs := map[party.ID]*keygen.Output{}
+	for _, id := range partyIDs {
+		var err error
+		random := &seededReader{seed: append([]byte(seed), id.Bytes()...)}
+		states[id], outputs[id], err = frost.NewKeygenState(id, partyIDs, threshold, 0, keygen.WithRandom(random))
+		require.NoError(t, err)
+	}
+	require.NoError(t, runRounds(states))
+	for _, s := range states {
+		require.NoError(t, s.WaitForError())
+	}
+	return outputs
+}
+
+func TestKeygen_WithRandom(t *testing.T) {
+	partyIDs := helpers.GenerateSet(4)
+
+	outputs1 := runKeygenSeeded(t, partyIDs, 2, "seed")
+	outputs2 := runKeygenSeeded(t, partyIDs, 2, "seed")
+	for _, id := range partyIDs {
+		assert.True(t, outputs1[id].Public.Equal(outputs2[id].Public))
+		assert.True(t, outputs1[id].Public.GroupKey.Equal(outputs2[id].Public.GroupKey))
+		assert.Equal(t, 1, outputs1[id].SecretKey.Secret.Equal(&outputs2[id].SecretKey.Secret), "party %d", id)
+	}
+
+	other := runKeygenSeeded(t, partyIDs, 2, "other seed")
+	assert.False(t, outputs1[1].Public.GroupKey.Equal(other[1].Public.GroupKey))
+
+	// The shares are still usable for signing
+	secrets := map[party.ID]*eddsa.SecretShare{}
+	for _, id := range partyIDs {
+		secrets[id] = outputs1[id].SecretKey
+	}
+	assert.NoError(t, ValidateSecrets(secrets, outputs1[1].Public.GroupKey, outputs1[1].Public))
+
+	// A failing reader aborts the protocol
+	s, _, err := frost.NewKeygenState(1, partyIDs, 2, 0, keygen.WithRandom(bytes.NewReader(make([]byte, 40))))
+	require.NoError(t, err)
+	assert.Nil(t, s.ProcessAll())
+	assert.ErrorIs(t, s.WaitForError(), io.ErrUnexpectedEOF)
+}
+
+func TestKeygen_IdentityCommitment(t *testing.T) {
+	partyIDs := helpers.GenerateSet(3)
+	states := map[party.ID]*state.State{}
+	for _, id := range partyIDs {
+		var err error
+		states[id], _, err = frost.NewKeygenState(id, partyIDs, 1, 0)
+		require.NoError(t, err)
+	}
+
+	var msgs1 []*messages.Message
+	for _, id := range partyIDs {
+		msgs1 = append(msgs1, states[id].ProcessAll()...)
+	}
+
+	// Party 2 replaces its highest degree commitment by the identity, whose encoding is all zeros
+	for i, msg := range msgs1 {
+		if msg.From != 2 {
+			continue
+		}
+		data, err := msg.MarshalBinary()
+		require.NoError(t, err)
+		copy(data[len(data)-32:], make([]byte, 32))
+		var tampered messages.Message
+		require.NoError(t, tampered.UnmarshalBinary(data))
+		msgs1[i] = &tampered
+	}
+
+	s := states[1]
+	for _, msg := range msgs1 {
+		require.NoError(t, s.HandleMessage(msg))
+	}
+	assert.Nil(t, s.ProcessAll())
+	err := s.WaitForError()
+	require.Error(t, err)
+	var stateErr *state.Error
+	require.ErrorAs(t, err, &stateErr)
+	assert.Equal(t, party.ID(2), stateErr.PartyID)
+	assert.Contains(t, err.Error(), "identity")
+}
+
+// labeledHasher is an eddsa.Hasher which prepends label to the input of eddsa.SHA512, and counts its calls.
+type labeledHasher struct {
+	label string
+	calls int
+}
+
+func (h *labeledHasher) Hash(label string, m ...[]byte) []byte {
+	h.calls++
+	return eddsa.SHA512.Hash(h.label+label, m...)
+}
+
+func (h *labeledHasher) HashToScalar(s *ristretto.Scalar, label string, m ...[]byte) *ristretto.Scalar {
+	h.calls++
+	return eddsa.SHA512.HashToScalar(s, h.label+label, m...)
+}
+
+func TestKeygen_WithHasher(t *testing.T) {
+	partyIDs := helpers.GenerateSet(3)
+
+	// newStates returns the states of all parties, where party 3 uses h3 and the others h.
+	newStates := func(h, h3 eddsa.Hasher) map[party.ID]*state.State {
+		states := map[party.ID]*state.State{}
+		for _, id := range partyIDs {
+			hasher := h
+			if id == 3 {
+				hasher = h3
+			}
+			var err error
+			states[id], _, err = frost.NewKeygenState(id, partyIDs, 1, 0, keygen.WithHasher(hasher))
+			require.NoError(t, err)
+		}
+		return states
+	}
+
+	h := &labeledHasher{label: "test"}
+	require.NoError(t, runRounds(newStates(h, h)))
+	assert.NotZero(t, h.calls, "the proofs of knowledge should use the Hasher")
+
+	// The proof of party 3 is rejected by the others
+	states := newStates(eddsa.SHA512, &labeledHasher{label: "other"})
+	var msgs1 []*messages.Message
+	for _, id := range partyIDs {
+		msgs1 = append(msgs1, states[id].ProcessAll()...)
+	}
+	s := states[1]
+	for _, msg := range msgs1 {
+		require.NoError(t, s.HandleMessage(msg))
+	}
+	assert.Nil(t, s.ProcessAll())
+	var stateErr *state.Error
+	require.ErrorAs(t, s.WaitForError(), &stateErr)
+	assert.Equal(t, party.ID(3), stateErr.PartyID)
+}
+
+func TestNewKeygenState_Threshold(t *testing.T) {
+	partyIDs := helpers.GenerateSet(5)
+	for _, tt := range []struct {
+		threshold party.Size
+		valid     bool
+	}{
+		{0, false},
+		{1, true},
+		{4, true},
+		{5, false},
+		{6, false},
+	} {
+		_, _, err := frost.NewKeygenState(1, partyIDs, tt.threshold, 0)
+		if tt.valid {
+			assert.NoError(t, err, "threshold %d", tt.threshold)
+			continue
+		}
+		if assert.Error(t, err, "threshold %d", tt.threshold) {
+			assert.Contains(t, err.Error(), fmt.Sprintf("threshold %d is invalid for 5 parties", tt.threshold))
+		}
+		_, _, err = frost.TrustedDeal(tt.threshold, partyIDs, nil)
+		assert.Error(t, err, "threshold %d", tt.threshold)
+	}
+}
+
+func TestNewKeygenState_InvalidIDs(t *testing.T) {
+	for _, tt := range []struct {
+		partyIDs party.IDSlice
+		wantErr  string
+	}{
+		{party.IDSlice{1, 2, 2}, "ID 2 appears more than once"},
+		{party.IDSlice{0, 1, 2}, "contain 0"},
+	} {
+		_, _, err := frost.NewKeygenState(1, tt.partyIDs, 1, 0)
+		if assert.Error(t, err, "%v", tt.partyIDs) {
+			assert.Contains(t, err.Error(), tt.wantErr)
+		}
+		_, _, err = frost.KeygenAll(1, tt.partyIDs)
+		if assert.Error(t, err, "%v", tt.partyIDs) {
+			assert.Contains(t, err.Error(), tt.wantErr)
+		}
+		_, _, err = frost.TrustedDeal(1, tt.partyIDs, nil)
+		if assert.Error(t, err, "%v", tt.partyIDs) {
+			assert.Contains(t, err.Error(), tt.wantErr)
+		}
+	}
+}
+
+func TestKeygen_InconsistentGroupKey(t *testing.T) {
+	partyIDs := helpers.GenerateSet(3)
+	states := map[party.ID]*state.State{}
+	for _, id := range partyIDs {
+		var err error
+		states[id], _, err = frost.NewKeygenState(id, partyIDs, 1, 0)
+		require.NoError(t, err)
+	}
+	// Party 1 runs a second session with the same parameters, whose messages it sends to party 3 only.
+	// Both sessions are valid, so party 3 accepts the commitments and the share, but obtains a different group key.
+	other, _, err := frost.NewKeygenState(1, partyIDs, 1, 0)
+	require.NoError(t, err)
+
+	marshal := func(msgs []*messages.Message) [][]byte {
+		out := make([][]byte, 0, len(msgs))
+		for _, msg := range msgs {
+			data, err := msg.MarshalBinary()
+			require.NoError(t, err)
+			out = append(out, data)
+		}
+		return out
+	}
+	deliver := func(s *state.State, to party.ID, msgs [][]byte, from ...party.ID) {
+		for _, data := range msgs {
+			var msg messages.Message
+			require.NoError(t, msg.UnmarshalBinary(data))
+			if msg.From == to || (msg.To != 0 && msg.To != to) {
+				continue
+			}
+			for _, id := range from {
+				if msg.From == id {
+					require.NoError(t, s.HandleMessage(&msg))
+				}
+			}
+		}
+	}
+
+	var msgs1 [][]byte
+	for _, id := range partyIDs {
+		msgs1 = append(msgs1, marshal(states[id].ProcessAll())...)
+	}
+	otherMsgs1 := marshal(other.ProcessAll())
+	deliver(states[1], 1, msgs1, 2, 3)
+	deliver(states[2], 2, msgs1, 1, 3)
+	deliver(states[3], 3, msgs1, 2)
+	deliver(states[3], 3, otherMsgs1, 1)
+	deliver(other, 1, msgs1, 2, 3)
+
+	var msgs2 [][]byte
+	for _, id := range partyIDs {
+		msgs2 = append(msgs2, marshal(states[id].ProcessAll())...)
+	}
+	otherMsgs2 := marshal(other.ProcessAll())
+	deliver(states[1], 1, msgs2, 2, 3)
+	deliver(states[2], 2, msgs2, 1, 3)
+	deliver(states[3], 3, msgs2, 2)
+	deliver(states[3], 3, otherMsgs2, 1)
+
+	var msgs3 [][]byte
+	for _, id := range partyIDs {
+		msgs3 = append(msgs3, marshal(states[id].ProcessAll())...)
+	}
+	require.Len(t, msgs3, 3, "the shares should be accepted")
+	for _, id := range partyIDs {
+		deliver(states[id], id, msgs3, partyIDs...)
+		_ = states[id].ProcessAll()
+
+		err := states[id].WaitForError()
+		require.Error(t, err, "party %d", id)
+		var abortErr *state.AbortError
+		require.ErrorAs(t, err, &abortErr)
+		assert.Empty(t, abortErr.Culprits, "party %d", id)
+		assert.Contains(t, err.Error(), "inconsistent")
+	}
+}
+
+func TestPublic_Accessors(t *testing.T) {
+	partyIDs := party.NewIDSlice([]party.ID{8, 2, 6, 4})
+	threshold := party.Size(2)
+	outputs := runKeygenSeeded(t, partyIDs, threshold, "accessors")
+
+	for _, id := range partyIDs {
+		public := outputs[id].Public
+		assert.Equal(t, len(partyIDs), public.PartySize())
+		assert.Equal(t, threshold, public.Threshold)
+		assert.Equal(t, party.IDSlice{2, 4, 6, 8}, public.IDs())
+	}
+
+	// Modifying the result does not change the Public
+	ids := outputs[2].Public.IDs()
+	ids[0] = 10
+	assert.Equal(t, party.ID(2), outputs[2].Public.IDs()[0])
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+)
+
+func TestSignAll(t *testing.T) {
+	public, secretShares, err := frost.TrustedDeal(2, []party.ID{1, 2, 3, 4, 5}, nil)
+	require.NoError(t, err)
+
+	for _, quorum := range [][]party.ID{{1, 2, 3}, {5, 3, 1}, {1, 2, 3, 4, 5}} {
+		sig, err := frost.SignAll(secretShares, public, quorum, MESSAGE)
+		require.NoError(t, err, "quorum %v", quorum)
+		assert.True(t, ed25519.Verify(public.GroupKey.ToEd25519(), MESSAGE, sig.ToEd25519()), "quorum %v", quorum)
+	}
+
+	_, err = frost.SignAll(secretShares, public, []party.ID{1, 2}, MESSAGE)
+	assert.Error(t, err, "the quorum is too small")
+	_, err = frost.SignAll(secretShares, public, nil, MESSAGE)
+	assert.Error(t, err, "the quorum is empty")
+
+	delete(secretShares, 3)
+	_, err = frost.SignAll(secretShares, public, []party.ID{1, 2, 3}, MESSAGE)
+	assert.Error(t, err, "a share is missing")
+}
+
+func TestKeygenAll(t *testing.T) {
+	partyIDs := []party.ID{2, 4, 6, 8}
+	secretShares, public, err := frost.KeygenAll(2, partyIDs)
+	require.NoError(t, err)
+	require.Len(t, secretShares, len(partyIDs))
+	assert.Equal(t, party.Size(2), public.Threshold)
+	assert.NoError(t, ValidateSecrets(secretShares, public.GroupKey, public))
+
+	// Any quorum of shares reconstructs the group key
+	for _, quorum := range [][]party.ID{{2, 4, 6}, {4, 6, 8}, {2, 4, 6, 8}} {
+		shares := make(map[party.ID]*eddsa.SecretShare, len(quorum))
+		for _, id := range quorum {
+			shares[id] = secretShares[id]
+		}
+		secret, err := frost.Reconstruct(shares, public)
+		require.NoError(t, err, "quorum %v", quorum)
+		var groupKey ristretto.Element
+		groupKey.ScalarBaseMult(secret)
+		assert.True(t, public.GroupKey.Equal(eddsa.NewPublicKeyFromPoint(&groupKey)), "quorum %v", quorum)
+	}
+
+	sig, err := frost.SignAll(secretShares, public, []party.ID{8, 2, 6}, MESSAGE)
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(public.GroupKey.ToEd25519(), MESSAGE, sig.ToEd25519()))
+
+	_, _, err = frost.KeygenAll(4, partyIDs)
+	assert.Error(t, err, "the threshold is too large")
+	_, _, err = frost.KeygenAll(1, []party.ID{1, 2, 2})
+	assert.Error(t, err, "partyIDs contains duplicates")
+	_, _, err = frost.KeygenAll(1, nil)
+	assert.Error(t, err, "partyIDs is empty")
+}
+
+func TestLargePartyIDs(t *testing.T) {
+	partyIDs := []party.ID{65536, 70000, 1 << 31, math.MaxUint32}
+	secretShares, public, err := frost.KeygenAll(2, partyIDs)
+	require.NoError(t, err)
+	assert.NoError(t, ValidateSecrets(secretShares, public.GroupKey, public))
+
+	sig, err := frost.SignAll(secretShares, public, []party.ID{math.MaxUint32, 70000, 65536}, MESSAGE)
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(public.GroupKey.ToEd25519(), MESSAGE, sig.ToEd25519()))
+
+	// The shares and public data survive serialization
+	data, err := secretShares[math.MaxUint32].MarshalBinary()
+	require.NoError(t, err)
+	var share eddsa.SecretShare
+	require.NoError(t, share.UnmarshalBinary(data))
+	assert.True(t, share.Equal(secretShares[math.MaxUint32]))
+
+	data, err = json.Marshal(public)
+	require.NoError(t, err)
+	var decoded eddsa.Public
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.True(t, decoded.Equal(public))
+}
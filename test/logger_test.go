@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+// recordingLogger is a state.Logger which records every event as a line msg="..." key=value ...,
+// where values containing spaces or quotes are quoted, as by the text handler of log/slog.
+type recordingLogger struct {
+	records []string
+}
+
+func (l *recordingLogger) Debug(msg string, args ...interface{}) {
+	record := "msg=" + strconv.Quote(msg)
+	for i := 0; i+1 < len(args); i += 2 {
+		value := fmt.Sprint(args[i+1])
+		if strings.ContainsAny(value, " \"=") {
+			value = strconv.Quote(value)
+		}
+		record += fmt.Sprintf(" %v=%s", args[i], value)
+	}
+	l.records = append(l.records, record)
+}
+
+// String returns all records, one per line.
+func (l *recordingLogger) String() string {
+	return strings.Join(l.records, "\n")
+}
+
+func TestState_SetLogger(t *testing.T) {
+	_, signSet, secretShares, publicShares := setupParties(1, 3)
+
+	logger := &recordingLogger{}
+
+	states := map[party.ID]*state.State{}
+	for _, id := range signSet {
+		var err error
+		states[id], _, err = frost.NewSignState(signSet, secretShares[id], publicShares, MESSAGE, 0)
+		require.NoError(t, err)
+	}
+	s := states[signSet[0]]
+	s.SetLogger(logger)
+
+	var msgs1 []*messages.Message
+	for _, id := range signSet {
+		msgs1 = append(msgs1, states[id].ProcessAll()...)
+	}
+	for _, msg := range msgs1 {
+		require.NoError(t, s.HandleMessage(msg))
+	}
+
+	// Deliver the commitment of the second party again
+	var duplicate *messages.Message
+	for _, msg := range msgs1 {
+		if msg.From == signSet[1] {
+			duplicate = msg
+		}
+	}
+	require.Error(t, s.HandleMessage(duplicate))
+
+	records := logger.records
+	assert.Contains(t, records[0], `msg="round started" round=0`)
+	assert.Contains(t, records[1], `msg="round finished" round=0`)
+	assert.Contains(t, records[2], `msg="message accepted" round=1 from=2`)
+	rejected := records[len(records)-1]
+	assert.Contains(t, rejected, `msg="message rejected" round=1 from=2`)
+	assert.Contains(t, rejected, `reason="message from this party was already received"`)
+
+	// The duplicate did not prevent the protocol from finishing
+	for _, id := range signSet[1:] {
+		for _, msg := range msgs1 {
+			require.NoError(t, states[id].HandleMessage(msg))
+		}
+	}
+	require.NoError(t, runRounds(states))
+	require.NoError(t, s.WaitForError())
+	assert.Contains(t, logger.String(), `msg="round finished" round=2`)
+	assert.NotContains(t, logger.String(), "aborted")
+
+	// Setting a nil Logger disables logging
+	s.SetLogger(nil)
+	assert.Error(t, s.HandleMessage(duplicate))
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+// recordingObserver records all callbacks. Messages processed in a round are recorded in increasing order of sender.
+type recordingObserver struct {
+	events []string
+	round  []party.ID
+	errs   []error
+}
+
+func (o *recordingObserver) flush() {
+	sort.Slice(o.round, func(i, j int) bool { return o.round[i] < o.round[j] })
+	for _, id := range o.round {
+		o.events = append(o.events, fmt.Sprintf("message %d", id))
+	}
+	o.round = nil
+}
+
+func (o *recordingObserver) OnRoundStart(round int) {
+	o.flush()
+	o.events = append(o.events, fmt.Sprintf("round %d", round))
+}
+
+func (o *recordingObserver) OnMessageProcessed(from party.ID) {
+	o.round = append(o.round, from)
+}
+
+func (o *recordingObserver) OnError(err error) {
+	o.flush()
+	o.errs = append(o.errs, err)
+	o.events = append(o.events, "error")
+}
+
+func TestState_SetObserver(t *testing.T) {
+	_, signSet, secretShares, publicShares := setupParties(2, 5)
+
+	states := map[party.ID]*state.State{}
+	observers := map[party.ID]*recordingObserver{}
+	for _, id := range signSet {
+		var err error
+		states[id], _, err = frost.NewSignState(signSet, secretShares[id], publicShares, MESSAGE, 0)
+		require.NoError(t, err)
+		observers[id] = &recordingObserver{}
+		states[id].SetObserver(observers[id])
+	}
+	require.NoError(t, runRounds(states))
+
+	for id, o := range observers {
+		o.flush()
+		var expected []string
+		for round := 0; round < 3; round++ {
+			expected = append(expected, fmt.Sprintf("round %d", round))
+			if round == 0 {
+				continue
+			}
+			for _, other := range signSet {
+				if other != id {
+					expected = append(expected, fmt.Sprintf("message %d", other))
+				}
+			}
+		}
+		assert.Equal(t, expected, o.events, "party %d", id)
+		assert.Empty(t, o.errs)
+	}
+
+	// Errors are reported once
+	s, _, err := frost.NewSignState(signSet, secretShares[signSet[0]], publicShares, MESSAGE, 10*time.Millisecond)
+	require.NoError(t, err)
+	o := &recordingObserver{}
+	s.SetObserver(o)
+	assert.Error(t, s.WaitForError())
+	assert.Equal(t, []string{"error"}, o.events)
+	require.Len(t, o.errs, 1)
+	assert.ErrorIs(t, o.errs[0], state.ErrTimeout)
+}
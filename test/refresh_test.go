@@ -0,0 +1,148 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/refresh"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/sign"
+	"github.com/taurusgroup/frost-ed25519/pkg/helpers"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/ristretto"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+// runRounds feeds all messages generated in one round to all parties in the next, until all states are finished.
+func runRounds(states map[party.ID]*state.State) error {
+	var msgs [][]byte
+	for {
+		var next [][]byte
+		for _, s := range states {
+			out, err := helpers.PartyRoutine(msgs, s)
+			if err != nil {
+				return err
+			}
+			next = append(next, out...)
+		}
+		msgs = next
+
+		finished := true
+		for _, s := range states {
+			finished = finished && s.IsFinished()
+		}
+		if finished {
+			return nil
+		}
+	}
+}
+
+func runRefresh(t *testing.T, secretShares map[party.ID]*eddsa.SecretShare, public *eddsa.Public) (map[party.ID]*eddsa.SecretShare, *eddsa.Public) {
+	states := map[party.ID]*state.State{}
+	outputs := map[party.ID]*refresh.Output{}
+	for id, secret := range secretShares {
+		var err error
+		states[id], outputs[id], err = frost.NewRefreshState(secret, public, 0)
+		require.NoError(t, err)
+	}
+	require.NoError(t, runRounds(states))
+
+	newSecrets := map[party.ID]*eddsa.SecretShare{}
+	newPublic := outputs[public.PartyIDs[0]].Public
+	for id, s := range states {
+		require.NoError(t, s.WaitForError())
+		assert.True(t, newPublic.Equal(outputs[id].Public), "parties disagree on the refreshed public shares")
+		newSecrets[id] = outputs[id].SecretKey
+	}
+	return newSecrets, newPublic
+}
+
+func runSign(t *testing.T, signIDs party.IDSlice, secretShares map[party.ID]*eddsa.SecretShare, public *eddsa.Public, message []byte) *eddsa.Signature {
+	states := map[party.ID]*state.State{}
+	outputs := map[party.ID]*sign.Output{}
+	for _, id := range signIDs {
+		var err error
+		states[id], outputs[id], err = frost.NewSignState(signIDs, secretShares[id], public, message, 0)
+		require.NoError(t, err)
+	}
+	require.NoError(t, runRounds(states))
+	for _, s := range states {
+		require.NoError(t, s.WaitForError())
+	}
+	return outputs[signIDs[0]].Signature
+}
+
+func TestRefresh(t *testing.T) {
+	_, _, secretShares, public := setupParties(1, 3)
+	groupKey := public.GroupKey
+
+	for i := 0; i < 2; i++ {
+		previous := secretShares
+		secretShares, public = runRefresh(t, secretShares, public)
+
+		require.True(t, groupKey.Equal(public.GroupKey), "group key changed")
+		require.NoError(t, ValidateSecrets(secretShares, groupKey, public))
+		for id, secret := range secretShares {
+			assert.False(t, secret.Equal(previous[id]), "share of %d was not refreshed", id)
+		}
+
+		for _, signIDs := range []party.IDSlice{{1, 2}, {1, 3}, {2, 3}} {
+			sig := runSign(t, signIDs, secretShares, public, MESSAGE)
+			assert.True(t, ed25519.Verify(groupKey.ToEd25519(), MESSAGE, sig.ToEd25519()))
+		}
+	}
+}
+
+func TestRefresh_InvalidUpdate(t *testing.T) {
+	_, _, secretShares, public := setupParties(1, 3)
+
+	states := map[party.ID]*state.State{}
+	for id, secret := range secretShares {
+		var err error
+		states[id], _, err = frost.NewRefreshState(secret, public, 0)
+		require.NoError(t, err)
+	}
+
+	var msgs1, msgs2 [][]byte
+	for _, s := range states {
+		out, err := helpers.PartyRoutine(nil, s)
+		require.NoError(t, err)
+		msgs1 = append(msgs1, out...)
+	}
+	for _, s := range states {
+		out, err := helpers.PartyRoutine(msgs1, s)
+		require.NoError(t, err)
+		msgs2 = append(msgs2, out...)
+	}
+
+	// Party 1 sends an update to party 2 which is not consistent with its commitments
+	for i, data := range msgs2 {
+		var msg messages.Message
+		require.NoError(t, msg.UnmarshalBinary(data))
+		if msg.From == 1 && msg.To == 2 {
+			msg.Refresh2.Share.Add(&msg.Refresh2.Share, ristretto.NewScalar().SetUint64(1))
+			msgs2[i], _ = msg.MarshalBinary()
+		}
+	}
+
+	_, err := helpers.PartyRoutine(msgs2, states[2])
+	require.Error(t, err)
+	var stateErr *state.Error
+	require.ErrorAs(t, err, &stateErr)
+	assert.Equal(t, party.ID(1), stateErr.PartyID)
+}
+
+func TestNewRefreshState_MismatchedShare(t *testing.T) {
+	_, _, secretShares, public := setupParties(1, 3)
+	_, _, err := frost.NewRefreshState(secretShares[1], &eddsa.Public{
+		PartyIDs:  public.PartyIDs,
+		Threshold: public.Threshold,
+		Shares:    map[party.ID]*ristretto.Element{1: &secretShares[2].Public, 2: &secretShares[2].Public, 3: &secretShares[3].Public},
+		GroupKey:  public.GroupKey,
+	}, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/reshare"
+	"github.com/taurusgroup/frost-ed25519/pkg/helpers"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+func TestReshare(t *testing.T) {
+	// 2-of-3 with parties 1, 2, 3
+	_, _, secretShares, previous := setupParties(1, 3)
+	groupKey := previous.GroupKey
+
+	// 3-of-5, where 1 and 3 stay, 2 leaves, and 4, 5, 6 join
+	dealers := party.NewIDSlice([]party.ID{1, 2})
+	receivers := party.NewIDSlice([]party.ID{1, 3, 4, 5, 6})
+	threshold := party.Size(2)
+
+	states := map[party.ID]*state.State{}
+	outputs := map[party.ID]*reshare.Output{}
+	for _, id := range []party.ID{1, 2, 3, 4, 5, 6} {
+		var err error
+		states[id], outputs[id], err = frost.NewReshareState(id, secretShares[id], previous, dealers, receivers, threshold, 0)
+		require.NoError(t, err)
+	}
+	require.NoError(t, runRounds(states))
+
+	public := outputs[1].Public
+	newShares := map[party.ID]*eddsa.SecretShare{}
+	for id, s := range states {
+		require.NoError(t, s.WaitForError())
+		assert.True(t, public.Equal(outputs[id].Public), "parties disagree on the new public shares")
+		if receivers.Contains(id) {
+			require.NotNil(t, outputs[id].SecretKey)
+			newShares[id] = outputs[id].SecretKey
+		} else {
+			assert.Nil(t, outputs[id].SecretKey)
+		}
+	}
+
+	require.True(t, groupKey.Equal(public.GroupKey), "group key changed")
+	assert.Equal(t, threshold, public.Threshold)
+	assert.True(t, receivers.Equal(public.PartyIDs))
+	require.NoError(t, ValidateSecrets(newShares, groupKey, public))
+
+	sig := runSign(t, party.NewIDSlice([]party.ID{3, 4, 6}), newShares, public, MESSAGE)
+	assert.True(t, ed25519.Verify(groupKey.ToEd25519(), MESSAGE, sig.ToEd25519()))
+
+	// The old shares are not valid in the new access structure
+	for _, id := range []party.ID{1, 3} {
+		assert.NotEqual(t, 1, secretShares[id].Public.Equal(public.Shares[id]))
+	}
+	mixed := map[party.ID]*eddsa.SecretShare{1: secretShares[1], 3: newShares[3], 4: newShares[4]}
+	signIDs := party.NewIDSlice([]party.ID{1, 3, 4})
+	signStates := map[party.ID]*state.State{}
+	for _, id := range signIDs {
+		var err error
+		signStates[id], _, err = frost.NewSignState(signIDs, mixed[id], public, MESSAGE, 0)
+		require.NoError(t, err)
+	}
+	assert.Error(t, runRounds(signStates))
+}
+
+func TestReshare_InvalidCommitment(t *testing.T) {
+	_, _, secretShares, previous := setupParties(1, 3)
+	dealers := party.NewIDSlice([]party.ID{1, 2})
+	receivers := party.NewIDSlice([]party.ID{1, 2, 3})
+
+	states := map[party.ID]*state.State{}
+	for _, id := range receivers {
+		var err error
+		states[id], _, err = frost.NewReshareState(id, secretShares[id], previous, dealers, receivers, 1, 0)
+		require.NoError(t, err)
+	}
+
+	var msgs1 [][]byte
+	for _, id := range receivers {
+		out, err := helpers.PartyRoutine(nil, states[id])
+		require.NoError(t, err)
+		msgs1 = append(msgs1, out...)
+	}
+
+	// Dealer 2 sends the commitments of dealer 1, whose constant is not 2's share of the group key
+	var msg1, msg2 messages.Message
+	require.NoError(t, msg1.UnmarshalBinary(msgs1[0]))
+	require.NoError(t, msg2.UnmarshalBinary(msgs1[1]))
+	require.Equal(t, party.ID(1), msg1.From)
+	require.Equal(t, party.ID(2), msg2.From)
+	msg2.Reshare1 = msg1.Reshare1
+	forged, err := msg2.MarshalBinary()
+	require.NoError(t, err)
+
+	_, err = helpers.PartyRoutine([][]byte{msgs1[0], forged}, states[3])
+	require.Error(t, err)
+	var stateErr *state.Error
+	require.ErrorAs(t, err, &stateErr)
+	assert.Equal(t, party.ID(2), stateErr.PartyID)
+}
+
+func TestNewReshareState_Errors(t *testing.T) {
+	_, _, secretShares, previous := setupParties(1, 3)
+	receivers := party.NewIDSlice([]party.ID{1, 2, 3})
+
+	// Not enough dealers
+	_, _, err := frost.NewReshareState(1, secretShares[1], previous, party.IDSlice{1}, receivers, 1, 0)
+	assert.Error(t, err)
+
+	// A dealer must provide a share
+	_, _, err = frost.NewReshareState(1, nil, previous, party.IDSlice{1, 2}, receivers, 1, 0)
+	assert.Error(t, err)
+
+	// Threshold too large for the receivers
+	_, _, err = frost.NewReshareState(1, secretShares[1], previous, party.IDSlice{1, 2}, receivers, 3, 0)
+	assert.Error(t, err)
+
+	// Party is not part of the protocol
+	_, _, err = frost.NewReshareState(4, nil, previous, party.IDSlice{1, 2}, receivers, 1, 0)
+	assert.Error(t, err)
+
+	// Receivers are evaluation points of the new sharing
+	_, _, err = frost.NewReshareState(1, secretShares[1], previous, party.IDSlice{1, 2}, party.IDSlice{1, 2, 2, 3}, 1, 0)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "ID 2 appears more than once")
+	}
+	_, _, err = frost.NewReshareState(1, secretShares[1], previous, party.IDSlice{1, 2}, party.IDSlice{0, 1, 2, 3}, 1, 0)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "contain 0")
+	}
+}
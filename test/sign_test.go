@@ -2,15 +2,21 @@ package main
 
 import (
 	"bytes"
+	"crypto"
 	"crypto/ed25519"
+	"crypto/sha512"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/eddsa"
 	"github.com/taurusgroup/frost-ed25519/pkg/frost"
 	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
 	"github.com/taurusgroup/frost-ed25519/pkg/frost/sign"
 	"github.com/taurusgroup/frost-ed25519/pkg/helpers"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
 	"github.com/taurusgroup/frost-ed25519/pkg/state"
 )
 
@@ -99,3 +105,127 @@ func TestSign(t *testing.T) {
 		}
 	}
 }
+
+func TestSign_PublicKeyFromEd25519(t *testing.T) {
+	_, signSet, secretShares, publicShares := setupParties(2, 5)
+
+	states := map[party.ID]*state.State{}
+	outputs := map[party.ID]*sign.Output{}
+	for _, id := range signSet {
+		var err error
+		states[id], outputs[id], err = frost.NewSignState(signSet, secretShares[id], publicShares, MESSAGE, 0)
+		require.NoError(t, err)
+	}
+	require.NoError(t, runRounds(states))
+
+	sig := outputs[signSet[0]].Signature
+	require.NotNil(t, sig)
+
+	// A verifier only knows the standard encoding of the group key
+	edKey := publicShares.GroupKey.ToEd25519()
+	assert.True(t, ed25519.Verify(edKey, MESSAGE, sig.ToEd25519()))
+
+	pk, err := eddsa.PublicKeyFromEd25519(edKey)
+	require.NoError(t, err)
+	assert.True(t, pk.Equal(publicShares.GroupKey))
+	assert.True(t, pk.Verify(MESSAGE, sig))
+	assert.True(t, ed25519.Verify(pk.ToEd25519(), MESSAGE, sig.ToEd25519()))
+}
+
+func TestSign_FromReader(t *testing.T) {
+	_, signSet, secretShares, publicShares := setupParties(2, 5)
+	message := bytes.Repeat(MESSAGE, 1<<16)
+
+	states := map[party.ID]*state.State{}
+	outputs := map[party.ID]*sign.Output{}
+	for _, id := range signSet {
+		var err error
+		states[id], outputs[id], err = frost.NewSignStateFromReader(signSet, secretShares[id], publicShares, bytes.NewReader(message), 0)
+		require.NoError(t, err)
+	}
+	require.NoError(t, runRounds(states))
+
+	sig := outputs[signSet[0]].Signature
+	require.NotNil(t, sig)
+	digest := sha512.Sum512(message)
+	assert.NoError(t, ed25519.VerifyWithOptions(publicShares.GroupKey.ToEd25519(), digest[:], sig.ToEd25519(), &ed25519.Options{Hash: crypto.SHA512}))
+}
+
+func TestSign_Finalize(t *testing.T) {
+	signSet, states, outputs, msgs1 := newSignStates(t, 2, 4)
+	s, output := states[signSet[0]], outputs[signSet[0]]
+
+	// The commitments are missing
+	_, err := sign.Finalize(s, output)
+	var incompleteErr *state.IncompleteError
+	require.ErrorAs(t, err, &incompleteErr)
+	assert.ErrorIs(t, err, state.ErrIncomplete)
+	assert.Equal(t, signSet[1:], incompleteErr.Missing)
+
+	var msgs2 []*messages.Message
+	for _, id := range signSet {
+		for _, msg := range msgs1 {
+			require.NoError(t, states[id].HandleMessage(msg))
+		}
+		msgs2 = append(msgs2, states[id].ProcessAll()...)
+	}
+
+	// Only some signature shares were received
+	for _, msg := range msgs2 {
+		if msg.From == signSet[1] {
+			require.NoError(t, s.HandleMessage(msg))
+		}
+	}
+	assert.Empty(t, s.ProcessAll())
+	sig, err := sign.Finalize(s, output)
+	assert.Nil(t, sig)
+	require.ErrorAs(t, err, &incompleteErr)
+	assert.Equal(t, party.IDSlice{signSet[2]}, incompleteErr.Missing)
+	assert.Nil(t, output.Signature)
+
+	// All shares were received, but the round was not yet processed
+	for _, msg := range msgs2 {
+		if msg.From == signSet[2] {
+			require.NoError(t, s.HandleMessage(msg))
+		}
+	}
+	_, err = sign.Finalize(s, output)
+	require.ErrorAs(t, err, &incompleteErr)
+	assert.Empty(t, incompleteErr.Missing)
+
+	s.ProcessAll()
+	sig, err = sign.Finalize(s, output)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sig.Equal(output.Signature))
+
+	// An aborted session returns its error
+	s = states[signSet[1]]
+	s.Zeroize()
+	_, err = sign.Finalize(s, outputs[signSet[1]])
+	assert.ErrorIs(t, err, state.ErrZeroized)
+	assert.NotErrorIs(t, err, state.ErrIncomplete)
+}
+
+func TestSign_Tweak(t *testing.T) {
+	_, signSet, secretShares, publicShares := setupParties(2, 5)
+	tweak := eddsa.DeriveTweak(publicShares.GroupKey, "m/44'/0'", "account 7")
+	tweaked := publicShares.GroupKey.Tweak(tweak)
+
+	states := map[party.ID]*state.State{}
+	outputs := map[party.ID]*sign.Output{}
+	for _, id := range signSet {
+		var err error
+		states[id], outputs[id], err = frost.NewSignState(signSet, secretShares[id], publicShares, MESSAGE, 0, sign.WithTweak(tweak))
+		require.NoError(t, err)
+	}
+	require.NoError(t, runRounds(states))
+	for _, id := range signSet {
+		require.NoError(t, states[id].WaitForError())
+	}
+
+	sig := outputs[signSet[0]].Signature
+	require.NotNil(t, sig)
+	assert.True(t, tweaked.Verify(MESSAGE, sig))
+	assert.True(t, ed25519.Verify(tweaked.ToEd25519(), MESSAGE, sig.ToEd25519()))
+	assert.False(t, ed25519.Verify(publicShares.GroupKey.ToEd25519(), MESSAGE, sig.ToEd25519()), "the signature is not valid for the group key")
+}
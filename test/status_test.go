@@ -0,0 +1,81 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+func TestState_Status(t *testing.T) {
+	_, signSet, secretShares, publicShares := setupParties(2, 3)
+	states := map[party.ID]*state.State{}
+	for _, id := range signSet {
+		var err error
+		states[id], _, err = frost.NewSignState(signSet, secretShares[id], publicShares, MESSAGE, 0)
+		require.NoError(t, err)
+	}
+	s := states[1]
+
+	// checkStatus compares the status of s with the expected one, and returns it
+	checkStatus := func(stage string, round int, phase state.Phase, expected, received party.IDSlice) state.SessionStatus {
+		status := s.Status()
+		assert.Equal(t, round, status.Round, stage)
+		assert.Equal(t, phase, status.Phase, stage)
+		assert.Equal(t, expected, status.Expected, stage)
+		assert.Equal(t, received, status.Received, stage)
+		assert.Equal(t, phase == state.PhaseFinished || phase == state.PhaseAborted, status.Terminal, stage)
+		assert.GreaterOrEqual(t, status.Elapsed, status.RoundElapsed, stage)
+		return status
+	}
+	others := party.IDSlice{2, 3}
+
+	// The first round does not expect any message
+	checkStatus("round 0", 0, state.PhaseReady, nil, nil)
+	var msgs1 []*messages.Message
+	for _, id := range signSet {
+		msgs1 = append(msgs1, states[id].ProcessAll()...)
+	}
+
+	checkStatus("round 1, no commitments", 1, state.PhaseWaiting, others, party.IDSlice{})
+	require.NoError(t, s.HandleMessage(msgs1[1]))
+	checkStatus("round 1, one commitment", 1, state.PhaseWaiting, others, party.IDSlice{2})
+	require.NoError(t, s.HandleMessage(msgs1[2]))
+	checkStatus("round 1, all commitments", 1, state.PhaseReady, others, others)
+
+	var msgs2 []*messages.Message
+	for _, id := range signSet {
+		for _, msg := range msgs1 {
+			if id != 1 && msg.From != id {
+				require.NoError(t, states[id].HandleMessage(msg))
+			}
+		}
+		msgs2 = append(msgs2, states[id].ProcessAll()...)
+	}
+	checkStatus("round 2, no shares", 2, state.PhaseWaiting, others, party.IDSlice{})
+
+	for _, msg := range msgs2[1:] {
+		require.NoError(t, s.HandleMessage(msg))
+	}
+	checkStatus("round 2, all shares", 2, state.PhaseReady, others, others)
+	s.ProcessAll()
+	status := checkStatus("finished", 2, state.PhaseFinished, nil, nil)
+	assert.NoError(t, status.Err)
+
+	// The durations no longer increase once the protocol is terminal
+	time.Sleep(time.Millisecond)
+	assert.Equal(t, status.Elapsed, s.Status().Elapsed)
+
+	// An aborted protocol reports its error
+	states[2].Zeroize()
+	status = states[2].Status()
+	assert.Equal(t, state.PhaseAborted, status.Phase)
+	assert.True(t, status.Terminal)
+	assert.ErrorIs(t, status.Err, state.ErrZeroized)
+	assert.Equal(t, "aborted", status.Phase.String())
+}
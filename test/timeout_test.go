@@ -0,0 +1,196 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/party"
+	"github.com/taurusgroup/frost-ed25519/pkg/frost/sign"
+	"github.com/taurusgroup/frost-ed25519/pkg/messages"
+	"github.com/taurusgroup/frost-ed25519/pkg/state"
+)
+
+func TestState_SetRoundTimeout(t *testing.T) {
+	_, signIDs, secretShares, publicShares := setupParties(1, 3)
+
+	states := make([]*state.State, 0, len(signIDs))
+	for _, id := range signIDs {
+		s, _, err := frost.NewSignState(signIDs, secretShares[id], publicShares, MESSAGE, 0)
+		require.NoError(t, err)
+		// The commitment round may take long, but the response round must be fast.
+		s.SetRoundTimeout(1, time.Minute)
+		s.SetRoundTimeout(2, 20*time.Millisecond)
+		states = append(states, s)
+	}
+
+	var msgs1 []*messages.Message
+	for _, s := range states {
+		msgs1 = append(msgs1, s.ProcessAll()...)
+	}
+
+	// Waiting longer than the timeout of round 2 in round 1 is fine
+	time.Sleep(50 * time.Millisecond)
+	for _, s := range states {
+		require.False(t, s.IsFinished(), "round 1 timed out")
+	}
+
+	// Party 1 moves to round 2, but never receives the signature share of party 2.
+	s := states[0]
+	for _, msg := range msgs1[1:] {
+		require.NoError(t, s.HandleMessage(msg))
+	}
+	require.Len(t, s.ProcessAll(), 1)
+
+	start := time.Now()
+	err := s.WaitForError()
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+	assert.ErrorIs(t, err, state.ErrTimeout)
+
+	var stateErr *state.Error
+	require.ErrorAs(t, err, &stateErr)
+	assert.Equal(t, 2, stateErr.RoundNumber)
+	assert.Equal(t, party.ID(0), stateErr.PartyID)
+
+	// Party 2 is still in round 1
+	assert.False(t, states[1].IsFinished())
+}
+
+func TestState_SetRoundTimeout_Default(t *testing.T) {
+	_, signIDs, secretShares, publicShares := setupParties(1, 3)
+
+	// The default timeout is used in round 1, since only round 2 has a specific one.
+	s, _, err := frost.NewSignState(signIDs, secretShares[signIDs[0]], publicShares, MESSAGE, 20*time.Millisecond)
+	require.NoError(t, err)
+	s.SetRoundTimeout(2, time.Minute)
+	s.ProcessAll()
+
+	err = s.WaitForError()
+	assert.ErrorIs(t, err, state.ErrTimeout)
+	var stateErr *state.Error
+	require.ErrorAs(t, err, &stateErr)
+	assert.Equal(t, 1, stateErr.RoundNumber)
+}
+
+func TestState_TimeoutError(t *testing.T) {
+	_, signIDs, secretShares, publicShares := setupParties(2, 4)
+
+	states := make([]*state.State, 0, len(signIDs))
+	for _, id := range signIDs {
+		s, _, err := frost.NewSignState(signIDs, secretShares[id], publicShares, MESSAGE, 0)
+		require.NoError(t, err)
+		s.SetRoundTimeout(2, 20*time.Millisecond)
+		states = append(states, s)
+	}
+
+	var msgs1, msgs2 []*messages.Message
+	for _, s := range states {
+		msgs1 = append(msgs1, s.ProcessAll()...)
+	}
+	for _, s := range states {
+		for _, msg := range msgs1 {
+			require.NoError(t, s.HandleMessage(msg))
+		}
+		msgs2 = append(msgs2, s.ProcessAll()...)
+	}
+
+	// Party 1 only receives the signature share of party 3, and the other signers are stragglers
+	s := states[0]
+	for _, msg := range msgs2 {
+		if msg.From == 3 {
+			require.NoError(t, s.HandleMessage(msg))
+		}
+	}
+	assert.Nil(t, s.ProcessAll())
+
+	err := s.WaitForError()
+	require.ErrorIs(t, err, state.ErrTimeout)
+	var timeoutErr *state.TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, 2, timeoutErr.Round)
+	assert.Equal(t, party.IDSlice{3}, timeoutErr.Received)
+	assert.Equal(t, party.IDSlice{2}, timeoutErr.Missing)
+}
+
+func TestRestartSignState(t *testing.T) {
+	partyIDs, _, secretShares, publicShares := setupParties(2, 5)
+	quorum := partyIDs[:4]
+
+	states := make(map[party.ID]*state.State, len(quorum))
+	for _, id := range quorum {
+		s, _, err := frost.NewSignState(quorum, secretShares[id], publicShares, MESSAGE, 0)
+		require.NoError(t, err)
+		s.SetRoundTimeout(2, 20*time.Millisecond)
+		states[id] = s
+	}
+
+	var msgs1, msgs2 []*messages.Message
+	for _, id := range quorum {
+		msgs1 = append(msgs1, states[id].ProcessAll()...)
+	}
+	for _, id := range quorum {
+		for _, msg := range msgs1 {
+			require.NoError(t, states[id].HandleMessage(msg))
+		}
+		// Party 4 committed, but drops out before sending its signature share
+		if id != 4 {
+			msgs2 = append(msgs2, states[id].ProcessAll()...)
+		}
+	}
+	for _, id := range quorum[:3] {
+		for _, msg := range msgs2 {
+			require.NoError(t, states[id].HandleMessage(msg))
+		}
+		assert.Nil(t, states[id].ProcessAll())
+	}
+
+	// Party 1 coordinates the restart with the parties it received a share from
+	err := states[1].WaitForError()
+	var timeoutErr *state.TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, party.IDSlice{4}, timeoutErr.Missing)
+	responders := append(timeoutErr.Received.Copy(), 1)
+
+	restarted := make(map[party.ID]*state.State, len(responders))
+	outputs := make(map[party.ID]*sign.Output, len(responders))
+	for _, id := range responders {
+		restarted[id], outputs[id], err = frost.RestartSignState(quorum, responders, secretShares[id], publicShares, MESSAGE, 0)
+		require.NoError(t, err)
+	}
+
+	var restartedMsgs1 []*messages.Message
+	for _, id := range responders {
+		restartedMsgs1 = append(restartedMsgs1, restarted[id].ProcessAll()...)
+	}
+	// The new session uses fresh nonces
+	for _, old := range msgs1 {
+		for _, msg := range restartedMsgs1 {
+			assert.Equal(t, 0, old.Sign1.Di.Equal(&msg.Sign1.Di))
+			assert.Equal(t, 0, old.Sign1.Ei.Equal(&msg.Sign1.Ei))
+		}
+	}
+	var restartedMsgs2 []*messages.Message
+	for _, id := range responders {
+		for _, msg := range restartedMsgs1 {
+			require.NoError(t, restarted[id].HandleMessage(msg))
+		}
+		restartedMsgs2 = append(restartedMsgs2, restarted[id].ProcessAll()...)
+	}
+	for _, id := range responders {
+		for _, msg := range restartedMsgs2 {
+			require.NoError(t, restarted[id].HandleMessage(msg))
+		}
+		restarted[id].ProcessAll()
+		require.NoError(t, restarted[id].WaitForError())
+		assert.True(t, publicShares.GroupKey.Verify(MESSAGE, outputs[id].Signature))
+	}
+
+	_, _, err = frost.RestartSignState(quorum, party.IDSlice{1, 2, 5}, secretShares[1], publicShares, MESSAGE, 0)
+	assert.Error(t, err, "party 5 was not in the quorum")
+	_, _, err = frost.RestartSignState(quorum, party.IDSlice{2, 3, 4}, secretShares[1], publicShares, MESSAGE, 0)
+	assert.Error(t, err, "party 1 is not a responder")
+	_, _, err = frost.RestartSignState(quorum, party.IDSlice{1, 2}, secretShares[1], publicShares, MESSAGE, 0)
+	assert.Error(t, err, "the responders are not enough to sign")
+}